@@ -24,3 +24,36 @@ func BuildRefreshTokenKey(userID int32) string {
 func BuildTokenBlacklistKey(tokenStr string) string {
 	return consts.TokenBlacklistCachePrefix + tokenStr
 }
+
+func BuildJobLockKey(jobName string) string {
+	return consts.JobLockCachePrefix + jobName
+}
+
+func BuildPostLikeKey(postID int32, visitorID string) string {
+	return consts.PostLikeCachePrefix + strconv.Itoa(int(postID)) + "_" + visitorID
+}
+
+func BuildPostReactionKey(postID int32, reaction string, visitorID string) string {
+	return consts.PostReactionCachePrefix + strconv.Itoa(int(postID)) + "_" + reaction + "_" + visitorID
+}
+
+func BuildReactionRateLimitKey(visitorID string) string {
+	return consts.ReactionRateLimitCachePrefix + visitorID
+}
+
+// BuildUnlockFailureKey target 为解锁令牌的值，区分文章和分类
+func BuildUnlockFailureKey(target string, clientIP string) string {
+	return consts.UnlockFailureCachePrefix + target + "_" + clientIP
+}
+
+func BuildPostVisitKey(postID int32, visitorID string) string {
+	return consts.PostVisitCachePrefix + strconv.Itoa(int(postID)) + "_" + visitorID
+}
+
+func BuildPostRelatedKey(version int64, postID int32) string {
+	return consts.PostRelatedCachePrefix + strconv.FormatInt(version, 10) + "_" + strconv.Itoa(int(postID))
+}
+
+func BuildSitemapKey(version int64, name string) string {
+	return consts.SitemapCachePrefix + strconv.FormatInt(version, 10) + "_" + name
+}
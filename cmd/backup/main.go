@@ -0,0 +1,51 @@
+package main
+
+import (
+	"context"
+	"dash/injection"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+)
+
+// 备份所有数据和上传目录，或从备份文件恢复
+// go run ./cmd/backup -config=conf/config.yaml
+// go run ./cmd/backup -config=conf/config.yaml -restore=./backup/dash-backup-xxx.zip
+var (
+	restore = flag.String("restore", "", "backup zip file to restore, the current data will be replaced")
+	keep    = flag.Int("keep", 0, "keep only the newest N backups after backup, 0 means keep all")
+)
+
+func main() {
+	// flag.Parse 在 config.NewConfig 中调用
+	command := injection.NewBackupCommand()
+	ctx := context.Background()
+	if *restore != "" {
+		result, err := command.BackupService.Restore(ctx, *restore)
+		if err != nil {
+			fmt.Fprintln(os.Stderr, "restore failed:", err)
+			os.Exit(1)
+		}
+		for table, count := range result.Tables {
+			fmt.Printf("%-20s %d\n", table, count)
+		}
+		fmt.Printf("restored backup version %d, %d upload files\n", result.Version, result.Uploads)
+		return
+	}
+
+	file, err := command.BackupService.Backup(ctx)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "backup failed:", err)
+		os.Exit(1)
+	}
+	fmt.Printf("backup %s (%d bytes)\n", filepath.Join(command.Config.Dash.Backup.Dir, file.Filename), file.Size)
+	count, err := command.BackupService.CleanBackups(ctx, *keep)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "clean backups failed:", err)
+		os.Exit(1)
+	}
+	if count > 0 {
+		fmt.Printf("deleted %d old backups\n", count)
+	}
+}
@@ -34,6 +34,15 @@ func main() {
 		g.GenerateModel("tag"),
 		g.GenerateModel("theme_setting"),
 		g.GenerateModel("user", gen.FieldType("mfa_type", "consts.MFAType")),
+		g.GenerateModel("post_revision", gen.FieldType("editor_type", "consts.EditorType")),
+		g.GenerateModel("post_meta"),
+		g.GenerateModel("post_reaction"),
+		g.GenerateModel("post_search_index"),
+		g.GenerateModel("slug_history", gen.FieldType("type", "consts.SlugType")),
+		g.GenerateModel("recycle_item", gen.FieldType("type", "consts.RecycleType")),
+		g.GenerateModel("series"),
+		g.GenerateModel("series_post"),
+		g.GenerateModel("post_draft", gen.FieldType("editor_type", "consts.EditorType")),
 	)
 	g.Execute()
 }
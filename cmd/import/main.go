@@ -0,0 +1,61 @@
+package main
+
+import (
+	"context"
+	"dash/injection"
+	"flag"
+	"fmt"
+	"os"
+)
+
+// 从 Hexo/Hugo/Jekyll 导入 Markdown 文章，或导入 WordPress 导出的 xml 文件
+// go run ./cmd/import -config=conf/config.yaml -src=./source/_posts -dry-run
+// go run ./cmd/import -config=conf/config.yaml -format=wordpress -src=./wordpress.xml
+var (
+	src    = flag.String("src", "", "zip file, directory or markdown file to import")
+	format = flag.String("format", "markdown", "import format: markdown or wordpress")
+	dryRun = flag.Bool("dry-run", false, "only preview the import result")
+)
+
+func main() {
+	// flag.Parse 在 config.NewConfig 中调用
+	command := injection.NewImportCommand()
+	if *src == "" {
+		fmt.Fprintln(os.Stderr, "-src is required")
+		os.Exit(2)
+	}
+	importFn := command.ImportService.ImportMarkdown
+	switch *format {
+	case "markdown":
+	case "wordpress":
+		importFn = command.ImportService.ImportWordPress
+	default:
+		fmt.Fprintln(os.Stderr, "unknown -format", *format)
+		os.Exit(2)
+	}
+	result, err := importFn(context.Background(), *src, *dryRun)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "import failed:", err)
+		os.Exit(1)
+	}
+	for _, item := range result.Items {
+		if item.Error != "" {
+			fmt.Printf("FAIL %s: %s\n", item.File, item.Error)
+			continue
+		}
+		fmt.Printf("OK   %s -> %s (%s)\n", item.File, item.Slug, item.Title)
+		if item.OldURL != "" && item.NewURL != "" {
+			fmt.Printf("     redirect %s -> %s\n", item.OldURL, item.NewURL)
+		}
+	}
+	if len(result.CreatedTags) > 0 {
+		fmt.Printf("new tags: %v\n", result.CreatedTags)
+	}
+	if len(result.CreatedCategories) > 0 {
+		fmt.Printf("new categories: %v\n", result.CreatedCategories)
+	}
+	fmt.Printf("total %d, imported %d, failed %d, skipped %d, dry run %v\n", result.Total, result.Imported, result.Failed, result.Skipped, result.DryRun)
+	if result.Failed > 0 {
+		os.Exit(1)
+	}
+}
@@ -1,6 +1,7 @@
 package config
 
 import (
+	"dash/consts"
 	"dash/utils"
 	"flag"
 	"fmt"
@@ -31,6 +32,9 @@ func NewConfig() *Config {
 	}
 	// 设置管理员路由
 	viper.SetDefault("dash.admin_url_path", "admin")
+	viper.SetDefault("dash.visit_flush_interval", "60s")
+	viper.SetDefault("dash.backup.interval", "24h")
+	viper.SetDefault("dash.backup.keep", 7)
 	// 读取配置文件并解析到conf结构体中
 	conf := &Config{}
 	if err := viper.ReadInConfig(); err != nil {
@@ -69,6 +73,7 @@ func NewConfig() *Config {
 	}
 
 	normalizeDir(&conf.Dash.LogDir, "log")
+	normalizeDir(&conf.Dash.Backup.Dir, consts.DashBackupDir)
 	// normalizeDir(&conf.Dash.UploadDir, consts.DashUploadDir)
 	// 查看sqlite是否启用，如果启用还需要创建sqliteDB
 	if conf.SQLite3 != nil && conf.SQLite3.Enable {
@@ -43,6 +43,8 @@ type SQLite3 struct {
 type Server struct {
 	Host string `mapstructure:"host" json:"host"`
 	Port string `mapstructure:"port" json:"port"`
+	// TrustedProxies 可信的反向代理 IP 或 CIDR，只有来自这些地址的 X-Forwarded-For 才会被用作客户端 IP，为空时直接使用连接的来源地址
+	TrustedProxies []string `mapstructure:"trusted_proxies" json:"trusted_proxies"`
 }
 
 type Log struct {
@@ -75,4 +77,15 @@ type Dash struct {
 	ThemeDir          string
 	AdminResourcesDir string
 	AdminURLPath      string `mapstructure:"admin_url_path"`
+	// VisitFlushInterval 访问量从 Redis 写入数据库的间隔
+	VisitFlushInterval time.Duration `mapstructure:"visit_flush_interval"`
+	Backup             Backup        `mapstructure:"backup"`
+}
+
+// Backup 定时备份配置，Dir 同时也是手动备份文件的保存目录
+type Backup struct {
+	Enable   bool          `mapstructure:"enable"`
+	Interval time.Duration `mapstructure:"interval"`
+	Dir      string        `mapstructure:"dir"`
+	Keep     int           `mapstructure:"keep"` // 定时备份后只保留最新的 Keep 个备份文件，小于等于 0 时不清理
 }
@@ -2,6 +2,8 @@ package consts
 
 const (
 	DashUploadDir       = "upload"  //默认附件上传路径
+	DashExportDir       = "export"  // 导出文件的保存路径，位于工作目录下
+	DashBackupDir       = "backup"  // 默认备份文件保存路径，位于工作目录下
 	DashDefaultTagColor = "#cfd3d7" // 默认标签颜色
 )
 
@@ -13,6 +15,30 @@ const (
 	TokenBlacklistCachePrefix = "token_blacklist_"
 	OneTimeTokenQueryName     = "ott"
 
+	JobLockCachePrefix = "job_lock_"
+
+	PostLikeCachePrefix          = "post_like_"
+	PostReactionCachePrefix      = "post_reaction_"
+	ReactionRateLimitCachePrefix = "reaction_rate_limit_"
+
+	PostRelatedCachePrefix = "post_related_"
+	PostRelatedVersionKey  = "post_related_version" // 文章变化时递增，使所有相关文章缓存失效
+
+	ContentVersionKey  = "content_version" // 文章、页面、分类、标签或设置变化时递增，使站点地图等按内容生成的缓存失效
+	SitemapCachePrefix = "sitemap_"
+
+	PostVisitCachePrefix = "post_visit_"
+	PostVisitBufferKey   = "post_visit_buffer"   // 未写入数据库的访问量，hash 结构，field 为文章 ID
+	PostVisitFlushingKey = "post_visit_flushing" // 正在写入数据库的访问量
+
 	AdminTokenHeaderName = "Authorization"
 	AuthorizedUser       = "authorized_user"
 )
+
+const (
+	PostUnlockTokenHeaderName = "X-Post-Unlock-Token"
+	PostUnlockTokenQueryName  = "unlock_token"
+	PostUnlockTokenPrefix     = "post_unlock_"
+	CategoryUnlockTokenPrefix = "category_unlock_"
+	UnlockFailureCachePrefix  = "unlock_failure_" // 密码错误次数，按解锁对象和客户端 IP 计数
+)
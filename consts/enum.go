@@ -417,6 +417,9 @@ func (c CommentStatus) Ptr() *CommentStatus {
 	return &c
 }
 
+// PostPermalinkType 文章链接格式，以 slug=my-post、后缀 .html 为例：
+// DEFAULT /archives/my-post.html，DATE /2025/03/my-post.html，DAY /2025/03/14/my-post.html，
+// ID /archives/42.html，YEAR /2025/my-post.html，ID_SLUG /archives/42/my-post.html
 type PostPermalinkType string
 
 const (
@@ -561,6 +564,75 @@ func (p PostType) Value() (driver.Value, error) {
 	return int64(p), nil
 }
 
+// SlugType slug 历史记录所属的对象，文章和页面共用 post 表，都记为 SlugTypePost
+type SlugType int32
+
+const (
+	SlugTypePost SlugType = iota
+	SlugTypeCategory
+	SlugTypeTag
+)
+
+func (s SlugType) Ptr() *SlugType {
+	return &s
+}
+
+func (s *SlugType) Scan(src interface{}) error {
+	if src == nil {
+		return xerr.BadParam.New("").WithMsg("field nil")
+	}
+	switch data := src.(type) {
+	case int64:
+		*s = SlugType(data)
+	case int32:
+		*s = SlugType(data)
+	case int:
+		*s = SlugType(data)
+	default:
+		return xerr.BadParam.New("").WithMsg("bad type")
+	}
+	return nil
+}
+
+func (s SlugType) Value() (driver.Value, error) {
+	return int64(s), nil
+}
+
+// RecycleType 回收站中对象的类型
+type RecycleType int32
+
+const (
+	RecycleTypePost RecycleType = iota
+	RecycleTypeSheet
+	RecycleTypeCategory
+	RecycleTypeTag
+)
+
+func (r RecycleType) Ptr() *RecycleType {
+	return &r
+}
+
+func (r *RecycleType) Scan(src interface{}) error {
+	if src == nil {
+		return xerr.BadParam.New("").WithMsg("field nil")
+	}
+	switch data := src.(type) {
+	case int64:
+		*r = RecycleType(data)
+	case int32:
+		*r = RecycleType(data)
+	case int:
+		*r = RecycleType(data)
+	default:
+		return xerr.BadParam.New("").WithMsg("bad type")
+	}
+	return nil
+}
+
+func (r RecycleType) Value() (driver.Value, error) {
+	return int64(r), nil
+}
+
 type CommentType int32
 
 const (
@@ -590,6 +662,7 @@ func (ct CommentType) Value() (driver.Value, error) {
 	return int64(ct), nil
 }
 
+// SheetPermaLinkType 页面链接格式，SECONDARY /s/about.html，ROOT /about.html
 type SheetPermaLinkType string
 
 const (
@@ -1016,3 +1089,12 @@ func (c CategoryType) Value() (driver.Value, error) {
 func (c CategoryType) Ptr() *CategoryType {
 	return &c
 }
+
+// FeedFormat 订阅格式，RSS /feed.xml，ATOM /atom.xml，JSON /feed.json
+type FeedFormat string
+
+const (
+	FeedFormatRSS  FeedFormat = "RSS"
+	FeedFormatAtom FeedFormat = "ATOM"
+	FeedFormatJSON FeedFormat = "JSON"
+)
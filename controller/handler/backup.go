@@ -0,0 +1,84 @@
+package handler
+
+import (
+	"dash/consts"
+	"dash/model/dto"
+	"dash/service"
+	"dash/utils/xerr"
+	"net/url"
+	"os"
+	"path/filepath"
+
+	"github.com/gin-gonic/gin"
+)
+
+const backupDownloadPath = "/api/admin/backups/"
+
+type BackupHandler struct {
+	BackupService       service.BackupService
+	OneTimeTokenService service.OneTimeTokenService
+}
+
+func NewBackupHandler(backupService service.BackupService, oneTimeTokenService service.OneTimeTokenService) *BackupHandler {
+	return &BackupHandler{
+		BackupService:       backupService,
+		OneTimeTokenService: oneTimeTokenService,
+	}
+}
+
+func (b *BackupHandler) ListBackups(ctx *gin.Context) (interface{}, error) {
+	return b.BackupService.ListBackups(ctx)
+}
+
+// CreateBackup 立即备份，返回带一次性 token 的下载链接
+func (b *BackupHandler) CreateBackup(ctx *gin.Context) (interface{}, error) {
+	backup, err := b.BackupService.Backup(ctx)
+	if err != nil {
+		return nil, err
+	}
+	downloadPath := backupDownloadPath + backup.Filename
+	token := b.OneTimeTokenService.Create(downloadPath)
+	backup.DownloadURL = downloadPath + "?" + url.Values{consts.OneTimeTokenQueryName: {token}}.Encode()
+	return backup, nil
+}
+
+func (b *BackupHandler) DownloadBackup(ctx *gin.Context) (interface{}, error) {
+	filename := ctx.Param("filename")
+	path, err := b.BackupService.GetBackupFilePath(ctx, filename)
+	if err != nil {
+		return nil, err
+	}
+	return &dto.FileDownload{Path: path, Filename: filename}, nil
+}
+
+func (b *BackupHandler) DeleteBackup(ctx *gin.Context) (interface{}, error) {
+	return nil, b.BackupService.DeleteBackup(ctx, ctx.Param("filename"))
+}
+
+// RestoreBackup 从备份目录中已有的备份恢复
+func (b *BackupHandler) RestoreBackup(ctx *gin.Context) (interface{}, error) {
+	path, err := b.BackupService.GetBackupFilePath(ctx, ctx.Param("filename"))
+	if err != nil {
+		return nil, err
+	}
+	return b.BackupService.Restore(ctx, path)
+}
+
+// RestoreUploadedBackup 上传备份文件并恢复
+func (b *BackupHandler) RestoreUploadedBackup(ctx *gin.Context) (interface{}, error) {
+	fileHeader, err := ctx.FormFile("file")
+	if err != nil {
+		return nil, xerr.WithStatus(err, xerr.StatusBadRequest).WithMsg("file is required")
+	}
+	tempDir, err := os.MkdirTemp("", "dash-upload-*")
+	if err != nil {
+		return nil, xerr.NoType.Wrap(err).WithMsg("create temp dir failed")
+	}
+	defer os.RemoveAll(tempDir)
+	src := filepath.Join(tempDir, "backup.zip")
+	err = ctx.SaveUploadedFile(fileHeader, src)
+	if err != nil {
+		return nil, xerr.NoType.Wrap(err).WithMsg("save upload file failed")
+	}
+	return b.BackupService.Restore(ctx, src)
+}
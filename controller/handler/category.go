@@ -3,6 +3,7 @@ package handler
 import (
 	"dash/consts"
 	"dash/model/dto"
+	"dash/model/entity"
 	"dash/model/param"
 	"dash/model/property"
 	"dash/model/vo"
@@ -21,15 +22,21 @@ type CategoryHandler struct {
 	CategoryService     service.CategoryService
 	PostService         service.PostService
 	PostCategoryService service.PostCategoryService
+	VisibilityService   service.VisibilityService
+	SlugHistoryService  service.SlugHistoryService
+	RecycleService      service.RecycleService
 	PostAssembler       assembler.PostAssembler
 }
 
-func NewCategoryHandler(optionService service.OptionService, categoryService service.CategoryService, postService service.PostService, postCategoryService service.PostCategoryService, postAssembler assembler.PostAssembler) *CategoryHandler {
+func NewCategoryHandler(optionService service.OptionService, categoryService service.CategoryService, postService service.PostService, postCategoryService service.PostCategoryService, visibilityService service.VisibilityService, slugHistoryService service.SlugHistoryService, recycleService service.RecycleService, postAssembler assembler.PostAssembler) *CategoryHandler {
 	return &CategoryHandler{
 		OptionService:       optionService,
 		CategoryService:     categoryService,
 		PostService:         postService,
 		PostCategoryService: postCategoryService,
+		VisibilityService:   visibilityService,
+		SlugHistoryService:  slugHistoryService,
+		RecycleService:      recycleService,
 		PostAssembler:       postAssembler,
 	}
 }
@@ -66,7 +73,21 @@ func (c *CategoryHandler) ListCategoriesWithPosts(ctx *gin.Context) (interface{}
 	if err != nil {
 		return nil, err
 	}
-	categoryDTOs, err := c.CategoryService.ConvertToCategoryDTOs(ctx, categories)
+	hiddenCategoryIDs, err := c.VisibilityService.ListHiddenCategoryIDs(ctx, getUnlockTokens(ctx))
+	if err != nil {
+		return nil, err
+	}
+	hiddenCategoryIDSet := make(map[int32]struct{}, len(hiddenCategoryIDs))
+	for _, id := range hiddenCategoryIDs {
+		hiddenCategoryIDSet[id] = struct{}{}
+	}
+	visibleCategories := make([]*entity.Category, 0, len(categories))
+	for _, category := range categories {
+		if _, ok := hiddenCategoryIDSet[category.ID]; !ok {
+			visibleCategories = append(visibleCategories, category)
+		}
+	}
+	categoryDTOs, err := c.CategoryService.ConvertToCategoryDTOs(ctx, visibleCategories)
 	if err != nil {
 		return nil, err
 	}
@@ -77,6 +98,11 @@ func (c *CategoryHandler) ListCategoriesWithPosts(ctx *gin.Context) (interface{}
 		if err != nil {
 			return nil, err
 		}
+		// a post may also belong to a hidden category
+		posts, err = filterHiddenPosts(ctx, c.PostCategoryService, hiddenCategoryIDs, posts)
+		if err != nil {
+			return nil, err
+		}
 		postVOs, err := c.PostAssembler.ConvertToPostVOs(ctx, posts)
 		if err != nil {
 			return nil, err
@@ -100,9 +126,24 @@ func (c *CategoryHandler) ListPostsByCategorySlug(ctx *gin.Context) (interface{}
 	}
 	pageSize := c.OptionService.GetOrByDefault(ctx, property.CategoryPageSize).(int)
 	category, err := c.CategoryService.GetCategoryBySlug(ctx, slug)
+	if xerr.GetType(err) == xerr.NoRecord {
+		return c.redirectOldSlug(ctx, slug, err)
+	}
 	if err != nil {
 		return "", err
 	}
+	if !c.VisibilityService.IsAdmin(ctx) {
+		if category.Type == consts.CategoryTypeIntimate {
+			return nil, xerr.NoRecord.New("private category id=%v", category.ID).WithMsg("The resource does not exist or has been deleted").WithStatus(xerr.StatusNotFound)
+		}
+		if !c.CategoryService.IsCategoryUnlocked(ctx, category, getUnlockTokens(ctx)) {
+			return nil, xerr.Forbidden.New("").WithMsg("category password required").WithStatus(xerr.StatusForbidden)
+		}
+	}
+	hiddenCategoryIDs, err := c.VisibilityService.ListHiddenCategoryIDs(ctx, getUnlockTokens(ctx))
+	if err != nil {
+		return nil, err
+	}
 	id := category.ID
 	pageQuery := param.PostQuery{
 		Page: param.Page{
@@ -113,7 +154,8 @@ func (c *CategoryHandler) ListPostsByCategorySlug(ctx *gin.Context) (interface{}
 		Sort: &param.Sort{
 			Fields: []string{"create_time,desc"},
 		},
-		Statuses: []*consts.PostStatus{consts.PostStatusPublished.Ptr()},
+		Statuses:           []*consts.PostStatus{consts.PostStatusPublished.Ptr()},
+		ExcludeCategoryIDs: hiddenCategoryIDs,
 	}
 	posts, totalPage, err := c.PostService.Page(ctx, pageQuery)
 	if err != nil {
@@ -130,6 +172,43 @@ func (c *CategoryHandler) ListPostsByCategorySlug(ctx *gin.Context) (interface{}
 	return postPage, nil
 }
 
+// redirectOldSlug 分类改过 slug 时，旧 slug 跳转到分类现在的地址，没有历史记录时返回 notFoundErr
+func (c *CategoryHandler) redirectOldSlug(ctx *gin.Context, slug string, notFoundErr error) (interface{}, error) {
+	categoryID, err := c.SlugHistoryService.GetTargetID(ctx, consts.SlugTypeCategory, slug)
+	if err != nil {
+		return nil, notFoundErr
+	}
+	category, err := c.CategoryService.GetCategoryByID(ctx, categoryID)
+	if err != nil {
+		return nil, notFoundErr
+	}
+	if category.Type == consts.CategoryTypeIntimate && !c.VisibilityService.IsAdmin(ctx) {
+		return nil, notFoundErr
+	}
+	categoryDTO, err := c.CategoryService.ConvertToCategoryDTO(ctx, category)
+	if err != nil {
+		return nil, err
+	}
+	return &dto.Redirect{Location: categoryDTO.FullPath, Slug: category.Slug}, nil
+}
+
+func (c *CategoryHandler) UnlockCategory(ctx *gin.Context) (interface{}, error) {
+	slug, err := utils.ParamString(ctx, "slug")
+	if err != nil {
+		return nil, err
+	}
+	unlockParam := &param.Unlock{}
+	err = ctx.ShouldBindJSON(unlockParam)
+	if err != nil {
+		return nil, xerr.WithStatus(err, xerr.StatusBadRequest).WithMsg("parameter error")
+	}
+	category, err := c.CategoryService.GetCategoryBySlug(ctx, slug)
+	if err != nil {
+		return nil, err
+	}
+	return c.CategoryService.UnlockCategory(ctx, category, unlockParam.Password, ctx.ClientIP())
+}
+
 func (c *CategoryHandler) GetCategoryByID(ctx *gin.Context) (interface{}, error) {
 	id, err := utils.ParamInt32(ctx, "id")
 	if err != nil {
@@ -185,5 +264,8 @@ func (c *CategoryHandler) DeleteCategory(ctx *gin.Context) (interface{}, error)
 	if err != nil {
 		return nil, err
 	}
+	if c.OptionService.GetOrByDefault(ctx, property.RecycleCategoriesAndTags).(bool) {
+		return nil, c.RecycleService.RecycleCategory(ctx, categoryID)
+	}
 	return nil, c.CategoryService.DeleteByID(ctx, categoryID)
 }
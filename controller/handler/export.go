@@ -0,0 +1,45 @@
+package handler
+
+import (
+	"dash/consts"
+	"dash/model/dto"
+	"dash/service"
+	"net/url"
+
+	"github.com/gin-gonic/gin"
+)
+
+const markdownExportDownloadPath = "/api/admin/export/markdown/"
+
+type ExportHandler struct {
+	ExportService       service.ExportService
+	OneTimeTokenService service.OneTimeTokenService
+}
+
+func NewExportHandler(exportService service.ExportService, oneTimeTokenService service.OneTimeTokenService) *ExportHandler {
+	return &ExportHandler{
+		ExportService:       exportService,
+		OneTimeTokenService: oneTimeTokenService,
+	}
+}
+
+// ExportMarkdown 生成导出文件，返回带一次性 token 的下载链接，浏览器可以直接打开下载
+func (e *ExportHandler) ExportMarkdown(ctx *gin.Context) (interface{}, error) {
+	exportFile, err := e.ExportService.ExportMarkdown(ctx)
+	if err != nil {
+		return nil, err
+	}
+	downloadPath := markdownExportDownloadPath + exportFile.Filename
+	token := e.OneTimeTokenService.Create(downloadPath)
+	exportFile.DownloadURL = downloadPath + "?" + url.Values{consts.OneTimeTokenQueryName: {token}}.Encode()
+	return exportFile, nil
+}
+
+func (e *ExportHandler) DownloadMarkdownExport(ctx *gin.Context) (interface{}, error) {
+	filename := ctx.Param("filename")
+	path, err := e.ExportService.GetExportFilePath(ctx, filename)
+	if err != nil {
+		return nil, err
+	}
+	return &dto.FileDownload{Path: path, Filename: filename}, nil
+}
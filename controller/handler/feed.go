@@ -0,0 +1,67 @@
+package handler
+
+import (
+	"dash/consts"
+	"dash/service"
+	"dash/utils"
+	"dash/utils/xerr"
+	"path"
+
+	"github.com/gin-gonic/gin"
+)
+
+type FeedHandler struct {
+	FeedService service.FeedService
+}
+
+func NewFeedHandler(feedService service.FeedService) *FeedHandler {
+	return &FeedHandler{
+		FeedService: feedService,
+	}
+}
+
+// getFeedFormat 按请求路径的文件名确定订阅格式：feed.xml、atom.xml、feed.json
+func getFeedFormat(ctx *gin.Context) (consts.FeedFormat, error) {
+	switch filename := path.Base(ctx.Request.URL.Path); filename {
+	case "feed.xml":
+		return consts.FeedFormatRSS, nil
+	case "atom.xml":
+		return consts.FeedFormatAtom, nil
+	case "feed.json":
+		return consts.FeedFormatJSON, nil
+	default:
+		return "", xerr.NoRecord.New("feed filename=%v", filename).WithMsg("feed not found").WithStatus(xerr.StatusNotFound)
+	}
+}
+
+func (f *FeedHandler) GetFeed(ctx *gin.Context) (interface{}, error) {
+	format, err := getFeedFormat(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return f.FeedService.GetFeed(ctx, format)
+}
+
+func (f *FeedHandler) GetCategoryFeed(ctx *gin.Context) (interface{}, error) {
+	format, err := getFeedFormat(ctx)
+	if err != nil {
+		return nil, err
+	}
+	slug, err := utils.ParamString(ctx, "slug")
+	if err != nil {
+		return nil, err
+	}
+	return f.FeedService.GetCategoryFeed(ctx, format, slug)
+}
+
+func (f *FeedHandler) GetTagFeed(ctx *gin.Context) (interface{}, error) {
+	format, err := getFeedFormat(ctx)
+	if err != nil {
+		return nil, err
+	}
+	slug, err := utils.ParamString(ctx, "slug")
+	if err != nil {
+		return nil, err
+	}
+	return f.FeedService.GetTagFeed(ctx, format, slug)
+}
@@ -0,0 +1,53 @@
+package handler
+
+import (
+	"context"
+	"dash/model/dto"
+	"dash/service"
+	"dash/utils/xerr"
+	"os"
+	"path/filepath"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+type ImportHandler struct {
+	ImportService service.ImportService
+}
+
+func NewImportHandler(importService service.ImportService) *ImportHandler {
+	return &ImportHandler{
+		ImportService: importService,
+	}
+}
+
+// ImportMarkdown 上传 zip 或单个 Markdown 文件导入文章，dry_run=true 时只返回预览结果
+func (i *ImportHandler) ImportMarkdown(ctx *gin.Context) (interface{}, error) {
+	return i.importUploadedFile(ctx, i.ImportService.ImportMarkdown)
+}
+
+// ImportWordPress 上传 WordPress 导出的 xml 文件导入文章和页面，dry_run=true 时只返回预览结果
+func (i *ImportHandler) ImportWordPress(ctx *gin.Context) (interface{}, error) {
+	return i.importUploadedFile(ctx, i.ImportService.ImportWordPress)
+}
+
+func (i *ImportHandler) importUploadedFile(ctx *gin.Context, importFn func(ctx context.Context, src string, dryRun bool) (*dto.ImportResult, error)) (interface{}, error) {
+	fileHeader, err := ctx.FormFile("file")
+	if err != nil {
+		return nil, xerr.WithStatus(err, xerr.StatusBadRequest).WithMsg("file is required")
+	}
+	dryRun, _ := strconv.ParseBool(ctx.PostForm("dry_run"))
+
+	tempDir, err := os.MkdirTemp("", "dash-upload-*")
+	if err != nil {
+		return nil, xerr.NoType.Wrap(err).WithMsg("create temp dir failed")
+	}
+	defer os.RemoveAll(tempDir)
+	src := filepath.Join(tempDir, filepath.Base(fileHeader.Filename))
+	err = ctx.SaveUploadedFile(fileHeader, src)
+	if err != nil {
+		return nil, xerr.NoType.Wrap(err).WithMsg("save upload file failed")
+	}
+	return importFn(ctx, src, dryRun)
+}
@@ -0,0 +1,63 @@
+package handler
+
+import (
+	"dash/model/dto"
+	"dash/service"
+	"dash/service/assembler"
+	"dash/utils/xerr"
+	"net/url"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+type PermalinkHandler struct {
+	PermalinkService  service.PermalinkService
+	VisibilityService service.VisibilityService
+	BasePostAssembler assembler.BasePostAssembler
+}
+
+func NewPermalinkHandler(
+	permalinkService service.PermalinkService,
+	visibilityService service.VisibilityService,
+	basePostAssembler assembler.BasePostAssembler,
+) *PermalinkHandler {
+	return &PermalinkHandler{
+		PermalinkService:  permalinkService,
+		VisibilityService: visibilityService,
+		BasePostAssembler: basePostAssembler,
+	}
+}
+
+// ResolvePermalink 把 ?path=/2025/03/14/my-post.html 这样的链接解析为文章或页面
+func (p *PermalinkHandler) ResolvePermalink(ctx *gin.Context) (interface{}, error) {
+	path := ctx.Query("path")
+	if path == "" {
+		return nil, xerr.BadParam.New("empty path").WithMsg("path parameter is required").WithStatus(xerr.StatusBadRequest)
+	}
+	post, err := p.PermalinkService.Resolve(ctx, path)
+	if err != nil {
+		return nil, err
+	}
+	err = p.VisibilityService.CheckPostVisible(ctx, post)
+	if err != nil {
+		return nil, err
+	}
+	postOutlineDTO, err := p.BasePostAssembler.ConvertToPostOutlineDTO(ctx, post)
+	if err != nil {
+		return nil, err
+	}
+	canonicalPath, err := p.PermalinkService.BuildPath(ctx, post)
+	if err != nil {
+		return nil, err
+	}
+	requestPath := path
+	if u, err := url.Parse(path); err == nil {
+		requestPath = u.Path
+	}
+	return &dto.Permalink{
+		Type:      post.Type,
+		Post:      postOutlineDTO,
+		Canonical: strings.TrimRight(requestPath, "/") == canonicalPath,
+	}, nil
+}
@@ -4,6 +4,7 @@ import (
 	"dash/consts"
 	"dash/controller/binding"
 	"dash/model/dto"
+	"dash/model/entity"
 	"dash/model/param"
 	"dash/model/property"
 	"dash/model/vo"
@@ -18,16 +19,53 @@ import (
 )
 
 type PostHandler struct {
-	OptionService service.OptionService
-	PostService   service.PostService
-	PostAssembler assembler.PostAssembler
+	OptionService       service.OptionService
+	PostService         service.PostService
+	CategoryService     service.CategoryService
+	PostCategoryService service.PostCategoryService
+	VisibilityService   service.VisibilityService
+	MetaService         service.MetaService
+	ReactionService     service.ReactionService
+	VisitService        service.VisitService
+	SearchService       service.SearchService
+	RelatedPostService  service.RelatedPostService
+	SlugHistoryService  service.SlugHistoryService
+	RecycleService      service.RecycleService
+	PostDraftService    service.PostDraftService
+	PostAssembler       assembler.PostAssembler
 }
 
-func NewPostHandler(optionService service.OptionService, postService service.PostService, postAssembler assembler.PostAssembler) *PostHandler {
+func NewPostHandler(
+	optionService service.OptionService,
+	postService service.PostService,
+	categoryService service.CategoryService,
+	postCategoryService service.PostCategoryService,
+	visibilityService service.VisibilityService,
+	metaService service.MetaService,
+	reactionService service.ReactionService,
+	visitService service.VisitService,
+	searchService service.SearchService,
+	relatedPostService service.RelatedPostService,
+	slugHistoryService service.SlugHistoryService,
+	recycleService service.RecycleService,
+	postDraftService service.PostDraftService,
+	postAssembler assembler.PostAssembler,
+) *PostHandler {
 	return &PostHandler{
-		OptionService: optionService,
-		PostService:   postService,
-		PostAssembler: postAssembler,
+		OptionService:       optionService,
+		PostService:         postService,
+		CategoryService:     categoryService,
+		PostCategoryService: postCategoryService,
+		VisibilityService:   visibilityService,
+		MetaService:         metaService,
+		ReactionService:     reactionService,
+		VisitService:        visitService,
+		SearchService:       searchService,
+		RelatedPostService:  relatedPostService,
+		SlugHistoryService:  slugHistoryService,
+		RecycleService:      recycleService,
+		PostDraftService:    postDraftService,
+		PostAssembler:       postAssembler,
 	}
 }
 
@@ -44,6 +82,11 @@ func (p *PostHandler) ListPosts(ctx *gin.Context) (interface{}, error) {
 	if postQuery.Sort == nil {
 		postQuery.Sort = &param.Sort{Fields: []string{"top_priority,desc", "create_time,desc"}}
 	}
+	postQuery.Statuses = p.VisibilityService.FilterStatuses(ctx, postQuery.Statuses)
+	postQuery.ExcludeCategoryIDs, err = p.VisibilityService.ListHiddenCategoryIDs(ctx, getUnlockTokens(ctx))
+	if err != nil {
+		return nil, err
+	}
 	posts, totalCount, err := p.PostService.Page(ctx, postQuery)
 	if err != nil {
 		return nil, err
@@ -76,7 +119,31 @@ func (p *PostHandler) GetPostByID(ctx *gin.Context) (interface{}, error) {
 	if err != nil {
 		return nil, err
 	}
-	return postDetailDTO, nil
+	postDetailVO := &vo.AdminPostDetail{PostDetail: *postDetailDTO}
+	draft, err := p.PostDraftService.GetByPostID(ctx, postID)
+	if err != nil && xerr.GetType(err) != xerr.NoRecord {
+		return nil, err
+	}
+	if draft != nil {
+		postDetailVO.Draft = p.PostDraftService.ConvertToPostDraftDTO(ctx, draft)
+	}
+	return postDetailVO, nil
+}
+
+func (p *PostHandler) ListPostMetas(ctx *gin.Context) (interface{}, error) {
+	postID, err := utils.ParamInt32(ctx, "id")
+	if err != nil {
+		return nil, err
+	}
+	_, err = p.PostService.GetPostByID(ctx, postID)
+	if err != nil {
+		return nil, err
+	}
+	metas, err := p.MetaService.ListByPostID(ctx, postID)
+	if err != nil {
+		return nil, err
+	}
+	return p.MetaService.ConvertToMetaDTOs(ctx, metas), nil
 }
 
 func (p *PostHandler) GetPostBySlug(ctx *gin.Context) (interface{}, error) {
@@ -85,41 +152,242 @@ func (p *PostHandler) GetPostBySlug(ctx *gin.Context) (interface{}, error) {
 		return nil, err
 	}
 	post, err := p.PostService.GetPostBySlug(ctx, slug)
+	if xerr.GetType(err) == xerr.NoRecord {
+		return p.redirectOldSlug(ctx, slug, err)
+	}
 	if err != nil {
 		return nil, err
 	}
-	postDetailDTO, err := p.PostAssembler.ConvertToDetailVO(ctx, post)
+	if post.Type != consts.PostTypePost { // 页面通过 SheetHandler 访问
+		return nil, xerr.NoRecord.New("post slug=%v is not a post", slug).WithMsg("The resource does not exist or has been deleted").WithStatus(xerr.StatusNotFound)
+	}
+	err = p.VisibilityService.CheckPostVisible(ctx, post)
+	if err != nil {
+		return nil, err
+	}
+	if !p.VisibilityService.IsAdmin(ctx) {
+		protectedPost, err := p.checkPostAccess(ctx, post)
+		if err != nil || protectedPost != nil {
+			return protectedPost, err
+		}
+		p.VisitService.RecordVisit(ctx, post.ID, getVisitorID(ctx), ctx.Request.UserAgent())
+	}
+	// 和 /api/posts/:slug/related 一样按访问者的解锁凭证计算隐藏的分类，系列和相关文章保持一致
+	hiddenCategoryIDs, err := p.VisibilityService.ListHiddenCategoryIDs(ctx, getUnlockTokens(ctx))
+	if err != nil {
+		return nil, err
+	}
+	postDetailDTO, err := p.PostAssembler.ConvertToDetailVO(ctx, post, hiddenCategoryIDs)
 	if err != nil {
 		return nil, err
 	}
 	return postDetailDTO, nil
 }
 
-func (p *PostHandler) SearchPost(ctx *gin.Context) (interface{}, error) {
-	keyword, err := utils.MustGetQueryString(ctx, "keyword")
+func (p *PostHandler) ListRelatedPosts(ctx *gin.Context) (interface{}, error) {
+	slug, err := utils.ParamString(ctx, "slug")
 	if err != nil {
 		return nil, err
 	}
-	sort := param.Sort{}
-	err = ctx.ShouldBindWith(&sort, binding.CustomFormBinding)
+	relatedQuery := param.RelatedPostQuery{}
+	err = ctx.ShouldBindWith(&relatedQuery, binding.CustomFormBinding)
 	if err != nil {
 		return nil, xerr.WithStatus(err, xerr.StatusBadRequest).WithMsg("Parameter error")
 	}
-	if len(sort.Fields) == 0 {
-		sort = p.OptionService.GetPostSort(ctx)
+	if relatedQuery.Size == 0 {
+		relatedQuery.Size = p.OptionService.GetOrByDefault(ctx, property.RelatedPostSize).(int)
 	}
-	// defaultPageSize := p.OptionService.GetIndexPageSize(ctx)
-	page := param.Page{
-		PageNum:  0,
-		PageSize: 100,
+	post, err := p.PostService.GetPostBySlug(ctx, slug)
+	if err != nil {
+		return nil, err
 	}
-	postQuery := param.PostQuery{
-		Page:     page,
-		Sort:     &sort,
-		Keyword:  &keyword,
-		Statuses: []*consts.PostStatus{consts.PostStatusPublished.Ptr()},
+	if post.Type != consts.PostTypePost {
+		return nil, xerr.NoRecord.New("post slug=%v is not a post", slug).WithMsg("The resource does not exist or has been deleted").WithStatus(xerr.StatusNotFound)
+	}
+	err = p.VisibilityService.CheckPostVisible(ctx, post)
+	if err != nil {
+		return nil, err
+	}
+	if !p.VisibilityService.IsAdmin(ctx) {
+		// 未解锁的文章不返回相关文章，避免从推荐结果推测出文章内容
+		protectedPost, err := p.checkPostAccess(ctx, post)
+		if err != nil {
+			return nil, err
+		}
+		if protectedPost != nil {
+			return make([]*dto.PostOutline, 0), nil
+		}
+	}
+	hiddenCategoryIDs, err := p.VisibilityService.ListHiddenCategoryIDs(ctx, getUnlockTokens(ctx))
+	if err != nil {
+		return nil, err
+	}
+	relatedPosts, err := p.RelatedPostService.ListRelatedPosts(ctx, post, relatedQuery.Size, hiddenCategoryIDs)
+	if err != nil {
+		return nil, err
+	}
+	return p.PostAssembler.ConvertToPostOutlineDTOs(ctx, relatedPosts)
+}
+
+// redirectOldSlug 文章改过 slug 时，旧 slug 跳转到文章现在的地址，没有历史记录时返回 notFoundErr
+func (p *PostHandler) redirectOldSlug(ctx *gin.Context, slug string, notFoundErr error) (interface{}, error) {
+	postID, err := p.SlugHistoryService.GetTargetID(ctx, consts.SlugTypePost, slug)
+	if err != nil {
+		return nil, notFoundErr
+	}
+	post, err := p.PostService.GetPostByID(ctx, postID)
+	if err != nil || post.Type != consts.PostTypePost {
+		return nil, notFoundErr
+	}
+	if p.VisibilityService.CheckPostVisible(ctx, post) != nil {
+		return nil, notFoundErr
+	}
+	postOutlineDTO, err := p.PostAssembler.ConvertToPostOutlineDTO(ctx, post)
+	if err != nil {
+		return nil, err
+	}
+	return &dto.Redirect{Location: postOutlineDTO.FullPath, Slug: post.Slug}, nil
+}
+
+func (p *PostHandler) UnlockPost(ctx *gin.Context) (interface{}, error) {
+	slug, err := utils.ParamString(ctx, "slug")
+	if err != nil {
+		return nil, err
+	}
+	unlockParam := &param.Unlock{}
+	err = ctx.ShouldBindJSON(unlockParam)
+	if err != nil {
+		return nil, xerr.WithStatus(err, xerr.StatusBadRequest).WithMsg("parameter error")
+	}
+	post, err := p.PostService.GetPostBySlug(ctx, slug)
+	if err != nil {
+		return nil, err
+	}
+	// 和文章详情一样的检查，草稿、私密文章等不可见的内容按不存在处理，避免通过解锁接口探测；加密页面也通过这里解锁
+	if post.Type != consts.PostTypePost && post.Type != consts.PostTypeSheet {
+		return nil, xerr.NoRecord.New("post slug=%v can not be unlocked", slug).WithMsg("The resource does not exist or has been deleted").WithStatus(xerr.StatusNotFound)
+	}
+	err = p.VisibilityService.CheckPostVisible(ctx, post)
+	if err != nil {
+		return nil, err
+	}
+	return p.PostService.UnlockPost(ctx, post, unlockParam.Password, ctx.ClientIP())
+}
+
+// getVisiblePostByID 点赞和回应使用文章 ID，gin 要求同一位置的通配符同名，所以路由中仍叫 :slug
+func (p *PostHandler) getVisiblePostByID(ctx *gin.Context) (*entity.Post, error) {
+	postID, err := utils.ParamInt32(ctx, "slug")
+	if err != nil {
+		return nil, err
+	}
+	post, err := p.PostService.GetPostByID(ctx, postID)
+	if err != nil {
+		return nil, err
 	}
-	posts, total, err := p.PostService.Page(ctx, postQuery)
+	err = p.VisibilityService.CheckPostVisible(ctx, post)
+	if err != nil {
+		return nil, err
+	}
+	// 未解锁的文章不能点赞和回应，否则访客看不到正文也能刷计数
+	if !p.VisibilityService.IsAdmin(ctx) {
+		protectedPost, err := p.checkPostAccess(ctx, post)
+		if err != nil {
+			return nil, err
+		}
+		if protectedPost != nil {
+			return nil, xerr.Forbidden.New("post id=%v is locked", post.ID).WithMsg("post password required").WithStatus(xerr.StatusForbidden)
+		}
+	}
+	return post, nil
+}
+
+func (p *PostHandler) LikePost(ctx *gin.Context) (interface{}, error) {
+	post, err := p.getVisiblePostByID(ctx)
+	if err != nil {
+		return nil, err
+	}
+	likes, err := p.ReactionService.LikePost(ctx, post, getVisitorID(ctx))
+	if err != nil {
+		return nil, err
+	}
+	return &dto.PostLikes{PostID: post.ID, Likes: likes}, nil
+}
+
+func (p *PostHandler) ReactToPost(ctx *gin.Context) (interface{}, error) {
+	reactionParam := &param.Reaction{}
+	err := ctx.ShouldBindJSON(reactionParam)
+	if err != nil {
+		return nil, xerr.WithStatus(err, xerr.StatusBadRequest).WithMsg("parameter error")
+	}
+	post, err := p.getVisiblePostByID(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return p.ReactionService.ReactToPost(ctx, post, reactionParam.Reaction, getVisitorID(ctx))
+}
+
+func (p *PostHandler) ListAvailableReactions(ctx *gin.Context) (interface{}, error) {
+	return p.ReactionService.ListAvailableReactions(ctx), nil
+}
+
+// checkPostAccess 检查游客能否阅读文章内容，未解锁的加密文章或加密分类下的文章只返回标题和摘要
+func (p *PostHandler) checkPostAccess(ctx *gin.Context, post *entity.Post) (*vo.ProtectedPost, error) {
+	unlockTokens := getUnlockTokens(ctx)
+	categories, err := p.PostCategoryService.ListCategoriesByPostID(ctx, post.ID)
+	if err != nil {
+		return nil, err
+	}
+	lockedCategories := make([]*entity.Category, 0)
+	for _, category := range categories {
+		if !p.CategoryService.IsCategoryUnlocked(ctx, category, unlockTokens) {
+			lockedCategories = append(lockedCategories, category)
+		}
+	}
+	if len(lockedCategories) == 0 && p.PostService.IsPostUnlocked(ctx, post, unlockTokens) {
+		return nil, nil
+	}
+
+	postDTO, err := p.PostAssembler.ConvertToPostDTO(ctx, post)
+	if err != nil {
+		return nil, err
+	}
+	lockedCategoryDTOs, err := p.CategoryService.ConvertToCategoryDTOs(ctx, lockedCategories)
+	if err != nil {
+		return nil, err
+	}
+	return &vo.ProtectedPost{
+		PostOutline:      postDTO.PostOutline,
+		Summary:          postDTO.Summary,
+		Thumbnail:        postDTO.Thumbnail,
+		PasswordRequired: true,
+		LockedCategories: lockedCategoryDTOs,
+	}, nil
+}
+
+func (p *PostHandler) SearchPost(ctx *gin.Context) (interface{}, error) {
+	searchQuery := param.SearchQuery{}
+	err := ctx.ShouldBindWith(&searchQuery, binding.CustomFormBinding)
+	if err != nil {
+		return nil, xerr.WithStatus(err, xerr.StatusBadRequest).WithMsg("Parameter error")
+	}
+	if searchQuery.PageSize <= 0 || searchQuery.PageSize > 50 {
+		searchQuery.PageSize = 20
+	}
+	searchQuery.Statuses = p.VisibilityService.VisibleStatuses(ctx)
+	searchQuery.ExcludeProtected = !p.VisibilityService.IsAdmin(ctx)
+	searchQuery.ExcludeCategoryIDs, err = p.VisibilityService.ListHiddenCategoryIDs(ctx, getUnlockTokens(ctx))
+	if err != nil {
+		return nil, err
+	}
+	hits, total, err := p.SearchService.Search(ctx, searchQuery)
+	if err != nil {
+		return nil, err
+	}
+	postIDs := make([]int32, 0, len(hits))
+	for _, hit := range hits {
+		postIDs = append(postIDs, hit.PostID)
+	}
+	posts, err := p.PostService.ListByIDs(ctx, postIDs)
 	if err != nil {
 		return nil, err
 	}
@@ -127,7 +395,66 @@ func (p *PostHandler) SearchPost(ctx *gin.Context) (interface{}, error) {
 	if err != nil {
 		return nil, err
 	}
-	return dto.NewPage(postVOs, total, page), nil
+	postVOMap := make(map[int32]*vo.Post, len(postVOs))
+	for _, postVO := range postVOs {
+		postVOMap[postVO.ID] = postVO
+	}
+	// 按相关度的顺序返回
+	searchPosts := make([]*vo.SearchPost, 0, len(hits))
+	for _, hit := range hits {
+		postVO, ok := postVOMap[hit.PostID]
+		if !ok {
+			continue
+		}
+		searchPosts = append(searchPosts, &vo.SearchPost{
+			Post:           *postVO,
+			Score:          hit.Score,
+			TitleHighlight: hit.TitleHighlight,
+			Snippet:        hit.Snippet,
+		})
+	}
+	return dto.NewPage(searchPosts, total, searchQuery.Page), nil
+}
+
+func (p *PostHandler) SuggestSearch(ctx *gin.Context) (interface{}, error) {
+	suggestQuery := param.SuggestQuery{}
+	err := ctx.ShouldBindWith(&suggestQuery, binding.CustomFormBinding)
+	if err != nil {
+		return nil, xerr.WithStatus(err, xerr.StatusBadRequest).WithMsg("Parameter error")
+	}
+	suggestQuery.Statuses = p.VisibilityService.VisibleStatuses(ctx)
+	suggestQuery.ExcludeProtected = !p.VisibilityService.IsAdmin(ctx)
+	suggestQuery.ExcludeCategoryIDs, err = p.VisibilityService.ListHiddenCategoryIDs(ctx, getUnlockTokens(ctx))
+	if err != nil {
+		return nil, err
+	}
+	return p.SearchService.Suggest(ctx, suggestQuery)
+}
+
+// RebuildSearchIndex 清空并重建搜索索引，升级或索引数据异常时使用
+func (p *PostHandler) RebuildSearchIndex(ctx *gin.Context) (interface{}, error) {
+	return p.SearchService.RebuildIndex(ctx)
+}
+
+func (p *PostHandler) ListScheduledPosts(ctx *gin.Context) (interface{}, error) {
+	posts, err := p.PostService.ListScheduledPosts(ctx)
+	if err != nil {
+		return nil, err
+	}
+	postOutlineDTOs := make([]*dto.PostOutline, 0, len(posts))
+	for _, post := range posts {
+		postOutlineDTO, err := p.PostAssembler.ConvertToPostOutlineDTO(ctx, post)
+		if err != nil {
+			return nil, err
+		}
+		postOutlineDTOs = append(postOutlineDTOs, postOutlineDTO)
+	}
+	return postOutlineDTOs, nil
+}
+
+// RenderPosts 修改渲染设置后重新渲染所有 Markdown 文章
+func (p *PostHandler) RenderPosts(ctx *gin.Context) (interface{}, error) {
+	return p.PostService.RenderAll(ctx)
 }
 
 func (p *PostHandler) CreatePost(ctx *gin.Context) (interface{}, error) {
@@ -232,7 +559,7 @@ func (p *PostHandler) DeletePost(ctx *gin.Context) (interface{}, error) {
 	if err != nil {
 		return nil, err
 	}
-	return nil, p.PostService.DeleteByID(ctx, postID)
+	return nil, p.RecycleService.RecyclePosts(ctx, []int32{postID})
 }
 
 func (p *PostHandler) DeletePostBatch(ctx *gin.Context) (interface{}, error) {
@@ -241,7 +568,7 @@ func (p *PostHandler) DeletePostBatch(ctx *gin.Context) (interface{}, error) {
 	if err != nil {
 		return nil, xerr.WithMsg(err, "postIDs error").WithStatus(xerr.StatusBadRequest)
 	}
-	return nil, p.PostService.DeleteBatchByID(ctx, postIDs)
+	return nil, p.RecycleService.RecyclePosts(ctx, postIDs)
 }
 
 func (p *PostHandler) GetPostArchive(ctx *gin.Context) (interface{}, error) {
@@ -250,6 +577,10 @@ func (p *PostHandler) GetPostArchive(ctx *gin.Context) (interface{}, error) {
 		return nil, err
 	}
 	pageSize := p.OptionService.GetOrByDefault(ctx, property.ArchivePageSize).(int)
+	hiddenCategoryIDs, err := p.VisibilityService.ListHiddenCategoryIDs(ctx, getUnlockTokens(ctx))
+	if err != nil {
+		return nil, err
+	}
 	postQuery := param.PostQuery{
 		Page: param.Page{
 			PageNum:  int(page),
@@ -258,7 +589,8 @@ func (p *PostHandler) GetPostArchive(ctx *gin.Context) (interface{}, error) {
 		Sort: &param.Sort{
 			Fields: []string{"create_time,desc"},
 		},
-		Statuses: []*consts.PostStatus{consts.PostStatusPublished.Ptr()},
+		Statuses:           []*consts.PostStatus{consts.PostStatusPublished.Ptr()},
+		ExcludeCategoryIDs: hiddenCategoryIDs,
 	}
 	posts, totalPage, err := p.PostService.Page(ctx, postQuery)
 	if err != nil {
@@ -0,0 +1,66 @@
+package handler
+
+import (
+	"dash/model/param"
+	"dash/service"
+	"dash/service/assembler"
+	"dash/utils"
+	"dash/utils/xerr"
+	"errors"
+
+	"github.com/gin-gonic/gin"
+	"github.com/go-playground/validator/v10"
+)
+
+type PostDraftHandler struct {
+	PostDraftService service.PostDraftService
+	PostAssembler    assembler.PostAssembler
+}
+
+func NewPostDraftHandler(postDraftService service.PostDraftService, postAssembler assembler.PostAssembler) *PostDraftHandler {
+	return &PostDraftHandler{
+		PostDraftService: postDraftService,
+		PostAssembler:    postAssembler,
+	}
+}
+
+func (p *PostDraftHandler) SaveDraft(ctx *gin.Context) (interface{}, error) {
+	postID, err := utils.ParamInt32(ctx, "id")
+	if err != nil {
+		return nil, err
+	}
+	draftParam := &param.PostDraft{}
+	err = ctx.ShouldBindJSON(draftParam)
+	if err != nil {
+		e := validator.ValidationErrors{}
+		if errors.As(err, &e) {
+			return nil, xerr.WithStatus(e, xerr.StatusBadRequest).WithMsg(e.Error())
+		}
+		return nil, xerr.WithStatus(err, xerr.StatusBadRequest).WithMsg("parameter error")
+	}
+	draft, err := p.PostDraftService.Save(ctx, postID, draftParam)
+	if err != nil {
+		return nil, err
+	}
+	return p.PostDraftService.ConvertToPostDraftDTO(ctx, draft), nil
+}
+
+func (p *PostDraftHandler) PublishDraft(ctx *gin.Context) (interface{}, error) {
+	postID, err := utils.ParamInt32(ctx, "id")
+	if err != nil {
+		return nil, err
+	}
+	post, err := p.PostDraftService.Publish(ctx, postID)
+	if err != nil {
+		return nil, err
+	}
+	return p.PostAssembler.ConvertToPostOutlineDTO(ctx, post)
+}
+
+func (p *PostDraftHandler) DiscardDraft(ctx *gin.Context) (interface{}, error) {
+	postID, err := utils.ParamInt32(ctx, "id")
+	if err != nil {
+		return nil, err
+	}
+	return nil, p.PostDraftService.DeleteByPostID(ctx, postID)
+}
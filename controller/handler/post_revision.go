@@ -0,0 +1,81 @@
+package handler
+
+import (
+	"dash/service"
+	"dash/service/assembler"
+	"dash/utils"
+
+	"github.com/gin-gonic/gin"
+)
+
+type PostRevisionHandler struct {
+	PostRevisionService service.PostRevisionService
+	PostAssembler       assembler.PostAssembler
+}
+
+func NewPostRevisionHandler(postRevisionService service.PostRevisionService, postAssembler assembler.PostAssembler) *PostRevisionHandler {
+	return &PostRevisionHandler{
+		PostRevisionService: postRevisionService,
+		PostAssembler:       postAssembler,
+	}
+}
+
+func (p *PostRevisionHandler) ListRevisions(ctx *gin.Context) (interface{}, error) {
+	postID, err := utils.ParamInt32(ctx, "id")
+	if err != nil {
+		return nil, err
+	}
+	revisions, err := p.PostRevisionService.ListByPostID(ctx, postID)
+	if err != nil {
+		return nil, err
+	}
+	return p.PostRevisionService.ConvertToPostRevisionDTOs(ctx, revisions), nil
+}
+
+func (p *PostRevisionHandler) GetRevision(ctx *gin.Context) (interface{}, error) {
+	postID, err := utils.ParamInt32(ctx, "id")
+	if err != nil {
+		return nil, err
+	}
+	revisionID, err := utils.ParamInt32(ctx, "revisionID")
+	if err != nil {
+		return nil, err
+	}
+	revision, err := p.PostRevisionService.GetByID(ctx, postID, revisionID)
+	if err != nil {
+		return nil, err
+	}
+	return p.PostRevisionService.ConvertToPostRevisionDetailDTO(ctx, revision), nil
+}
+
+func (p *PostRevisionHandler) DiffRevisions(ctx *gin.Context) (interface{}, error) {
+	postID, err := utils.ParamInt32(ctx, "id")
+	if err != nil {
+		return nil, err
+	}
+	fromID, err := utils.MustGetQueryInt32(ctx, "from")
+	if err != nil {
+		return nil, err
+	}
+	toID, err := utils.MustGetQueryInt32(ctx, "to")
+	if err != nil {
+		return nil, err
+	}
+	return p.PostRevisionService.Diff(ctx, postID, fromID, toID)
+}
+
+func (p *PostRevisionHandler) RestoreRevision(ctx *gin.Context) (interface{}, error) {
+	postID, err := utils.ParamInt32(ctx, "id")
+	if err != nil {
+		return nil, err
+	}
+	revisionID, err := utils.ParamInt32(ctx, "revisionID")
+	if err != nil {
+		return nil, err
+	}
+	post, err := p.PostRevisionService.Restore(ctx, postID, revisionID)
+	if err != nil {
+		return nil, err
+	}
+	return p.PostAssembler.ConvertToPostOutlineDTO(ctx, post)
+}
@@ -0,0 +1,60 @@
+package handler
+
+import (
+	"dash/controller/binding"
+	"dash/model/dto"
+	"dash/model/param"
+	"dash/service"
+	"dash/utils"
+	"dash/utils/xerr"
+
+	"github.com/gin-gonic/gin"
+)
+
+type RecycleHandler struct {
+	RecycleService service.RecycleService
+}
+
+func NewRecycleHandler(recycleService service.RecycleService) *RecycleHandler {
+	return &RecycleHandler{
+		RecycleService: recycleService,
+	}
+}
+
+func (r *RecycleHandler) ListRecycleItems(ctx *gin.Context) (interface{}, error) {
+	recycleQuery := param.RecycleQuery{}
+	err := ctx.ShouldBindWith(&recycleQuery, binding.CustomFormBinding)
+	if err != nil {
+		return nil, xerr.WithStatus(err, xerr.StatusBadRequest).WithMsg("Parameter error")
+	}
+	if recycleQuery.PageSize <= 0 || recycleQuery.PageSize > 100 {
+		recycleQuery.PageSize = 20
+	}
+	items, total, err := r.RecycleService.Page(ctx, recycleQuery)
+	if err != nil {
+		return nil, err
+	}
+	return dto.NewPage(r.RecycleService.ConvertToRecycleItemDTOs(ctx, items), total, recycleQuery.Page), nil
+}
+
+// RestoreRecycleItem 文章和页面恢复为草稿，分类和标签按删除前的数据重建
+func (r *RecycleHandler) RestoreRecycleItem(ctx *gin.Context) (interface{}, error) {
+	id, err := utils.ParamInt32(ctx, "id")
+	if err != nil {
+		return nil, err
+	}
+	return nil, r.RecycleService.Restore(ctx, id)
+}
+
+func (r *RecycleHandler) PurgeRecycleItem(ctx *gin.Context) (interface{}, error) {
+	id, err := utils.ParamInt32(ctx, "id")
+	if err != nil {
+		return nil, err
+	}
+	return nil, r.RecycleService.Purge(ctx, id)
+}
+
+// EmptyRecycleBin 清空回收站，返回彻底删除的数量
+func (r *RecycleHandler) EmptyRecycleBin(ctx *gin.Context) (interface{}, error) {
+	return r.RecycleService.PurgeAll(ctx)
+}
@@ -0,0 +1,32 @@
+package handler
+
+import (
+	"dash/service"
+	"dash/utils/xerr"
+	"os"
+
+	"github.com/gin-gonic/gin"
+)
+
+type SEOHandler struct {
+	SEOService service.SEOService
+}
+
+func NewSEOHandler(seoService service.SEOService) *SEOHandler {
+	return &SEOHandler{
+		SEOService: seoService,
+	}
+}
+
+// RenderIndex 读取前端入口 index.html，按请求路径注入标题、描述、canonical、Open Graph 和 JSON-LD
+func (s *SEOHandler) RenderIndex(ctx *gin.Context) ([]byte, error) {
+	html, err := os.ReadFile("resource/static/index.html")
+	if err != nil {
+		return nil, xerr.NoType.Wrap(err).WithMsg("read index.html failed")
+	}
+	pageMeta, err := s.SEOService.GetPageMeta(ctx, ctx.Request.URL.Path)
+	if err != nil {
+		return nil, err
+	}
+	return s.SEOService.RenderPage(ctx, html, pageMeta)
+}
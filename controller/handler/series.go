@@ -0,0 +1,166 @@
+package handler
+
+import (
+	"dash/model/param"
+	"dash/model/vo"
+	"dash/service"
+	"dash/service/assembler"
+	"dash/utils"
+	"dash/utils/xerr"
+	"errors"
+
+	"github.com/gin-gonic/gin"
+	"github.com/go-playground/validator/v10"
+)
+
+type SeriesHandler struct {
+	SeriesService       service.SeriesService
+	PostCategoryService service.PostCategoryService
+	VisibilityService   service.VisibilityService
+	PostAssembler       assembler.PostAssembler
+}
+
+func NewSeriesHandler(
+	seriesService service.SeriesService,
+	postCategoryService service.PostCategoryService,
+	visibilityService service.VisibilityService,
+	postAssembler assembler.PostAssembler,
+) *SeriesHandler {
+	return &SeriesHandler{
+		SeriesService:       seriesService,
+		PostCategoryService: postCategoryService,
+		VisibilityService:   visibilityService,
+		PostAssembler:       postAssembler,
+	}
+}
+
+func (s *SeriesHandler) ListSeries(ctx *gin.Context) (interface{}, error) {
+	series, err := s.SeriesService.List(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return s.SeriesService.ConvertToSeriesDTOs(ctx, series)
+}
+
+// GetSeriesByID 后台查看系列，包含所有状态的文章
+func (s *SeriesHandler) GetSeriesByID(ctx *gin.Context) (interface{}, error) {
+	id, err := utils.ParamInt32(ctx, "id")
+	if err != nil {
+		return nil, err
+	}
+	series, err := s.SeriesService.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	seriesDTO, err := s.SeriesService.ConvertToSeriesDTO(ctx, series)
+	if err != nil {
+		return nil, err
+	}
+	posts, err := s.SeriesService.ListPosts(ctx, id, nil)
+	if err != nil {
+		return nil, err
+	}
+	postVOs, err := s.PostAssembler.ConvertToPostVOs(ctx, posts)
+	if err != nil {
+		return nil, err
+	}
+	return &vo.SeriesDetail{Series: seriesDTO, Posts: postVOs}, nil
+}
+
+// GetSeriesBySlug 公开的系列文章列表，只包含访问者可见的文章
+func (s *SeriesHandler) GetSeriesBySlug(ctx *gin.Context) (interface{}, error) {
+	slug, err := utils.ParamString(ctx, "slug")
+	if err != nil {
+		return nil, err
+	}
+	series, err := s.SeriesService.GetBySlug(ctx, slug)
+	if err != nil {
+		return nil, err
+	}
+	posts, err := s.SeriesService.ListPosts(ctx, series.ID, s.VisibilityService.VisibleStatuses(ctx))
+	if err != nil {
+		return nil, err
+	}
+	hiddenCategoryIDs, err := s.VisibilityService.ListHiddenCategoryIDs(ctx, getUnlockTokens(ctx))
+	if err != nil {
+		return nil, err
+	}
+	posts, err = filterHiddenPosts(ctx, s.PostCategoryService, hiddenCategoryIDs, posts)
+	if err != nil {
+		return nil, err
+	}
+	seriesDTO, err := s.SeriesService.ConvertToSeriesDTO(ctx, series)
+	if err != nil {
+		return nil, err
+	}
+	seriesDTO.PostCount = int64(len(posts))
+	postVOs, err := s.PostAssembler.ConvertToPostVOs(ctx, posts)
+	if err != nil {
+		return nil, err
+	}
+	return &vo.SeriesDetail{Series: seriesDTO, Posts: postVOs}, nil
+}
+
+func (s *SeriesHandler) CreateSeries(ctx *gin.Context) (interface{}, error) {
+	seriesParam := &param.Series{}
+	err := ctx.ShouldBindJSON(seriesParam)
+	if err != nil {
+		e := validator.ValidationErrors{}
+		if errors.As(err, &e) {
+			return nil, xerr.WithStatus(e, xerr.StatusBadRequest).WithMsg(e.Error())
+		}
+		return nil, xerr.WithStatus(err, xerr.StatusBadRequest)
+	}
+	series, err := s.SeriesService.Create(ctx, seriesParam)
+	if err != nil {
+		return nil, err
+	}
+	return s.SeriesService.ConvertToSeriesDTO(ctx, series)
+}
+
+func (s *SeriesHandler) UpdateSeries(ctx *gin.Context) (interface{}, error) {
+	id, err := utils.ParamInt32(ctx, "id")
+	if err != nil {
+		return nil, err
+	}
+	seriesParam := &param.Series{}
+	err = ctx.ShouldBindJSON(seriesParam)
+	if err != nil {
+		e := validator.ValidationErrors{}
+		if errors.As(err, &e) {
+			return nil, xerr.WithStatus(e, xerr.StatusBadRequest).WithMsg(e.Error())
+		}
+		return nil, xerr.WithStatus(err, xerr.StatusBadRequest)
+	}
+	series, err := s.SeriesService.UpdateByID(ctx, id, seriesParam)
+	if err != nil {
+		return nil, err
+	}
+	return s.SeriesService.ConvertToSeriesDTO(ctx, series)
+}
+
+func (s *SeriesHandler) DeleteSeries(ctx *gin.Context) (interface{}, error) {
+	id, err := utils.ParamInt32(ctx, "id")
+	if err != nil {
+		return nil, err
+	}
+	return nil, s.SeriesService.DeleteByID(ctx, id)
+}
+
+// UpdateSeriesPosts 设置系列中的文章及顺序，添加、移除和排序都通过这个接口
+func (s *SeriesHandler) UpdateSeriesPosts(ctx *gin.Context) (interface{}, error) {
+	id, err := utils.ParamInt32(ctx, "id")
+	if err != nil {
+		return nil, err
+	}
+	seriesPostsParam := &param.SeriesPosts{}
+	err = ctx.ShouldBindJSON(seriesPostsParam)
+	if err != nil {
+		return nil, xerr.WithStatus(err, xerr.StatusBadRequest).WithMsg("invalid post_ids")
+	}
+	err = s.SeriesService.UpdatePosts(ctx, id, seriesPostsParam.PostIDs)
+	if err != nil {
+		return nil, err
+	}
+	return s.GetSeriesByID(ctx)
+}
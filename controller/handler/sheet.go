@@ -1,41 +1,207 @@
 package handler
 
-// import (
-// 	"dash/consts"
-// 	"dash/service"
-// 	"dash/service/assembler"
-// 	"dash/utils"
-
-// 	"github.com/gin-gonic/gin"
-// )
-
-// type SheetHandler struct {
-// 	PostService   service.PostService
-// 	PostAssembler assembler.PostAssembler
-// }
-
-// func NewSheetHandler(postService service.PostService, postAssembler assembler.PostAssembler) *SheetHandler {
-// 	return &SheetHandler{
-// 		PostService:   postService,
-// 		PostAssembler: postAssembler,
-// 	}
-// }
-
-// func (s *SheetHandler) Sheet(ctx *gin.Context) (interface{}, error) {
-// 	slug, err := utils.ParamString(ctx, "slug")
-// 	if err != nil {
-// 		return nil, err
-// 	}
-// 	post, err := s.PostService.GetPostBySlug(ctx, slug)
-// 	if err != nil {
-// 		return nil, err
-// 	}
-// 	if post.Type != consts.PostTypeSheet {
-// 		return nil, nil
-// 	}
-// 	postVO, err := s.PostAssembler.ConvertToDetailVO(ctx, post)
-// 	if err != nil {
-// 		return nil, err
-// 	}
-// 	return postVO, nil
-// }
+import (
+	"dash/consts"
+	"dash/controller/binding"
+	"dash/model/dto"
+	"dash/model/param"
+	"dash/model/vo"
+	"dash/service"
+	"dash/service/assembler"
+	"dash/utils"
+	"dash/utils/xerr"
+	"errors"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/go-playground/validator/v10"
+)
+
+type SheetHandler struct {
+	SheetService       service.SheetService
+	BasePostService    service.BasePostService
+	VisibilityService  service.VisibilityService
+	VisitService       service.VisitService
+	SlugHistoryService service.SlugHistoryService
+	SheetAssembler     assembler.SheetAssembler
+}
+
+func NewSheetHandler(
+	sheetService service.SheetService,
+	basePostService service.BasePostService,
+	visibilityService service.VisibilityService,
+	visitService service.VisitService,
+	slugHistoryService service.SlugHistoryService,
+	sheetAssembler assembler.SheetAssembler,
+) *SheetHandler {
+	return &SheetHandler{
+		SheetService:       sheetService,
+		BasePostService:    basePostService,
+		VisibilityService:  visibilityService,
+		VisitService:       visitService,
+		SlugHistoryService: slugHistoryService,
+		SheetAssembler:     sheetAssembler,
+	}
+}
+
+func (s *SheetHandler) ListSheets(ctx *gin.Context) (interface{}, error) {
+	sheetQuery := param.SheetQuery{}
+	err := ctx.ShouldBindWith(&sheetQuery, binding.CustomFormBinding)
+	if err != nil {
+		return nil, xerr.WithStatus(err, xerr.StatusBadRequest).WithMsg("invalid parameter")
+	}
+	if sheetQuery.PageSize > 50 {
+		sheetQuery.PageSize = 50
+	}
+	if sheetQuery.Sort == nil {
+		sheetQuery.Sort = &param.Sort{Fields: []string{"top_priority,desc", "create_time,desc"}}
+	}
+	sheets, totalCount, err := s.SheetService.Page(ctx, sheetQuery)
+	if err != nil {
+		return nil, err
+	}
+	sheetVOs, err := s.SheetAssembler.ConvertToSheetVOs(ctx, sheets)
+	if err != nil {
+		return nil, err
+	}
+	return dto.NewPage(sheetVOs, totalCount, sheetQuery.Page), nil
+}
+
+func (s *SheetHandler) GetSheetByID(ctx *gin.Context) (interface{}, error) {
+	sheetID, err := utils.ParamInt32(ctx, "id")
+	if err != nil {
+		return nil, err
+	}
+	sheet, err := s.SheetService.GetSheetByID(ctx, sheetID)
+	if err != nil {
+		return nil, err
+	}
+	return s.SheetAssembler.ConvertToSheetDetailVO(ctx, sheet)
+}
+
+// GetSheetByPath 公开接口，按完整路径查找页面，如 /api/sheet/docs/install
+func (s *SheetHandler) GetSheetByPath(ctx *gin.Context) (interface{}, error) {
+	path := strings.Trim(ctx.Param("path"), "/")
+	if path == "" {
+		return nil, xerr.BadParam.New("").WithMsg("sheet path is empty").WithStatus(xerr.StatusBadRequest)
+	}
+	slugs := strings.Split(path, "/")
+	sheet, err := s.SheetService.GetSheetByPath(ctx, slugs)
+	if xerr.GetType(err) == xerr.NoRecord {
+		return s.redirectOldSlug(ctx, slugs[len(slugs)-1], err)
+	}
+	if err != nil {
+		return nil, err
+	}
+	err = s.VisibilityService.CheckPostVisible(ctx, sheet)
+	if err != nil {
+		return nil, err
+	}
+	if !s.VisibilityService.IsAdmin(ctx) && !s.BasePostService.IsPostUnlocked(ctx, sheet, getUnlockTokens(ctx)) {
+		// 加密页面通过 /api/posts/:slug/unlock 解锁
+		postDTO, err := s.SheetAssembler.ConvertToPostDTO(ctx, sheet)
+		if err != nil {
+			return nil, err
+		}
+		return &vo.ProtectedPost{
+			PostOutline:      postDTO.PostOutline,
+			Summary:          postDTO.Summary,
+			Thumbnail:        postDTO.Thumbnail,
+			PasswordRequired: true,
+			LockedCategories: make([]*dto.Category, 0),
+		}, nil
+	}
+	if !s.VisibilityService.IsAdmin(ctx) {
+		s.VisitService.RecordVisit(ctx, sheet.ID, getVisitorID(ctx), ctx.Request.UserAgent())
+	}
+	return s.SheetAssembler.ConvertToSheetDetailVO(ctx, sheet)
+}
+
+// redirectOldSlug 页面改过 slug 时，旧路径跳转到页面现在的地址，没有历史记录时返回 notFoundErr
+func (s *SheetHandler) redirectOldSlug(ctx *gin.Context, slug string, notFoundErr error) (interface{}, error) {
+	sheetID, err := s.SlugHistoryService.GetTargetID(ctx, consts.SlugTypePost, slug)
+	if err != nil {
+		return nil, notFoundErr
+	}
+	sheet, err := s.BasePostService.GetPostByID(ctx, sheetID)
+	if err != nil || sheet.Type != consts.PostTypeSheet {
+		return nil, notFoundErr
+	}
+	if s.VisibilityService.CheckPostVisible(ctx, sheet) != nil {
+		return nil, notFoundErr
+	}
+	postOutlineDTO, err := s.SheetAssembler.ConvertToPostOutlineDTO(ctx, sheet)
+	if err != nil {
+		return nil, err
+	}
+	return &dto.Redirect{Location: postOutlineDTO.FullPath, Slug: sheet.Slug}, nil
+}
+
+func (s *SheetHandler) CreateSheet(ctx *gin.Context) (interface{}, error) {
+	sheetParam := &param.Post{}
+	err := ctx.ShouldBindJSON(&sheetParam)
+	if err != nil {
+		e := validator.ValidationErrors{}
+		if errors.As(err, &e) {
+			return nil, xerr.WithStatus(e, xerr.StatusBadRequest).WithMsg(e.Error())
+		}
+		return nil, xerr.WithStatus(err, xerr.StatusBadRequest).WithMsg("parameter error")
+	}
+	sheet, err := s.SheetService.Create(ctx, sheetParam)
+	if err != nil {
+		return nil, err
+	}
+	return s.SheetAssembler.ConvertToPostOutlineDTO(ctx, sheet)
+}
+
+func (s *SheetHandler) UpdateSheet(ctx *gin.Context) (interface{}, error) {
+	sheetParam := &param.Post{}
+	err := ctx.ShouldBindJSON(&sheetParam)
+	if err != nil {
+		e := validator.ValidationErrors{}
+		if errors.As(err, &e) {
+			return nil, xerr.WithStatus(e, xerr.StatusBadRequest).WithMsg(e.Error())
+		}
+		return nil, xerr.WithStatus(err, xerr.StatusBadRequest).WithMsg("parameter error")
+	}
+	sheetID, err := utils.ParamInt32(ctx, "id")
+	if err != nil {
+		return nil, err
+	}
+	sheet, err := s.SheetService.UpdateByID(ctx, sheetID, sheetParam)
+	if err != nil {
+		return nil, err
+	}
+	return s.SheetAssembler.ConvertToPostOutlineDTO(ctx, sheet)
+}
+
+func (s *SheetHandler) UpdateSheetStatus(ctx *gin.Context) (interface{}, error) {
+	sheetID, err := utils.ParamInt32(ctx, "id")
+	if err != nil {
+		return nil, err
+	}
+	statusStr, err := utils.ParamString(ctx, "status")
+	if err != nil {
+		return nil, xerr.WithStatus(err, xerr.StatusBadRequest).WithMsg("parameter error")
+	}
+	status, err := consts.PostStatusFromString(statusStr)
+	if err != nil {
+		return nil, xerr.WithStatus(err, xerr.StatusBadRequest).WithMsg("parameter error")
+	}
+	if int32(status) < int32(consts.PostStatusPublished) || int32(status) > int32(consts.PostStatusIntimate) {
+		return nil, xerr.WithStatus(nil, xerr.StatusBadRequest).WithMsg("status error")
+	}
+	sheet, err := s.SheetService.UpdateStatusByID(ctx, sheetID, status)
+	if err != nil {
+		return nil, err
+	}
+	return s.SheetAssembler.ConvertToPostOutlineDTO(ctx, sheet)
+}
+
+func (s *SheetHandler) DeleteSheet(ctx *gin.Context) (interface{}, error) {
+	sheetID, err := utils.ParamInt32(ctx, "id")
+	if err != nil {
+		return nil, err
+	}
+	return nil, s.SheetService.DeleteByID(ctx, sheetID)
+}
@@ -0,0 +1,55 @@
+package handler
+
+import (
+	"dash/model/dto"
+	"dash/service"
+	"dash/utils"
+	"dash/utils/xerr"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+type SitemapHandler struct {
+	SitemapService service.SitemapService
+}
+
+func NewSitemapHandler(sitemapService service.SitemapService) *SitemapHandler {
+	return &SitemapHandler{
+		SitemapService: sitemapService,
+	}
+}
+
+func (s *SitemapHandler) GetSitemap(ctx *gin.Context) (interface{}, error) {
+	content, err := s.SitemapService.GetSitemap(ctx, 0)
+	if err != nil {
+		return nil, err
+	}
+	return &dto.RawContent{ContentType: "application/xml; charset=utf-8", Data: content}, nil
+}
+
+// GetSitemapPage 分页的站点地图，如 /sitemap/2.xml
+func (s *SitemapHandler) GetSitemapPage(ctx *gin.Context) (interface{}, error) {
+	filename, err := utils.ParamString(ctx, "filename")
+	if err != nil {
+		return nil, err
+	}
+	page, err := strconv.Atoi(strings.TrimSuffix(filename, ".xml"))
+	if err != nil || page < 1 || !strings.HasSuffix(filename, ".xml") {
+		return nil, xerr.NoRecord.New("sitemap filename=%v", filename).WithMsg("sitemap page not found").WithStatus(xerr.StatusNotFound)
+	}
+	content, err := s.SitemapService.GetSitemap(ctx, page)
+	if err != nil {
+		return nil, err
+	}
+	return &dto.RawContent{ContentType: "application/xml; charset=utf-8", Data: content}, nil
+}
+
+func (s *SitemapHandler) GetRobots(ctx *gin.Context) (interface{}, error) {
+	content, err := s.SitemapService.GetRobots(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return &dto.RawContent{ContentType: "text/plain; charset=utf-8", Data: content}, nil
+}
@@ -0,0 +1,57 @@
+package handler
+
+import (
+	"dash/controller/binding"
+	"dash/model/dto"
+	"dash/model/param"
+	"dash/service"
+	"dash/utils"
+	"dash/utils/xerr"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+type SlugHistoryHandler struct {
+	SlugHistoryService service.SlugHistoryService
+}
+
+func NewSlugHistoryHandler(slugHistoryService service.SlugHistoryService) *SlugHistoryHandler {
+	return &SlugHistoryHandler{
+		SlugHistoryService: slugHistoryService,
+	}
+}
+
+func (s *SlugHistoryHandler) ListSlugHistories(ctx *gin.Context) (interface{}, error) {
+	slugHistoryQuery := param.SlugHistoryQuery{}
+	err := ctx.ShouldBindWith(&slugHistoryQuery, binding.CustomFormBinding)
+	if err != nil {
+		return nil, xerr.WithStatus(err, xerr.StatusBadRequest).WithMsg("Parameter error")
+	}
+	if slugHistoryQuery.PageSize <= 0 || slugHistoryQuery.PageSize > 100 {
+		slugHistoryQuery.PageSize = 20
+	}
+	histories, total, err := s.SlugHistoryService.Page(ctx, slugHistoryQuery)
+	if err != nil {
+		return nil, err
+	}
+	return dto.NewPage(s.SlugHistoryService.ConvertToSlugHistoryDTOs(ctx, histories), total, slugHistoryQuery.Page), nil
+}
+
+func (s *SlugHistoryHandler) DeleteSlugHistory(ctx *gin.Context) (interface{}, error) {
+	id, err := utils.ParamInt32(ctx, "id")
+	if err != nil {
+		return nil, err
+	}
+	return nil, s.SlugHistoryService.DeleteByID(ctx, id)
+}
+
+// PruneSlugHistories 删除某个时间之前的旧 slug，删除后这些旧链接不再跳转
+func (s *SlugHistoryHandler) PruneSlugHistories(ctx *gin.Context) (interface{}, error) {
+	pruneParam := &param.SlugHistoryPrune{}
+	err := ctx.ShouldBindJSON(pruneParam)
+	if err != nil {
+		return nil, xerr.WithStatus(err, xerr.StatusBadRequest).WithMsg("parameter error")
+	}
+	return s.SlugHistoryService.Prune(ctx, time.UnixMilli(pruneParam.Before), pruneParam.Type)
+}
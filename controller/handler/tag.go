@@ -17,20 +17,38 @@ import (
 )
 
 type TagHandler struct {
-	OptionService  service.OptionService
-	TagService     service.TagService
-	PostService    service.PostService
-	PostTagService service.PostTagService
-	PostAssembler  assembler.PostAssembler
+	OptionService       service.OptionService
+	TagService          service.TagService
+	PostService         service.PostService
+	PostTagService      service.PostTagService
+	PostCategoryService service.PostCategoryService
+	VisibilityService   service.VisibilityService
+	SlugHistoryService  service.SlugHistoryService
+	RecycleService      service.RecycleService
+	PostAssembler       assembler.PostAssembler
 }
 
-func NewTagHandler(optionService service.OptionService, tagService service.TagService, postService service.PostService, postTagService service.PostTagService, postAssembler assembler.PostAssembler) *TagHandler {
+func NewTagHandler(
+	optionService service.OptionService,
+	tagService service.TagService,
+	postService service.PostService,
+	postTagService service.PostTagService,
+	postCategoryService service.PostCategoryService,
+	visibilityService service.VisibilityService,
+	slugHistoryService service.SlugHistoryService,
+	recycleService service.RecycleService,
+	postAssembler assembler.PostAssembler,
+) *TagHandler {
 	return &TagHandler{
-		OptionService:  optionService,
-		TagService:     tagService,
-		PostService:    postService,
-		PostTagService: postTagService,
-		PostAssembler:  postAssembler,
+		OptionService:       optionService,
+		TagService:          tagService,
+		PostService:         postService,
+		PostTagService:      postTagService,
+		PostCategoryService: postCategoryService,
+		VisibilityService:   visibilityService,
+		SlugHistoryService:  slugHistoryService,
+		RecycleService:      recycleService,
+		PostAssembler:       postAssembler,
 	}
 }
 
@@ -55,7 +73,12 @@ func (t *TagHandler) ListTags(ctx *gin.Context) (interface{}, error) {
 		return nil, err
 	}
 	if tagQuery.Detail != nil && *tagQuery.Detail {
-		return t.TagService.ConvertToTagWithPostCountDTOs(ctx, tags)
+		// 公开接口的文章数不包含私密和未解锁的加密分类下的文章，管理员不排除
+		hiddenCategoryIDs, err := t.VisibilityService.ListHiddenCategoryIDs(ctx, getUnlockTokens(ctx))
+		if err != nil {
+			return nil, err
+		}
+		return t.TagService.ConvertToTagWithPostCountDTOs(ctx, tags, hiddenCategoryIDs)
 	}
 
 	return t.TagService.ConvertToTagDTOs(ctx, tags)
@@ -73,6 +96,10 @@ func (t *TagHandler) ListTagsWithPosts(ctx *gin.Context) (interface{}, error) {
 	if err != nil {
 		return nil, err
 	}
+	hiddenCategoryIDs, err := t.VisibilityService.ListHiddenCategoryIDs(ctx, getUnlockTokens(ctx))
+	if err != nil {
+		return nil, err
+	}
 	tagVOs := make([]*vo.Tag, 0)
 	for _, tagDTO := range tagDTOs {
 		tagVO := &vo.Tag{}
@@ -80,6 +107,10 @@ func (t *TagHandler) ListTagsWithPosts(ctx *gin.Context) (interface{}, error) {
 		if err != nil {
 			return nil, err
 		}
+		posts, err = filterHiddenPosts(ctx, t.PostCategoryService, hiddenCategoryIDs, posts)
+		if err != nil {
+			return nil, err
+		}
 		postVOs, err := t.PostAssembler.ConvertToPostVOs(ctx, posts)
 		if err != nil {
 			return nil, err
@@ -147,6 +178,9 @@ func (t *TagHandler) DeleteTag(ctx *gin.Context) (interface{}, error) {
 	if err != nil {
 		return nil, err
 	}
+	if t.OptionService.GetOrByDefault(ctx, property.RecycleCategoriesAndTags).(bool) {
+		return nil, t.RecycleService.RecycleTag(ctx, id)
+	}
 	return nil, t.TagService.DeleteByID(ctx, id)
 }
 
@@ -161,9 +195,16 @@ func (t *TagHandler) ListPostsByTagSlug(ctx *gin.Context) (interface{}, error) {
 	}
 	pageSize := t.OptionService.GetOrByDefault(ctx, property.TagPageSize).(int)
 	tag, err := t.TagService.GetTagBySlug(ctx, slug)
+	if xerr.GetType(err) == xerr.NoRecord {
+		return t.redirectOldSlug(ctx, slug, err)
+	}
 	if err != nil {
 		return "", err
 	}
+	hiddenCategoryIDs, err := t.VisibilityService.ListHiddenCategoryIDs(ctx, getUnlockTokens(ctx))
+	if err != nil {
+		return nil, err
+	}
 	id := tag.ID
 	pageQuery := param.PostQuery{
 		Page: param.Page{
@@ -174,7 +215,8 @@ func (t *TagHandler) ListPostsByTagSlug(ctx *gin.Context) (interface{}, error) {
 		Sort: &param.Sort{
 			Fields: []string{"create_time,desc"},
 		},
-		Statuses: []*consts.PostStatus{consts.PostStatusPublished.Ptr()},
+		Statuses:           []*consts.PostStatus{consts.PostStatusPublished.Ptr()},
+		ExcludeCategoryIDs: hiddenCategoryIDs,
 	}
 	posts, totalPage, err := t.PostService.Page(ctx, pageQuery)
 	if err != nil {
@@ -279,3 +321,20 @@ func (t *TagHandler) ListPostsByTagSlug(ctx *gin.Context) (interface{}, error) {
 // 	}
 // 	return t.TagService.ConvertToTagDTO(ctx, tag)
 // }
+
+// redirectOldSlug 标签改过 slug 时，旧 slug 跳转到标签现在的地址，没有历史记录时返回 notFoundErr
+func (t *TagHandler) redirectOldSlug(ctx *gin.Context, slug string, notFoundErr error) (interface{}, error) {
+	tagID, err := t.SlugHistoryService.GetTargetID(ctx, consts.SlugTypeTag, slug)
+	if err != nil {
+		return nil, notFoundErr
+	}
+	tag, err := t.TagService.GetTagByID(ctx, tagID)
+	if err != nil {
+		return nil, notFoundErr
+	}
+	tagDTO, err := t.TagService.ConvertToTagDTO(ctx, tag)
+	if err != nil {
+		return nil, err
+	}
+	return &dto.Redirect{Location: tagDTO.FullPath, Slug: tag.Slug}, nil
+}
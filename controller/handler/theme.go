@@ -5,6 +5,7 @@ import (
 	"dash/model/vo"
 	"dash/service"
 	"dash/utils"
+	"strings"
 
 	"github.com/gin-gonic/gin"
 )
@@ -90,7 +91,7 @@ func (l *ThemeHandler) GetThemeSettings(ctx *gin.Context) (interface{}, error) {
 		Icon:         getStringValue("icon", ""),
 		AvatarCircle: getBoolValue("avatar_circle", false),
 		SidebarWidth: getStringValue("sidebar_width", "20%"),
-		RSS:          getStringValue("rss", ""),
+		RSS:          getStringValue("rss", strings.TrimRight(blogURL, "/")+"/feed.xml"),
 		Twitter:      getStringValue("twitter", ""),
 		Facebook:     getStringValue("facebook", ""),
 		Instagram:    getStringValue("instagram", ""),
@@ -0,0 +1,51 @@
+package handler
+
+import (
+	"crypto/sha256"
+	"dash/consts"
+	"dash/model/entity"
+	"dash/service"
+	"encoding/hex"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+// getUnlockTokens 从请求头或查询参数中获取解锁令牌，多个令牌以逗号分隔
+func getUnlockTokens(ctx *gin.Context) []string {
+	raw := ctx.GetHeader(consts.PostUnlockTokenHeaderName)
+	if raw == "" {
+		raw = ctx.Query(consts.PostUnlockTokenQueryName)
+	}
+	tokens := make([]string, 0)
+	for _, token := range strings.Split(raw, ",") {
+		if token = strings.TrimSpace(token); token != "" {
+			tokens = append(tokens, token)
+		}
+	}
+	return tokens
+}
+
+// filterHiddenPosts 去掉属于隐藏分类的文章
+func filterHiddenPosts(ctx *gin.Context, postCategoryService service.PostCategoryService, hiddenCategoryIDs []int32, posts []*entity.Post) ([]*entity.Post, error) {
+	if len(hiddenCategoryIDs) == 0 {
+		return posts, nil
+	}
+	hiddenPostIDs, err := postCategoryService.ListPostIDSetByCategoryIDs(ctx, hiddenCategoryIDs)
+	if err != nil {
+		return nil, err
+	}
+	result := make([]*entity.Post, 0, len(posts))
+	for _, post := range posts {
+		if _, ok := hiddenPostIDs[post.ID]; !ok {
+			result = append(result, post)
+		}
+	}
+	return result, nil
+}
+
+// getVisitorID 用 IP 和 User-Agent 的哈希标识游客，不保存原始 IP
+func getVisitorID(ctx *gin.Context) string {
+	sum := sha256.Sum256([]byte(ctx.ClientIP() + "|" + ctx.Request.UserAgent()))
+	return hex.EncodeToString(sum[:])
+}
@@ -9,6 +9,7 @@ import (
 	"dash/utils/xerr"
 	"net/http"
 	"strconv"
+	"strings"
 
 	"github.com/gin-gonic/gin"
 )
@@ -87,6 +88,30 @@ func (a *AuthMiddleware) GetWrapHandler() gin.HandlerFunc {
 	}
 }
 
+// GetOptionalWrapHandler 用于公开接口，携带有效的管理员 Token 时设置当前用户，否则按游客处理，从不拦截请求
+func (a *AuthMiddleware) GetOptionalWrapHandler() gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		tokenWithBearer := ctx.GetHeader(consts.AdminTokenHeaderName)
+		token, ok := strings.CutPrefix(tokenWithBearer, "Bearer ")
+		if !ok || token == "" {
+			return
+		}
+		userID, err := cache.Redis.Get(ctx, cache.BuildTokenAccessKey(token)).Result()
+		if err != nil {
+			return
+		}
+		userIDInt, err := strconv.Atoi(userID)
+		if err != nil {
+			return
+		}
+		user, err := a.UserService.GetUserByID(ctx, int32(userIDInt))
+		if err != nil {
+			return
+		}
+		ctx.Set(consts.AuthorizedUser, user)
+	}
+}
+
 func abortWithStatusJSON(ctx *gin.Context, status int, message string) {
 	ctx.AbortWithStatusJSON(200, &dto.BaseDTO{
 		Status:  status,
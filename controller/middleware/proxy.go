@@ -0,0 +1,41 @@
+package middleware
+
+import (
+	"sync"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+)
+
+type ProxyMiddleware struct {
+	logger         *zap.Logger
+	trustedProxies []string
+	warnOnce       sync.Once
+}
+
+func NewProxyMiddleware(logger *zap.Logger, trustedProxies []string) *ProxyMiddleware {
+	return &ProxyMiddleware{
+		logger:         logger,
+		trustedProxies: trustedProxies,
+	}
+}
+
+// WarnUntrustedForwarded 没有配置 trusted_proxies 却收到 X-Forwarded-For 时提示一次。
+// 这种情况通常是部署在反向代理之后，所有访客都会被识别成代理的 IP，
+// 解锁密码的错误次数、点赞、回应和访问量去重都会被所有访客共用
+func (p *ProxyMiddleware) WarnUntrustedForwarded() gin.HandlerFunc {
+	if len(p.trustedProxies) > 0 {
+		return func(ctx *gin.Context) {
+			ctx.Next()
+		}
+	}
+	return func(ctx *gin.Context) {
+		if ctx.GetHeader("X-Forwarded-For") != "" {
+			p.warnOnce.Do(func() {
+				p.logger.Warn("request has X-Forwarded-For but server.trusted_proxies is empty, all visitors behind the proxy share one client ip",
+					zap.String("remote_addr", ctx.Request.RemoteAddr))
+			})
+		}
+		ctx.Next()
+	}
+}
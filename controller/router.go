@@ -2,6 +2,7 @@ package controller
 
 import (
 	"dash/config"
+	"dash/consts"
 	"dash/controller/middleware"
 	"dash/model/dto"
 	"net/http"
@@ -11,6 +12,7 @@ import (
 
 	"github.com/gin-contrib/cors"
 	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
 )
 
 // registerRouter 注册路由和中间件
@@ -27,6 +29,7 @@ func (s *Server) registerRouter() {
 				"Content-Type",
 				"Accept",
 				"X-Requested-With",
+				consts.PostUnlockTokenHeaderName,
 				"Access-Control-Request-Method",
 				"Access-Control-Request-Headers",
 			},
@@ -50,6 +53,8 @@ func (s *Server) registerRouter() {
 	ginLoggerMiddleware := middleware.NewGinLoggerMiddleware(s.Logger)                // 创建日志中间件
 	recoveryMiddleware := middleware.NewRecoveryMiddleware(s.Logger)                  // 创建恢复中间件
 	router.Use(ginLoggerMiddleware.Logger(), recoveryMiddleware.RecoveryWithLogger()) // 注册中间件
+	proxyMiddleware := middleware.NewProxyMiddleware(s.Logger, s.Conf.Server.TrustedProxies)
+	router.Use(proxyMiddleware.WarnUntrustedForwarded()) // 反向代理没有配置为可信时给出提示
 	// 健康检查路由
 	router.GET("/ping", func(ctx *gin.Context) {
 		ctx.JSON(http.StatusOK, dto.BaseDTO{
@@ -60,10 +65,20 @@ func (s *Server) registerRouter() {
 	})
 	staticRouter := router.Group("/")
 	{
-		staticRouter.StaticFile("", "resource/static/index.html")
+		staticRouter.GET("", s.serveIndex)
+		staticRouter.HEAD("", s.serveIndex)
+		staticRouter.GET("/sitemap.xml", s.handler(s.SitemapHandler.GetSitemap))
+		staticRouter.GET("/sitemap/:filename", s.handler(s.SitemapHandler.GetSitemapPage)) // 链接超过 50000 条时的分页，如 /sitemap/2.xml
+		staticRouter.GET("/robots.txt", s.handler(s.SitemapHandler.GetRobots))
+		// 订阅：feed.xml 为 RSS 2.0，atom.xml 为 Atom，feed.json 为 JSON Feed
+		staticRouter.GET("/feed.xml", s.handler(s.FeedHandler.GetFeed))
+		staticRouter.GET("/atom.xml", s.handler(s.FeedHandler.GetFeed))
+		staticRouter.GET("/feed.json", s.handler(s.FeedHandler.GetFeed))
+		staticRouter.GET("/feed/categories/:slug/:filename", s.handler(s.FeedHandler.GetCategoryFeed))
+		staticRouter.GET("/feed/tags/:slug/:filename", s.handler(s.FeedHandler.GetTagFeed))
 		staticRouter.StaticFS("/assets", gin.Dir("resource/static/assets", false)) // 挂载静态资源目录（JS/CSS/图片等）
 	}
-	publicRouter := router.Group("/api")
+	publicRouter := router.Group("/api", s.AuthMiddleware.GetOptionalWrapHandler()) // 公开接口，携带管理员 Token 时可以看到草稿和私密文章
 	{
 
 		publicThemeRouter := publicRouter.Group("/theme")
@@ -74,7 +89,13 @@ func (s *Server) registerRouter() {
 		{
 			publicPostRouter.GET("", s.handler(s.PostHandler.ListPosts))
 			publicPostRouter.GET("/:slug", s.handler(s.PostHandler.GetPostBySlug))
+			publicPostRouter.POST("/:slug/unlock", s.handler(s.PostHandler.UnlockPost))
+			publicPostRouter.GET("/:slug/related", s.handler(s.PostHandler.ListRelatedPosts))
+			publicPostRouter.POST("/:slug/likes", s.handler(s.PostHandler.LikePost))        // :slug 为文章 ID
+			publicPostRouter.POST("/:slug/reactions", s.handler(s.PostHandler.ReactToPost)) // :slug 为文章 ID
+			publicPostRouter.GET("/reactions", s.handler(s.PostHandler.ListAvailableReactions))
 			publicPostRouter.GET("/search", s.handler(s.PostHandler.SearchPost))
+			publicPostRouter.GET("/search/suggest", s.handler(s.PostHandler.SuggestSearch))
 			publicPostRouter.GET("/archive", s.handler(s.PostHandler.GetPostArchive))
 		}
 		publicMenuRouter := publicRouter.Group("/menus")
@@ -85,6 +106,7 @@ func (s *Server) registerRouter() {
 		{
 			publicCategoryRouter.GET("", s.handler(s.CategoryHandler.ListCategoriesWithPosts))
 			publicCategoryRouter.GET("/:slug/posts", s.handler(s.CategoryHandler.ListPostsByCategorySlug))
+			publicCategoryRouter.POST("/:slug/unlock", s.handler(s.CategoryHandler.UnlockCategory))
 		}
 		publicTagRouter := publicRouter.Group("/tags")
 		{
@@ -93,10 +115,15 @@ func (s *Server) registerRouter() {
 			publicTagRouter.GET("/count", s.handler(s.TagHandler.ListTags))
 
 		}
+		publicSeriesRouter := publicRouter.Group("/series")
+		{
+			publicSeriesRouter.GET("/:slug", s.handler(s.SeriesHandler.GetSeriesBySlug))
+		}
 		publicSheetRouter := publicRouter.Group("/sheet")
 		{
-			publicSheetRouter.GET("/:slug", s.handler(s.PostHandler.GetPostBySlug))
+			publicSheetRouter.GET("/*path", s.handler(s.SheetHandler.GetSheetByPath))
 		}
+		publicRouter.GET("/permalink", s.handler(s.PermalinkHandler.ResolvePermalink)) // ?path=/2025/03/14/my-post.html
 
 	}
 	adminRouter := router.Group("/api/admin")
@@ -116,14 +143,35 @@ func (s *Server) registerRouter() {
 		adminPostsRouter := adminRouter.Group("/posts").Use(s.AuthMiddleware.GetWrapHandler())
 		{
 			adminPostsRouter.GET("", s.handler(s.PostHandler.ListPosts))
+			adminPostsRouter.GET("/scheduled", s.handler(s.PostHandler.ListScheduledPosts))
 			adminPostsRouter.GET("/:id", s.handler(s.PostHandler.GetPostByID))
+			adminPostsRouter.GET("/:id/metas", s.handler(s.PostHandler.ListPostMetas))
 			adminPostsRouter.GET("/slug/:slug", s.handler(s.PostHandler.GetPostBySlug))
 			adminPostsRouter.POST("", s.handler(s.PostHandler.CreatePost))
+			adminPostsRouter.POST("/render", s.handler(s.PostHandler.RenderPosts))
+			adminPostsRouter.POST("/search/rebuild", s.handler(s.PostHandler.RebuildSearchIndex))
 			adminPostsRouter.PUT("/:id", s.handler(s.PostHandler.UpdatePost))
 			adminPostsRouter.PATCH("/:id/status/:status", s.handler(s.PostHandler.UpdatePostStatus))
 			adminPostsRouter.PATCH("/status/:status", s.handler(s.PostHandler.UpdatePostStatusBatch))
 			adminPostsRouter.DELETE("/:id", s.handler((s.PostHandler.DeletePost)))
 			adminPostsRouter.DELETE("", s.handler((s.PostHandler.DeletePostBatch)))
+			adminPostsRouter.GET("/:id/revisions", s.handler(s.PostRevisionHandler.ListRevisions))
+			adminPostsRouter.GET("/:id/revisions/diff", s.handler(s.PostRevisionHandler.DiffRevisions))
+			adminPostsRouter.GET("/:id/revisions/:revisionID", s.handler(s.PostRevisionHandler.GetRevision))
+			adminPostsRouter.POST("/:id/revisions/:revisionID/restore", s.handler(s.PostRevisionHandler.RestoreRevision))
+			adminPostsRouter.PUT("/:id/draft", s.handler(s.PostDraftHandler.SaveDraft))
+			adminPostsRouter.POST("/:id/draft/publish", s.handler(s.PostDraftHandler.PublishDraft))
+			adminPostsRouter.DELETE("/:id/draft", s.handler(s.PostDraftHandler.DiscardDraft))
+		}
+		adminSheetRouter := adminRouter.Group("/sheets").Use(s.AuthMiddleware.GetWrapHandler())
+		{
+			adminSheetRouter.GET("", s.handler(s.SheetHandler.ListSheets))
+			adminSheetRouter.GET("/:id", s.handler(s.SheetHandler.GetSheetByID))
+			adminSheetRouter.GET("/:id/metas", s.handler(s.PostHandler.ListPostMetas))
+			adminSheetRouter.POST("", s.handler(s.SheetHandler.CreateSheet))
+			adminSheetRouter.PUT("/:id", s.handler(s.SheetHandler.UpdateSheet))
+			adminSheetRouter.PATCH("/:id/status/:status", s.handler(s.SheetHandler.UpdateSheetStatus))
+			adminSheetRouter.DELETE("/:id", s.handler(s.SheetHandler.DeleteSheet))
 		}
 		adminCategoryRouter := adminRouter.Group("/categories").Use(s.AuthMiddleware.GetWrapHandler())
 		{
@@ -141,6 +189,47 @@ func (s *Server) registerRouter() {
 			adminTagRouter.PUT("/:id", s.handler(s.TagHandler.UpdateTag))
 			adminTagRouter.DELETE("/:id", s.handler(s.TagHandler.DeleteTag))
 		}
+		adminSeriesRouter := adminRouter.Group("/series").Use(s.AuthMiddleware.GetWrapHandler())
+		{
+			adminSeriesRouter.GET("", s.handler(s.SeriesHandler.ListSeries))
+			adminSeriesRouter.GET("/:id", s.handler(s.SeriesHandler.GetSeriesByID))
+			adminSeriesRouter.POST("", s.handler(s.SeriesHandler.CreateSeries))
+			adminSeriesRouter.PUT("/:id", s.handler(s.SeriesHandler.UpdateSeries))
+			adminSeriesRouter.DELETE("/:id", s.handler(s.SeriesHandler.DeleteSeries))
+			adminSeriesRouter.PUT("/:id/posts", s.handler(s.SeriesHandler.UpdateSeriesPosts)) // 按 post_ids 的顺序整体替换系列中的文章
+		}
+		adminSlugHistoryRouter := adminRouter.Group("/slug_histories").Use(s.AuthMiddleware.GetWrapHandler())
+		{
+			adminSlugHistoryRouter.GET("", s.handler(s.SlugHistoryHandler.ListSlugHistories))
+			adminSlugHistoryRouter.POST("/prune", s.handler(s.SlugHistoryHandler.PruneSlugHistories))
+			adminSlugHistoryRouter.DELETE("/:id", s.handler(s.SlugHistoryHandler.DeleteSlugHistory))
+		}
+		adminRecycleRouter := adminRouter.Group("/recycle").Use(s.AuthMiddleware.GetWrapHandler())
+		{
+			adminRecycleRouter.GET("", s.handler(s.RecycleHandler.ListRecycleItems))
+			adminRecycleRouter.POST("/:id/restore", s.handler(s.RecycleHandler.RestoreRecycleItem))
+			adminRecycleRouter.DELETE("/:id", s.handler(s.RecycleHandler.PurgeRecycleItem))
+			adminRecycleRouter.DELETE("", s.handler(s.RecycleHandler.EmptyRecycleBin))
+		}
+		adminImportRouter := adminRouter.Group("/import").Use(s.AuthMiddleware.GetWrapHandler())
+		{
+			adminImportRouter.POST("/markdown", s.handler(s.ImportHandler.ImportMarkdown))
+			adminImportRouter.POST("/wordpress", s.handler(s.ImportHandler.ImportWordPress))
+		}
+		adminExportRouter := adminRouter.Group("/export").Use(s.AuthMiddleware.GetWrapHandler())
+		{
+			adminExportRouter.POST("/markdown", s.handler(s.ExportHandler.ExportMarkdown))
+			adminExportRouter.GET("/markdown/:filename", s.handler(s.ExportHandler.DownloadMarkdownExport)) // 使用 ExportMarkdown 返回的带 ott 的链接下载
+		}
+		adminBackupRouter := adminRouter.Group("/backups").Use(s.AuthMiddleware.GetWrapHandler())
+		{
+			adminBackupRouter.GET("", s.handler(s.BackupHandler.ListBackups))
+			adminBackupRouter.POST("", s.handler(s.BackupHandler.CreateBackup))
+			adminBackupRouter.POST("/restore", s.handler(s.BackupHandler.RestoreUploadedBackup))
+			adminBackupRouter.GET("/:filename", s.handler(s.BackupHandler.DownloadBackup)) // 使用 CreateBackup 返回的带 ott 的链接下载
+			adminBackupRouter.DELETE("/:filename", s.handler(s.BackupHandler.DeleteBackup))
+			adminBackupRouter.POST("/:filename/restore", s.handler(s.BackupHandler.RestoreBackup))
+		}
 	}
 
 	// NoRoute 回退：
@@ -157,6 +246,19 @@ func (s *Server) registerRouter() {
 			return
 		}
 		// 非 /api 的路径统一回退至 index.html（React SPA 入口）
-		ctx.File("resource/static/index.html")
+		s.serveIndex(ctx)
 	})
 }
+
+// serveIndex 输出 index.html，GET 请求按路径注入标题、描述等页面信息，方便爬虫和链接预览；注入失败时输出原始文件
+func (s *Server) serveIndex(ctx *gin.Context) {
+	if ctx.Request.Method == http.MethodGet || ctx.Request.Method == http.MethodHead {
+		html, err := s.SEOHandler.RenderIndex(ctx)
+		if err == nil {
+			ctx.Data(http.StatusOK, "text/html; charset=utf-8", html)
+			return
+		}
+		s.Logger.Error("render index.html failed", zap.String("path", ctx.Request.URL.Path), zap.Error(err))
+	}
+	ctx.File("resource/static/index.html")
+}
@@ -1,14 +1,18 @@
 package controller
 
 import (
+	"bytes"
 	"context"
+	"crypto/sha256"
 	"dash/cache"
 	"dash/config"
 	"dash/controller/handler"
 	"dash/controller/middleware"
 	"dash/model/dto"
 	"dash/model/param"
+	"dash/scheduler"
 	"dash/utils/xerr"
+	"encoding/hex"
 	"fmt"
 	"net/http"
 	"os"
@@ -34,15 +38,29 @@ type Server struct {
 	HttpServer *http.Server // HTTP服务器实例
 
 	AuthMiddleware *middleware.AuthMiddleware
+	Scheduler      *scheduler.Scheduler // 定时任务调度器
 
-	PostHandler      *handler.PostHandler
-	CategoryHandler  *handler.CategoryHandler // 分类处理器
-	TagHandler       *handler.TagHandler
-	StatisticHandler *handler.StatisticsHandler
-	ThemeHandler     *handler.ThemeHandler
-	MenuHandler      *handler.MenuHandler
-	AdminHandler     *handler.AdminHandler
-	InstallHandler   *handler.InstallHandler
+	PostHandler         *handler.PostHandler
+	PostRevisionHandler *handler.PostRevisionHandler
+	PostDraftHandler    *handler.PostDraftHandler
+	SheetHandler        *handler.SheetHandler
+	PermalinkHandler    *handler.PermalinkHandler
+	SlugHistoryHandler  *handler.SlugHistoryHandler
+	RecycleHandler      *handler.RecycleHandler
+	ImportHandler       *handler.ImportHandler
+	ExportHandler       *handler.ExportHandler
+	BackupHandler       *handler.BackupHandler
+	CategoryHandler     *handler.CategoryHandler // 分类处理器
+	TagHandler          *handler.TagHandler
+	SeriesHandler       *handler.SeriesHandler
+	SEOHandler          *handler.SEOHandler
+	SitemapHandler      *handler.SitemapHandler
+	FeedHandler         *handler.FeedHandler
+	StatisticHandler    *handler.StatisticsHandler
+	ThemeHandler        *handler.ThemeHandler
+	MenuHandler         *handler.MenuHandler
+	AdminHandler        *handler.AdminHandler
+	InstallHandler      *handler.InstallHandler
 }
 
 func NewServer(
@@ -51,10 +69,24 @@ func NewServer(
 	db *gorm.DB,
 	cache *cache.RedisCache,
 	authMiddleware *middleware.AuthMiddleware,
+	scheduler *scheduler.Scheduler,
 
 	postHandler *handler.PostHandler,
+	postRevisionHandler *handler.PostRevisionHandler,
+	postDraftHandler *handler.PostDraftHandler,
+	sheetHandler *handler.SheetHandler,
+	permalinkHandler *handler.PermalinkHandler,
+	slugHistoryHandler *handler.SlugHistoryHandler,
+	recycleHandler *handler.RecycleHandler,
+	importHandler *handler.ImportHandler,
+	exportHandler *handler.ExportHandler,
+	backupHandler *handler.BackupHandler,
 	categoryHandler *handler.CategoryHandler,
 	tagHandler *handler.TagHandler,
+	seriesHandler *handler.SeriesHandler,
+	seoHandler *handler.SEOHandler,
+	sitemapHandler *handler.SitemapHandler,
+	feedHandler *handler.FeedHandler,
 	statisticHandler *handler.StatisticsHandler,
 	themeHandler *handler.ThemeHandler,
 	menuHandler *handler.MenuHandler,
@@ -67,6 +99,10 @@ func NewServer(
 	}
 	// 创建Gin路由引擎
 	router := gin.New()
+	// gin 默认信任所有代理，任何客户端都能通过 X-Forwarded-For 伪造 IP
+	if err := router.SetTrustedProxies(conf.Server.TrustedProxies); err != nil {
+		panic(err)
+	}
 
 	// 创建HTTP服务器实例
 	httpServer := &http.Server{
@@ -84,15 +120,29 @@ func NewServer(
 		HttpServer: httpServer, // 设置HTTP服务器
 
 		AuthMiddleware: authMiddleware,
+		Scheduler:      scheduler,
 
-		PostHandler:      postHandler,
-		CategoryHandler:  categoryHandler, // 设置分类处理器
-		TagHandler:       tagHandler,
-		StatisticHandler: statisticHandler,
-		ThemeHandler:     themeHandler,
-		MenuHandler:      menuHandler,
-		AdminHandler:     adminHandler,
-		InstallHandler:   installHandler,
+		PostHandler:         postHandler,
+		PostRevisionHandler: postRevisionHandler,
+		PostDraftHandler:    postDraftHandler,
+		SheetHandler:        sheetHandler,
+		PermalinkHandler:    permalinkHandler,
+		SlugHistoryHandler:  slugHistoryHandler,
+		RecycleHandler:      recycleHandler,
+		ImportHandler:       importHandler,
+		ExportHandler:       exportHandler,
+		BackupHandler:       backupHandler,
+		CategoryHandler:     categoryHandler, // 设置分类处理器
+		TagHandler:          tagHandler,
+		SeriesHandler:       seriesHandler,
+		SEOHandler:          seoHandler,
+		SitemapHandler:      sitemapHandler,
+		FeedHandler:         feedHandler,
+		StatisticHandler:    statisticHandler,
+		ThemeHandler:        themeHandler,
+		MenuHandler:         menuHandler,
+		AdminHandler:        adminHandler,
+		InstallHandler:      installHandler,
 	}
 
 	// 注册路由
@@ -189,6 +239,8 @@ func (s *Server) Run() {
 		}
 	}()
 	s.Logger.Info(fmt.Sprintf("Dash backend server run at %s\n", s.HttpServer.Addr))
+	// 启动定时任务
+	s.Scheduler.Start()
 	// Wait for interrupt signal to gracefully shutdown the server with
 	// a timeout of 5 seconds.
 	quit := make(chan os.Signal, 1)
@@ -198,6 +250,7 @@ func (s *Server) Run() {
 	signal.Notify(quit, syscall.SIGINT, syscall.SIGTERM)
 	<-quit
 	s.Logger.Info("shutdown server ...")
+	s.Scheduler.Stop()
 
 	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
 	defer cancel()
@@ -222,6 +275,29 @@ func (s *Server) handler(h h) gin.HandlerFunc {
 			ctx.JSON(200, &dto.BaseDTO{Status: status, Message: xerr.GetMessage(err)})
 			return
 		}
+		// 旧 slug 跳转，HTTP 状态仍为 200，避免浏览器自动跟随到前端页面地址
+		if redirect, ok := data.(*dto.Redirect); ok {
+			ctx.Header("Location", redirect.Location)
+			ctx.JSON(http.StatusOK, &dto.BaseDTO{
+				Status:  http.StatusMovedPermanently,
+				Data:    redirect,
+				Message: "Moved Permanently",
+			})
+			return
+		}
+		// 文件下载，以附件形式输出
+		if download, ok := data.(*dto.FileDownload); ok {
+			ctx.FileAttachment(download.Path, download.Filename)
+			return
+		}
+		// sitemap.xml、robots.txt、订阅等按原始内容输出，ETag 由内容计算，ServeContent 处理 If-None-Match、If-Modified-Since
+		if raw, ok := data.(*dto.RawContent); ok {
+			sum := sha256.Sum256(raw.Data)
+			ctx.Header("Content-Type", raw.ContentType)
+			ctx.Header("ETag", `"`+hex.EncodeToString(sum[:16])+`"`)
+			http.ServeContent(ctx.Writer, ctx.Request, "", raw.LastModified, bytes.NewReader(raw.Data))
+			return
+		}
 
 		// 返回成功响应
 		ctx.JSON(http.StatusOK, &dto.BaseDTO{
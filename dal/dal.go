@@ -89,11 +89,16 @@ func initMySQL(conf *config.Config, gormLogger logger.Interface) (*gorm.DB, erro
 	return db, err
 }
 
+// Models 所有数据表对应的实体，新增数据表时需要加到这里，备份和恢复也按这个列表处理
+func Models() []interface{} {
+	return []interface{}{&entity.Category{}, &entity.Menu{}, &entity.Option{}, &entity.Post{}, &entity.PostCategory{}, &entity.PostTag{}, &entity.Tag{}, &entity.ThemeSetting{}, &entity.User{}, &entity.PostRevision{}, &entity.PostMeta{}, &entity.PostReaction{}, &entity.PostSearchIndex{}, &entity.SlugHistory{}, &entity.RecycleItem{}, &entity.Series{}, &entity.SeriesPost{}, &entity.PostDraft{}}
+}
+
 func autoMigrate() {
 	db := DB.Session(&gorm.Session{
 		Logger: DB.Logger.LogMode(logger.Warn),
 	})
-	err := db.AutoMigrate(&entity.Category{}, &entity.Menu{}, &entity.Option{}, &entity.Post{}, &entity.PostCategory{}, &entity.PostTag{}, &entity.Tag{}, &entity.ThemeSetting{}, &entity.User{})
+	err := db.AutoMigrate(Models()...)
 	if err != nil {
 		dashLog.Fatal("failed auto migrate db", zap.Error(err))
 	}
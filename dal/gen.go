@@ -16,16 +16,25 @@ import (
 )
 
 var (
-	Q            = new(Query)
-	Category     *category
-	Menu         *menu
-	Option       *option
-	Post         *post
-	PostCategory *postCategory
-	PostTag      *postTag
-	Tag          *tag
-	ThemeSetting *themeSetting
-	User         *user
+	Q               = new(Query)
+	Category        *category
+	Menu            *menu
+	Option          *option
+	Post            *post
+	PostCategory    *postCategory
+	PostDraft       *postDraft
+	PostMeta        *postMeta
+	PostReaction    *postReaction
+	PostRevision    *postRevision
+	PostSearchIndex *postSearchIndex
+	PostTag         *postTag
+	RecycleItem     *recycleItem
+	Series          *series
+	SeriesPost      *seriesPost
+	SlugHistory     *slugHistory
+	Tag             *tag
+	ThemeSetting    *themeSetting
+	User            *user
 )
 
 func SetDefault(db *gorm.DB, opts ...gen.DOOption) {
@@ -35,7 +44,16 @@ func SetDefault(db *gorm.DB, opts ...gen.DOOption) {
 	Option = &Q.Option
 	Post = &Q.Post
 	PostCategory = &Q.PostCategory
+	PostDraft = &Q.PostDraft
+	PostMeta = &Q.PostMeta
+	PostReaction = &Q.PostReaction
+	PostRevision = &Q.PostRevision
+	PostSearchIndex = &Q.PostSearchIndex
 	PostTag = &Q.PostTag
+	RecycleItem = &Q.RecycleItem
+	Series = &Q.Series
+	SeriesPost = &Q.SeriesPost
+	SlugHistory = &Q.SlugHistory
 	Tag = &Q.Tag
 	ThemeSetting = &Q.ThemeSetting
 	User = &Q.User
@@ -43,47 +61,74 @@ func SetDefault(db *gorm.DB, opts ...gen.DOOption) {
 
 func Use(db *gorm.DB, opts ...gen.DOOption) *Query {
 	return &Query{
-		db:           db,
-		Category:     newCategory(db, opts...),
-		Menu:         newMenu(db, opts...),
-		Option:       newOption(db, opts...),
-		Post:         newPost(db, opts...),
-		PostCategory: newPostCategory(db, opts...),
-		PostTag:      newPostTag(db, opts...),
-		Tag:          newTag(db, opts...),
-		ThemeSetting: newThemeSetting(db, opts...),
-		User:         newUser(db, opts...),
+		db:              db,
+		Category:        newCategory(db, opts...),
+		Menu:            newMenu(db, opts...),
+		Option:          newOption(db, opts...),
+		Post:            newPost(db, opts...),
+		PostCategory:    newPostCategory(db, opts...),
+		PostDraft:       newPostDraft(db, opts...),
+		PostMeta:        newPostMeta(db, opts...),
+		PostReaction:    newPostReaction(db, opts...),
+		PostRevision:    newPostRevision(db, opts...),
+		PostSearchIndex: newPostSearchIndex(db, opts...),
+		PostTag:         newPostTag(db, opts...),
+		RecycleItem:     newRecycleItem(db, opts...),
+		Series:          newSeries(db, opts...),
+		SeriesPost:      newSeriesPost(db, opts...),
+		SlugHistory:     newSlugHistory(db, opts...),
+		Tag:             newTag(db, opts...),
+		ThemeSetting:    newThemeSetting(db, opts...),
+		User:            newUser(db, opts...),
 	}
 }
 
 type Query struct {
 	db *gorm.DB
 
-	Category     category
-	Menu         menu
-	Option       option
-	Post         post
-	PostCategory postCategory
-	PostTag      postTag
-	Tag          tag
-	ThemeSetting themeSetting
-	User         user
+	Category        category
+	Menu            menu
+	Option          option
+	Post            post
+	PostCategory    postCategory
+	PostDraft       postDraft
+	PostMeta        postMeta
+	PostReaction    postReaction
+	PostRevision    postRevision
+	PostSearchIndex postSearchIndex
+	PostTag         postTag
+	RecycleItem     recycleItem
+	Series          series
+	SeriesPost      seriesPost
+	SlugHistory     slugHistory
+	Tag             tag
+	ThemeSetting    themeSetting
+	User            user
 }
 
 func (q *Query) Available() bool { return q.db != nil }
 
 func (q *Query) clone(db *gorm.DB) *Query {
 	return &Query{
-		db:           db,
-		Category:     q.Category.clone(db),
-		Menu:         q.Menu.clone(db),
-		Option:       q.Option.clone(db),
-		Post:         q.Post.clone(db),
-		PostCategory: q.PostCategory.clone(db),
-		PostTag:      q.PostTag.clone(db),
-		Tag:          q.Tag.clone(db),
-		ThemeSetting: q.ThemeSetting.clone(db),
-		User:         q.User.clone(db),
+		db:              db,
+		Category:        q.Category.clone(db),
+		Menu:            q.Menu.clone(db),
+		Option:          q.Option.clone(db),
+		Post:            q.Post.clone(db),
+		PostCategory:    q.PostCategory.clone(db),
+		PostDraft:       q.PostDraft.clone(db),
+		PostMeta:        q.PostMeta.clone(db),
+		PostReaction:    q.PostReaction.clone(db),
+		PostRevision:    q.PostRevision.clone(db),
+		PostSearchIndex: q.PostSearchIndex.clone(db),
+		PostTag:         q.PostTag.clone(db),
+		RecycleItem:     q.RecycleItem.clone(db),
+		Series:          q.Series.clone(db),
+		SeriesPost:      q.SeriesPost.clone(db),
+		SlugHistory:     q.SlugHistory.clone(db),
+		Tag:             q.Tag.clone(db),
+		ThemeSetting:    q.ThemeSetting.clone(db),
+		User:            q.User.clone(db),
 	}
 }
 
@@ -97,42 +142,69 @@ func (q *Query) WriteDB() *Query {
 
 func (q *Query) ReplaceDB(db *gorm.DB) *Query {
 	return &Query{
-		db:           db,
-		Category:     q.Category.replaceDB(db),
-		Menu:         q.Menu.replaceDB(db),
-		Option:       q.Option.replaceDB(db),
-		Post:         q.Post.replaceDB(db),
-		PostCategory: q.PostCategory.replaceDB(db),
-		PostTag:      q.PostTag.replaceDB(db),
-		Tag:          q.Tag.replaceDB(db),
-		ThemeSetting: q.ThemeSetting.replaceDB(db),
-		User:         q.User.replaceDB(db),
+		db:              db,
+		Category:        q.Category.replaceDB(db),
+		Menu:            q.Menu.replaceDB(db),
+		Option:          q.Option.replaceDB(db),
+		Post:            q.Post.replaceDB(db),
+		PostCategory:    q.PostCategory.replaceDB(db),
+		PostDraft:       q.PostDraft.replaceDB(db),
+		PostMeta:        q.PostMeta.replaceDB(db),
+		PostReaction:    q.PostReaction.replaceDB(db),
+		PostRevision:    q.PostRevision.replaceDB(db),
+		PostSearchIndex: q.PostSearchIndex.replaceDB(db),
+		PostTag:         q.PostTag.replaceDB(db),
+		RecycleItem:     q.RecycleItem.replaceDB(db),
+		Series:          q.Series.replaceDB(db),
+		SeriesPost:      q.SeriesPost.replaceDB(db),
+		SlugHistory:     q.SlugHistory.replaceDB(db),
+		Tag:             q.Tag.replaceDB(db),
+		ThemeSetting:    q.ThemeSetting.replaceDB(db),
+		User:            q.User.replaceDB(db),
 	}
 }
 
 type queryCtx struct {
-	Category     *categoryDo
-	Menu         *menuDo
-	Option       *optionDo
-	Post         *postDo
-	PostCategory *postCategoryDo
-	PostTag      *postTagDo
-	Tag          *tagDo
-	ThemeSetting *themeSettingDo
-	User         *userDo
+	Category        *categoryDo
+	Menu            *menuDo
+	Option          *optionDo
+	Post            *postDo
+	PostCategory    *postCategoryDo
+	PostDraft       *postDraftDo
+	PostMeta        *postMetaDo
+	PostReaction    *postReactionDo
+	PostRevision    *postRevisionDo
+	PostSearchIndex *postSearchIndexDo
+	PostTag         *postTagDo
+	RecycleItem     *recycleItemDo
+	Series          *seriesDo
+	SeriesPost      *seriesPostDo
+	SlugHistory     *slugHistoryDo
+	Tag             *tagDo
+	ThemeSetting    *themeSettingDo
+	User            *userDo
 }
 
 func (q *Query) WithContext(ctx context.Context) *queryCtx {
 	return &queryCtx{
-		Category:     q.Category.WithContext(ctx),
-		Menu:         q.Menu.WithContext(ctx),
-		Option:       q.Option.WithContext(ctx),
-		Post:         q.Post.WithContext(ctx),
-		PostCategory: q.PostCategory.WithContext(ctx),
-		PostTag:      q.PostTag.WithContext(ctx),
-		Tag:          q.Tag.WithContext(ctx),
-		ThemeSetting: q.ThemeSetting.WithContext(ctx),
-		User:         q.User.WithContext(ctx),
+		Category:        q.Category.WithContext(ctx),
+		Menu:            q.Menu.WithContext(ctx),
+		Option:          q.Option.WithContext(ctx),
+		Post:            q.Post.WithContext(ctx),
+		PostCategory:    q.PostCategory.WithContext(ctx),
+		PostDraft:       q.PostDraft.WithContext(ctx),
+		PostMeta:        q.PostMeta.WithContext(ctx),
+		PostReaction:    q.PostReaction.WithContext(ctx),
+		PostRevision:    q.PostRevision.WithContext(ctx),
+		PostSearchIndex: q.PostSearchIndex.WithContext(ctx),
+		PostTag:         q.PostTag.WithContext(ctx),
+		RecycleItem:     q.RecycleItem.WithContext(ctx),
+		Series:          q.Series.WithContext(ctx),
+		SeriesPost:      q.SeriesPost.WithContext(ctx),
+		SlugHistory:     q.SlugHistory.WithContext(ctx),
+		Tag:             q.Tag.WithContext(ctx),
+		ThemeSetting:    q.ThemeSetting.WithContext(ctx),
+		User:            q.User.WithContext(ctx),
 	}
 }
 
@@ -49,6 +49,10 @@ func newPost(db *gorm.DB, opts ...gen.DOOption) post {
 	_post.MetaKeywords = field.NewString(tableName, "meta_keywords")
 	_post.Password = field.NewString(tableName, "password")
 	_post.Template = field.NewString(tableName, "template")
+	_post.PublishTime = field.NewTime(tableName, "publish_time")
+	_post.ExpireTime = field.NewTime(tableName, "expire_time")
+	_post.Toc = field.NewString(tableName, "toc")
+	_post.ParentID = field.NewInt32(tableName, "parent_id")
 
 	_post.fillFieldMap()
 
@@ -81,6 +85,10 @@ type post struct {
 	MetaKeywords    field.String
 	Password        field.String
 	Template        field.String
+	PublishTime     field.Time
+	ExpireTime      field.Time
+	Toc             field.String
+	ParentID        field.Int32
 
 	fieldMap map[string]field.Expr
 }
@@ -119,6 +127,10 @@ func (p *post) updateTableName(table string) *post {
 	p.MetaKeywords = field.NewString(table, "meta_keywords")
 	p.Password = field.NewString(table, "password")
 	p.Template = field.NewString(table, "template")
+	p.PublishTime = field.NewTime(table, "publish_time")
+	p.ExpireTime = field.NewTime(table, "expire_time")
+	p.Toc = field.NewString(table, "toc")
+	p.ParentID = field.NewInt32(table, "parent_id")
 
 	p.fillFieldMap()
 
@@ -143,7 +155,7 @@ func (p *post) GetFieldByName(fieldName string) (field.OrderExpr, bool) {
 }
 
 func (p *post) fillFieldMap() {
-	p.fieldMap = make(map[string]field.Expr, 22)
+	p.fieldMap = make(map[string]field.Expr, 26)
 	p.fieldMap["id"] = p.ID
 	p.fieldMap["type"] = p.Type
 	p.fieldMap["create_time"] = p.CreateTime
@@ -166,6 +178,10 @@ func (p *post) fillFieldMap() {
 	p.fieldMap["meta_keywords"] = p.MetaKeywords
 	p.fieldMap["password"] = p.Password
 	p.fieldMap["template"] = p.Template
+	p.fieldMap["publish_time"] = p.PublishTime
+	p.fieldMap["expire_time"] = p.ExpireTime
+	p.fieldMap["toc"] = p.Toc
+	p.fieldMap["parent_id"] = p.ParentID
 }
 
 func (p post) clone(db *gorm.DB) post {
@@ -0,0 +1,24 @@
+package injection
+
+import (
+	"dash/cache"
+	"dash/config"
+	"dash/service"
+
+	"gorm.io/gorm"
+)
+
+// ImportCommand 命令行导入文章使用，只初始化数据库、缓存和导入服务，不启动 HTTP 服务
+type ImportCommand struct {
+	DB            *gorm.DB
+	Cache         *cache.RedisCache
+	ImportService service.ImportService
+}
+
+// BackupCommand 命令行备份和恢复使用
+type BackupCommand struct {
+	Config        *config.Config
+	DB            *gorm.DB
+	Cache         *cache.RedisCache
+	BackupService service.BackupService
+}
@@ -12,6 +12,7 @@ import (
 	"dash/controller/handler"
 	"dash/controller/middleware"
 	"dash/log"
+	"dash/scheduler"
 	"dash/service/assembler"
 	"dash/service/impl"
 
@@ -40,6 +41,26 @@ func NewDashServer() *controller.Server {
 		impl.NewCategoryService,
 		impl.NewPostTagService,
 		impl.NewPostCategoryService,
+		impl.NewPostRevisionService,
+		impl.NewPostDraftService,
+		impl.NewMetaService,
+		impl.NewSlugHistoryService,
+		impl.NewRecycleService,
+		impl.NewImportService,
+		impl.NewExportService,
+		impl.NewBackupService,
+		impl.NewSeriesService,
+		impl.NewSEOService,
+		impl.NewSitemapService,
+		impl.NewFeedService,
+		impl.NewSheetService,
+		impl.NewReactionService,
+		impl.NewVisitService,
+		impl.NewSearchService,
+		impl.NewRelatedPostService,
+		impl.NewPermalinkService,
+		impl.NewVisibilityService,
+		impl.NewMarkdownService,
 		impl.NewUserService,
 		impl.NewThemeService,
 		impl.NewMenuService,
@@ -51,11 +72,25 @@ func NewDashServer() *controller.Server {
 		// 组装器
 		assembler.NewBasePostAssembler,
 		assembler.NewPostAssembler,
+		assembler.NewSheetAssembler,
 
 		// 处理器
 		handler.NewCategoryHandler,
 		handler.NewTagHandler,
+		handler.NewSeriesHandler,
+		handler.NewSEOHandler,
+		handler.NewSitemapHandler,
+		handler.NewFeedHandler,
 		handler.NewPostHandler,
+		handler.NewPostRevisionHandler,
+		handler.NewPostDraftHandler,
+		handler.NewSheetHandler,
+		handler.NewPermalinkHandler,
+		handler.NewSlugHistoryHandler,
+		handler.NewRecycleHandler,
+		handler.NewImportHandler,
+		handler.NewExportHandler,
+		handler.NewBackupHandler,
 		handler.NewStatisticsHandler,
 
 		handler.NewThemeHandler,
@@ -63,8 +98,61 @@ func NewDashServer() *controller.Server {
 
 		handler.NewAdminHandler,
 		handler.NewInstallHandler,
+		scheduler.NewScheduler,
 		controller.NewServer,
 		middleware.NewAuthMiddleware,
 	)
 	return nil
 }
+
+// NewImportCommand 初始化命令行导入需要的依赖
+func NewImportCommand() *ImportCommand {
+	wire.Build(
+		config.NewConfig,
+		log.NewLogger,
+		log.NewGormLogger,
+
+		cache.NewRedisCache,
+		dal.NewGormDB,
+
+		impl.NewOptionService,
+		impl.NewBasePostService,
+		impl.NewPostService,
+		impl.NewTagService,
+		impl.NewCategoryService,
+		impl.NewPostRevisionService,
+		impl.NewMetaService,
+		impl.NewSlugHistoryService,
+		impl.NewSearchService,
+		impl.NewRelatedPostService,
+		impl.NewVisibilityService,
+		impl.NewMarkdownService,
+		impl.NewOneTimeTokenService,
+		impl.NewPostCategoryService,
+		impl.NewSheetService,
+		impl.NewPermalinkService,
+		impl.NewImportService,
+
+		wire.Struct(new(ImportCommand), "*"),
+	)
+	return nil
+}
+
+// NewBackupCommand 初始化命令行备份和恢复需要的依赖
+func NewBackupCommand() *BackupCommand {
+	wire.Build(
+		config.NewConfig,
+		log.NewLogger,
+		log.NewGormLogger,
+
+		cache.NewRedisCache,
+		dal.NewGormDB,
+
+		impl.NewOptionService,
+		impl.NewRelatedPostService,
+		impl.NewBackupService,
+
+		wire.Struct(new(BackupCommand), "*"),
+	)
+	return nil
+}
@@ -14,6 +14,7 @@ import (
 	"dash/controller/middleware"
 	"dash/dal"
 	"dash/log"
+	"dash/scheduler"
 	"dash/service/assembler"
 	"dash/service/impl"
 )
@@ -33,17 +34,52 @@ func NewDashServer() *controller.Server {
 	oneTimeTokenService := impl.NewOneTimeTokenService()
 	userService := impl.NewUserService()
 	authMiddleware := middleware.NewAuthMiddleware(optionService, oneTimeTokenService, userService)
-	basePostService := impl.NewBasePostService(optionService)
-	postService := impl.NewPostService(basePostService, optionService)
-	tagService := impl.NewTagService(optionService, db)
-	postTagService := impl.NewPostTagService(tagService, db)
-	categoryService := impl.NewCategoryService(optionService)
+	searchService := impl.NewSearchService()
+	relatedPostService := impl.NewRelatedPostService(logger)
+	postRevisionService := impl.NewPostRevisionService(optionService, searchService, relatedPostService)
+	markdownService := impl.NewMarkdownService(optionService)
+	metaService := impl.NewMetaService()
+	slugHistoryService := impl.NewSlugHistoryService()
+	basePostService := impl.NewBasePostService(optionService, postRevisionService, markdownService, oneTimeTokenService, metaService, searchService, relatedPostService, slugHistoryService)
+	categoryService := impl.NewCategoryService(optionService, oneTimeTokenService, slugHistoryService)
 	postCategoryService := impl.NewPostCategoryService(categoryService, db)
-	basePostAssembler := assembler.NewBasePostAssembler(basePostService, optionService)
-	postAssembler := assembler.NewPostAssembler(postService, postTagService, tagService, postCategoryService, categoryService, basePostAssembler)
-	postHandler := handler.NewPostHandler(optionService, postService, postAssembler)
-	categoryHandler := handler.NewCategoryHandler(optionService, categoryService, postService, postCategoryService, postAssembler)
-	tagHandler := handler.NewTagHandler(optionService, tagService, postService, postTagService, postAssembler)
+	visibilityService := impl.NewVisibilityService(categoryService, postCategoryService)
+	postService := impl.NewPostService(basePostService, optionService, visibilityService, relatedPostService)
+	visitService := impl.NewVisitService(logger)
+	tagService := impl.NewTagService(optionService, slugHistoryService, db)
+	recycleService := impl.NewRecycleService(logger, optionService, basePostService, categoryService, tagService)
+	backupService := impl.NewBackupService(configConfig, db, optionService, relatedPostService)
+	schedulerScheduler := scheduler.NewScheduler(configConfig, logger, postService, visitService, recycleService, backupService)
+	reactionService := impl.NewReactionService(optionService)
+	postDraftService := impl.NewPostDraftService(optionService, markdownService, searchService, postRevisionService, relatedPostService)
+	postTagService := impl.NewPostTagService(tagService, db)
+	seriesService := impl.NewSeriesService()
+	sheetService := impl.NewSheetService(basePostService)
+	permalinkService := impl.NewPermalinkService(optionService, basePostService, sheetService)
+	basePostAssembler := assembler.NewBasePostAssembler(basePostService, optionService, permalinkService)
+	postAssembler := assembler.NewPostAssembler(postService, postTagService, tagService, postCategoryService, categoryService, metaService, reactionService, optionService, visibilityService, relatedPostService, seriesService, basePostAssembler)
+	postHandler := handler.NewPostHandler(optionService, postService, categoryService, postCategoryService, visibilityService, metaService, reactionService, visitService, searchService, relatedPostService, slugHistoryService, recycleService, postDraftService, postAssembler)
+	postRevisionHandler := handler.NewPostRevisionHandler(postRevisionService, postAssembler)
+	postDraftHandler := handler.NewPostDraftHandler(postDraftService, postAssembler)
+	sheetAssembler := assembler.NewSheetAssembler(basePostAssembler, sheetService, metaService, visibilityService)
+	sheetHandler := handler.NewSheetHandler(sheetService, basePostService, visibilityService, visitService, slugHistoryService, sheetAssembler)
+	permalinkHandler := handler.NewPermalinkHandler(permalinkService, visibilityService, basePostAssembler)
+	slugHistoryHandler := handler.NewSlugHistoryHandler(slugHistoryService)
+	recycleHandler := handler.NewRecycleHandler(recycleService)
+	importService := impl.NewImportService(postService, tagService, categoryService, permalinkService)
+	importHandler := handler.NewImportHandler(importService)
+	exportService := impl.NewExportService(configConfig)
+	exportHandler := handler.NewExportHandler(exportService, oneTimeTokenService)
+	backupHandler := handler.NewBackupHandler(backupService, oneTimeTokenService)
+	categoryHandler := handler.NewCategoryHandler(optionService, categoryService, postService, postCategoryService, visibilityService, slugHistoryService, recycleService, postAssembler)
+	tagHandler := handler.NewTagHandler(optionService, tagService, postService, postTagService, postCategoryService, visibilityService, slugHistoryService, recycleService, postAssembler)
+	seriesHandler := handler.NewSeriesHandler(seriesService, postCategoryService, visibilityService, postAssembler)
+	seoService := impl.NewSEOService(optionService, permalinkService, visibilityService, categoryService, tagService, postTagService, postCategoryService, userService)
+	seoHandler := handler.NewSEOHandler(seoService)
+	sitemapService := impl.NewSitemapService(logger, optionService, permalinkService, categoryService, tagService, postCategoryService)
+	sitemapHandler := handler.NewSitemapHandler(sitemapService)
+	feedService := impl.NewFeedService(optionService, permalinkService, categoryService, tagService, postCategoryService, postTagService, userService)
+	feedHandler := handler.NewFeedHandler(feedService)
 	statisticsHandler := handler.NewStatisticsHandler(postService, tagService, categoryService, optionService)
 	themeService := impl.NewThemeService()
 	themeHandler := handler.NewThemeHandler(optionService, userService, themeService)
@@ -54,6 +90,57 @@ func NewDashServer() *controller.Server {
 	adminHandler := handler.NewAdminHandler(adminService, jwtService)
 	installService := impl.NewInstallService(optionService, userService, categoryService, postService, menuService)
 	installHandler := handler.NewInstallHandler(installService, optionService)
-	server := controller.NewServer(configConfig, logger, db, redisCache, authMiddleware, postHandler, categoryHandler, tagHandler, statisticsHandler, themeHandler, menuHandler, adminHandler, installHandler)
+	server := controller.NewServer(configConfig, logger, db, redisCache, authMiddleware, schedulerScheduler, postHandler, postRevisionHandler, postDraftHandler, sheetHandler, permalinkHandler, slugHistoryHandler, recycleHandler, importHandler, exportHandler, backupHandler, categoryHandler, tagHandler, seriesHandler, seoHandler, sitemapHandler, feedHandler, statisticsHandler, themeHandler, menuHandler, adminHandler, installHandler)
 	return server
 }
+
+// NewImportCommand 初始化命令行导入需要的依赖
+func NewImportCommand() *ImportCommand {
+	configConfig := config.NewConfig()
+	logger := log.NewLogger(configConfig)
+	loggerInterface := log.NewGormLogger(configConfig, logger)
+	db := dal.NewGormDB(configConfig, loggerInterface)
+	redisCache := cache.NewRedisCache(configConfig, logger)
+	optionService := impl.NewOptionService(configConfig, logger)
+	searchService := impl.NewSearchService()
+	relatedPostService := impl.NewRelatedPostService(logger)
+	postRevisionService := impl.NewPostRevisionService(optionService, searchService, relatedPostService)
+	markdownService := impl.NewMarkdownService(optionService)
+	oneTimeTokenService := impl.NewOneTimeTokenService()
+	metaService := impl.NewMetaService()
+	slugHistoryService := impl.NewSlugHistoryService()
+	basePostService := impl.NewBasePostService(optionService, postRevisionService, markdownService, oneTimeTokenService, metaService, searchService, relatedPostService, slugHistoryService)
+	categoryService := impl.NewCategoryService(optionService, oneTimeTokenService, slugHistoryService)
+	postCategoryService := impl.NewPostCategoryService(categoryService, db)
+	visibilityService := impl.NewVisibilityService(categoryService, postCategoryService)
+	postService := impl.NewPostService(basePostService, optionService, visibilityService, relatedPostService)
+	tagService := impl.NewTagService(optionService, slugHistoryService, db)
+	sheetService := impl.NewSheetService(basePostService)
+	permalinkService := impl.NewPermalinkService(optionService, basePostService, sheetService)
+	importService := impl.NewImportService(postService, tagService, categoryService, permalinkService)
+	importCommand := &ImportCommand{
+		DB:            db,
+		Cache:         redisCache,
+		ImportService: importService,
+	}
+	return importCommand
+}
+
+// NewBackupCommand 初始化命令行备份和恢复需要的依赖
+func NewBackupCommand() *BackupCommand {
+	configConfig := config.NewConfig()
+	logger := log.NewLogger(configConfig)
+	loggerInterface := log.NewGormLogger(configConfig, logger)
+	db := dal.NewGormDB(configConfig, loggerInterface)
+	redisCache := cache.NewRedisCache(configConfig, logger)
+	optionService := impl.NewOptionService(configConfig, logger)
+	relatedPostService := impl.NewRelatedPostService(logger)
+	backupService := impl.NewBackupService(configConfig, db, optionService, relatedPostService)
+	backupCommand := &BackupCommand{
+		Config:        configConfig,
+		DB:            db,
+		Cache:         redisCache,
+		BackupService: backupService,
+	}
+	return backupCommand
+}
@@ -4,3 +4,9 @@ type AccessToken struct {
 	AccessToken string `json:"access_token"`
 	ExpiredIn   int    `json:"expired_in"`
 }
+
+// UnlockToken 输入密码解锁文章或分类后签发的访问令牌
+type UnlockToken struct {
+	Token      string `json:"token"`
+	ExpireTime int64  `json:"expire_time"`
+}
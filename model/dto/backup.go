@@ -0,0 +1,19 @@
+package dto
+
+// BackupManifest 备份文件中的 manifest.json，Version 用于恢复时校验备份格式
+type BackupManifest struct {
+	App        string           `json:"app"`
+	Version    int              `json:"version"`
+	CreateTime int64            `json:"create_time"`
+	DBType     string           `json:"db_type"`
+	Tables     map[string]int64 `json:"tables"` // 表名 -> 行数
+	Uploads    int64            `json:"uploads"`
+}
+
+// RestoreResult 恢复完成后各表写入的行数和恢复的上传文件数
+type RestoreResult struct {
+	Version    int              `json:"version"`
+	CreateTime int64            `json:"create_time"`
+	Tables     map[string]int64 `json:"tables"`
+	Uploads    int64            `json:"uploads"`
+}
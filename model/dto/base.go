@@ -5,6 +5,7 @@ import (
 	"dash/utils"
 	"math"
 	"reflect"
+	"time"
 )
 
 type BaseDTO struct {
@@ -12,6 +13,14 @@ type BaseDTO struct {
 	Message string      `json:"message"`
 	Data    interface{} `json:"data"`
 }
+
+// RawContent 按指定的 Content-Type 原样输出，用于 sitemap.xml、robots.txt、订阅等非 JSON 接口，支持 ETag 和 Last-Modified 条件请求
+type RawContent struct {
+	ContentType  string
+	Data         []byte
+	LastModified time.Time // 为零值时不输出 Last-Modified
+}
+
 type Page struct {
 	Contents    interface{} `json:"contents"`
 	Pages       int         `json:"pages"`
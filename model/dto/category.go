@@ -1,14 +1,18 @@
 package dto
 
+import "dash/consts"
+
 type Category struct {
-	ID          int32  `json:"id"`
-	Name        string `json:"name"`
-	Slug        string `json:"slug"`
-	Description string `json:"description"`
-	Thumbnail   string `json:"thumbnail"`
-	CreateTime  int64  `json:"create_time"`
-	FullPath    string `json:"full_path"`
-	Priority    int32  `json:"priority"`
+	ID          int32               `json:"id"`
+	Name        string              `json:"name"`
+	Slug        string              `json:"slug"`
+	Description string              `json:"description"`
+	Thumbnail   string              `json:"thumbnail"`
+	CreateTime  int64               `json:"create_time"`
+	FullPath    string              `json:"full_path"`
+	Priority    int32               `json:"priority"`
+	Type        consts.CategoryType `json:"type"`
+	HasPassword bool                `json:"has_password"`
 }
 
 type CategoryWithPostCount struct {
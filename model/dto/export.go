@@ -0,0 +1,15 @@
+package dto
+
+// ExportFile 导出生成的文件，通过 DownloadURL 下载，链接中的一次性 token 过期后需要重新导出
+type ExportFile struct {
+	Filename    string `json:"filename"`
+	Size        int64  `json:"size"`
+	CreateTime  int64  `json:"create_time"`
+	DownloadURL string `json:"download_url"`
+}
+
+// FileDownload 由 handler 返回时直接以附件形式输出文件
+type FileDownload struct {
+	Path     string `json:"-"`
+	Filename string `json:"filename"`
+}
@@ -0,0 +1,106 @@
+package dto
+
+import "encoding/xml"
+
+// RSS RSS 2.0 订阅，atom:link 指向订阅自身的地址
+type RSS struct {
+	XMLName xml.Name    `xml:"rss"`
+	Version string      `xml:"version,attr"`
+	AtomNS  string      `xml:"xmlns:atom,attr"`
+	Channel *RSSChannel `xml:"channel"`
+}
+
+type RSSChannel struct {
+	Title         string     `xml:"title"`
+	Link          string     `xml:"link"`
+	Description   string     `xml:"description"`
+	AtomLink      *AtomLink  `xml:"atom:link"`
+	Generator     string     `xml:"generator"`
+	LastBuildDate string     `xml:"lastBuildDate,omitempty"`
+	Items         []*RSSItem `xml:"item"`
+}
+
+type RSSItem struct {
+	Title       string   `xml:"title"`
+	Link        string   `xml:"link"`
+	GUID        *RSSGUID `xml:"guid"`
+	PubDate     string   `xml:"pubDate"`
+	Description string   `xml:"description"`
+	Categories  []string `xml:"category"`
+}
+
+type RSSGUID struct {
+	IsPermaLink string `xml:"isPermaLink,attr"`
+	Value       string `xml:",chardata"`
+}
+
+type AtomFeed struct {
+	XMLName   xml.Name     `xml:"feed"`
+	Xmlns     string       `xml:"xmlns,attr"`
+	Title     string       `xml:"title"`
+	Subtitle  string       `xml:"subtitle,omitempty"`
+	ID        string       `xml:"id"`
+	Updated   string       `xml:"updated"`
+	Links     []*AtomLink  `xml:"link"`
+	Author    *AtomAuthor  `xml:"author,omitempty"`
+	Generator string       `xml:"generator"`
+	Entries   []*AtomEntry `xml:"entry"`
+}
+
+type AtomLink struct {
+	Href string `xml:"href,attr"`
+	Rel  string `xml:"rel,attr,omitempty"`
+	Type string `xml:"type,attr,omitempty"`
+}
+
+type AtomAuthor struct {
+	Name string `xml:"name"`
+}
+
+type AtomEntry struct {
+	Title      string          `xml:"title"`
+	ID         string          `xml:"id"`
+	Links      []*AtomLink     `xml:"link"`
+	Published  string          `xml:"published"`
+	Updated    string          `xml:"updated"`
+	Summary    *AtomText       `xml:"summary,omitempty"`
+	Content    *AtomText       `xml:"content,omitempty"`
+	Categories []*AtomCategory `xml:"category"`
+}
+
+type AtomText struct {
+	Type string `xml:"type,attr"`
+	Body string `xml:",chardata"`
+}
+
+type AtomCategory struct {
+	Term string `xml:"term,attr"`
+}
+
+// JSONFeed JSON Feed 1.1，https://www.jsonfeed.org/version/1.1/
+type JSONFeed struct {
+	Version     string            `json:"version"`
+	Title       string            `json:"title"`
+	HomePageURL string            `json:"home_page_url"`
+	FeedURL     string            `json:"feed_url"`
+	Description string            `json:"description,omitempty"`
+	Authors     []*JSONFeedAuthor `json:"authors,omitempty"`
+	Items       []*JSONFeedItem   `json:"items"`
+}
+
+type JSONFeedAuthor struct {
+	Name string `json:"name"`
+}
+
+type JSONFeedItem struct {
+	ID            string   `json:"id"`
+	URL           string   `json:"url"`
+	Title         string   `json:"title"`
+	ContentHTML   string   `json:"content_html,omitempty"`
+	ContentText   string   `json:"content_text,omitempty"`
+	Summary       string   `json:"summary,omitempty"`
+	Image         string   `json:"image,omitempty"`
+	DatePublished string   `json:"date_published"`
+	DateModified  string   `json:"date_modified"`
+	Tags          []string `json:"tags,omitempty"`
+}
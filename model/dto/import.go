@@ -0,0 +1,31 @@
+package dto
+
+import "dash/consts"
+
+// ImportResult 导入结果，DryRun 时只解析和校验，不写入数据库
+type ImportResult struct {
+	DryRun            bool          `json:"dry_run"`
+	Total             int           `json:"total"`
+	Imported          int           `json:"imported"`
+	Failed            int           `json:"failed"`
+	Skipped           int           `json:"skipped"`            // 不支持导入的内容，如 WordPress 的附件、菜单项和修订版本
+	CreatedTags       []string      `json:"created_tags"`       // 新建的标签，DryRun 时为将要新建的标签
+	CreatedCategories []string      `json:"created_categories"` // 新建的分类，DryRun 时为将要新建的分类
+	Items             []*ImportItem `json:"items"`
+}
+
+// ImportItem 单个文件的导入结果，Error 不为空表示该文件导入失败
+type ImportItem struct {
+	File       string            `json:"file"`
+	Type       consts.PostType   `json:"type"`
+	Title      string            `json:"title"`
+	Slug       string            `json:"slug"`
+	Status     consts.PostStatus `json:"status"`
+	Tags       []string          `json:"tags"`
+	Categories []string          `json:"categories"`
+	CreateTime int64             `json:"create_time"`
+	PostID     int32             `json:"post_id,omitempty"`
+	OldURL     string            `json:"old_url,omitempty"` // 原博客的链接，和 NewURL 一起用于配置跳转
+	NewURL     string            `json:"new_url,omitempty"`
+	Error      string            `json:"error,omitempty"`
+}
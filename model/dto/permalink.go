@@ -0,0 +1,10 @@
+package dto
+
+import "dash/consts"
+
+// Permalink 链接解析结果，Canonical 为 false 时说明请求的不是当前设置下的链接，前端可跳转到 FullPath
+type Permalink struct {
+	Type      consts.PostType `json:"type"`
+	Post      *PostOutline    `json:"post"`
+	Canonical bool            `json:"canonical"`
+}
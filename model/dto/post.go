@@ -1,16 +1,21 @@
 package dto
 
-import "dash/consts"
+import (
+	"dash/consts"
+	"dash/utils"
+)
 
 type PostOutline struct {
-	ID         int32             `json:"id"`
-	Title      string            `json:"title"`
-	Status     consts.PostStatus `json:"status"`
-	Slug       string            `json:"slug"`
-	EditorType consts.EditorType `json:"editor_type"`
-	CreateTime int64             `json:"create_time"`
-	UpdateTime int64             `json:"update_time"`
-	FullPath   string            `json:"full_path"`
+	ID          int32             `json:"id"`
+	Title       string            `json:"title"`
+	Status      consts.PostStatus `json:"status"`
+	Slug        string            `json:"slug"`
+	EditorType  consts.EditorType `json:"editor_type"`
+	CreateTime  int64             `json:"create_time"`
+	UpdateTime  int64             `json:"update_time"`
+	FullPath    string            `json:"full_path"`
+	PublishTime int64             `json:"publish_time"`
+	ExpireTime  int64             `json:"expire_time"`
 }
 type Post struct {
 	PostOutline
@@ -21,10 +26,14 @@ type Post struct {
 	Likes       int64  `json:"likes"`
 	WordCount   int64  `json:"word_count"`
 	Topped      bool   `json:"topped"`
+	HasPassword bool   `json:"has_password"`
 }
 
 type PostDetail struct {
 	Post
-	OriginalContent string `json:"original_content"`
-	Content         string `json:"content"`
+	OriginalContent string           `json:"original_content"`
+	Content         string           `json:"content"`
+	Toc             []*utils.TocItem `json:"toc"`
+	MetaDescription string           `json:"meta_description"`
+	MetaKeywords    string           `json:"meta_keywords"`
 }
@@ -0,0 +1,15 @@
+package dto
+
+import "dash/consts"
+
+type PostDraft struct {
+	PostID          int32             `json:"post_id"`
+	Title           string            `json:"title"`
+	Summary         string            `json:"summary"`
+	EditorType      consts.EditorType `json:"editor_type"`
+	OriginalContent string            `json:"original_content"`
+	Content         string            `json:"content"`
+	TagIDs          []int32           `json:"tag_ids"`
+	CreateTime      int64             `json:"create_time"`
+	UpdateTime      int64             `json:"update_time"`
+}
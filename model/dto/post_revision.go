@@ -0,0 +1,32 @@
+package dto
+
+import (
+	"dash/consts"
+	"dash/utils"
+)
+
+type PostRevision struct {
+	ID         int32             `json:"id"`
+	PostID     int32             `json:"post_id"`
+	Version    int32             `json:"version"`
+	Title      string            `json:"title"`
+	Slug       string            `json:"slug"`
+	Summary    string            `json:"summary"`
+	EditorType consts.EditorType `json:"editor_type"`
+	Remark     string            `json:"remark"`
+	CreateTime int64             `json:"create_time"`
+}
+
+type PostRevisionDetail struct {
+	PostRevision
+	OriginalContent string `json:"original_content"`
+	Content         string `json:"content"`
+}
+
+type PostRevisionDiff struct {
+	From    *PostRevision     `json:"from"`
+	To      *PostRevision     `json:"to"`
+	Added   int               `json:"added"`
+	Deleted int               `json:"deleted"`
+	Lines   []*utils.DiffLine `json:"lines"`
+}
@@ -0,0 +1,11 @@
+package dto
+
+type Reaction struct {
+	Reaction string `json:"reaction"`
+	Count    int64  `json:"count"`
+}
+
+type PostLikes struct {
+	PostID int32 `json:"post_id"`
+	Likes  int64 `json:"likes"`
+}
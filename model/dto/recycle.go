@@ -0,0 +1,12 @@
+package dto
+
+import "dash/consts"
+
+type RecycleItem struct {
+	ID         int32              `json:"id"`
+	Type       consts.RecycleType `json:"type"`
+	TargetID   int32              `json:"target_id"`
+	Title      string             `json:"title"`
+	DeletedBy  string             `json:"deleted_by"`
+	DeleteTime int64              `json:"delete_time"`
+}
@@ -0,0 +1,13 @@
+package dto
+
+type SearchHit struct {
+	PostID         int32   `json:"post_id"`
+	Score          float64 `json:"score"`
+	TitleHighlight string  `json:"title_highlight"` // 转义后的标题，命中的词用 <mark> 标出
+	Snippet        string  `json:"snippet"`         // 正文中命中位置附近的片段
+}
+
+type SearchSuggestion struct {
+	Term      string `json:"term"`
+	PostCount int64  `json:"post_count"`
+}
@@ -0,0 +1,19 @@
+package dto
+
+// PageMeta 服务端注入 index.html 的页面信息，供搜索引擎和聊天软件的链接预览使用
+type PageMeta struct {
+	Title        string `json:"title"`
+	Description  string `json:"description"`
+	Keywords     string `json:"keywords"`
+	CanonicalURL string `json:"canonical_url"`
+	Image        string `json:"image"`
+	SiteName     string `json:"site_name"`
+	// Type Open Graph 类型，文章为 article，其他页面为 website
+	Type          string   `json:"type"`
+	Author        string   `json:"author"`
+	PublishedTime string   `json:"published_time"`
+	ModifiedTime  string   `json:"modified_time"`
+	Tags          []string `json:"tags"`
+	// JSONLD schema.org BlogPosting 数据，只有文章页面有
+	JSONLD string `json:"json_ld"`
+}
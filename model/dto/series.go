@@ -0,0 +1,20 @@
+package dto
+
+type Series struct {
+	ID          int32  `json:"id"`
+	Name        string `json:"name"`
+	Slug        string `json:"slug"`
+	Description string `json:"description"`
+	Thumbnail   string `json:"thumbnail"`
+	CreateTime  int64  `json:"create_time"`
+	PostCount   int64  `json:"post_count"`
+}
+
+// PostSeries 文章所在的系列，Index 从 1 开始，PrePost 和 NextPost 为系列中的上一篇和下一篇
+type PostSeries struct {
+	*Series
+	Index    int          `json:"index"`
+	Total    int          `json:"total"`
+	PrePost  *PostOutline `json:"pre_post"`
+	NextPost *PostOutline `json:"next_post"`
+}
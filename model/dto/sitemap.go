@@ -0,0 +1,24 @@
+package dto
+
+import "encoding/xml"
+
+const SitemapXmlns = "http://www.sitemaps.org/schemas/sitemap/0.9"
+
+// SitemapURLSet 单个站点地图文件，最多 50000 条链接
+type SitemapURLSet struct {
+	XMLName xml.Name      `xml:"urlset"`
+	Xmlns   string        `xml:"xmlns,attr"`
+	URLs    []*SitemapURL `xml:"url"`
+}
+
+// SitemapIndex 链接超过 50000 条时分页，/sitemap.xml 返回各页的索引
+type SitemapIndex struct {
+	XMLName  xml.Name      `xml:"sitemapindex"`
+	Xmlns    string        `xml:"xmlns,attr"`
+	Sitemaps []*SitemapURL `xml:"sitemap"`
+}
+
+type SitemapURL struct {
+	Loc     string `xml:"loc"`
+	LastMod string `xml:"lastmod,omitempty"`
+}
@@ -0,0 +1,17 @@
+package dto
+
+import "dash/consts"
+
+type SlugHistory struct {
+	ID         int32           `json:"id"`
+	Type       consts.SlugType `json:"type"`
+	Slug       string          `json:"slug"`
+	TargetID   int32           `json:"target_id"`
+	CreateTime int64           `json:"create_time"`
+}
+
+// Redirect 通过旧 slug 访问时返回，响应的 status 为 301，前端和爬虫应跳转到 Location
+type Redirect struct {
+	Location string `json:"location"`
+	Slug     string `json:"slug"`
+}
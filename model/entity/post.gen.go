@@ -35,6 +35,10 @@ type Post struct {
 	MetaKeywords    string            `gorm:"column:meta_keywords;type:varchar(511);not null" json:"meta_keywords"`
 	Password        string            `gorm:"column:password;type:varchar(255);not null" json:"password"`
 	Template        string            `gorm:"column:template;type:varchar(255);not null" json:"template"`
+	PublishTime     *time.Time        `gorm:"column:publish_time;type:datetime;index:post_publish_time,priority:1" json:"publish_time"`
+	ExpireTime      *time.Time        `gorm:"column:expire_time;type:datetime;index:post_expire_time,priority:1" json:"expire_time"`
+	Toc             string            `gorm:"column:toc;type:longtext" json:"toc"`
+	ParentID        int32             `gorm:"column:parent_id;type:int;not null;index:post_parent_id,priority:1;default:0" json:"parent_id"`
 }
 
 // TableName Post's table name
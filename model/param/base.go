@@ -8,3 +8,8 @@ type Page struct {
 type Sort struct {
 	Fields []string `json:"sort" form:"sort"`
 }
+
+// Unlock 解锁加密文章或分类的参数
+type Unlock struct {
+	Password string `json:"password" form:"password" binding:"required"`
+}
@@ -1,9 +1,13 @@
 package param
 
+import "dash/consts"
+
 type Category struct {
-	Name        string `json:"name" binding:"gte=1,lte=255"`
-	Slug        string `json:"slug" binding:"gte=0,lte=255"`
-	Description string `json:"description" binding:"gte=0,lte=100"`
-	Thumbnail   string `json:"thumbnail" binding:"gte=0,lte=1023"`
-	Priority    int32  `json:"priority" binding:"gte=0"`
+	Name        string               `json:"name" binding:"gte=1,lte=255"`
+	Slug        string               `json:"slug" binding:"gte=0,lte=255"`
+	Description string               `json:"description" binding:"gte=0,lte=100"`
+	Thumbnail   string               `json:"thumbnail" binding:"gte=0,lte=1023"`
+	Priority    int32                `json:"priority" binding:"gte=0"`
+	Type        *consts.CategoryType `json:"type"`                                // INTIMATE 为私密分类，游客不可见，为 nil 时不修改
+	Password    *string              `json:"password" binding:"omitempty,lte=72"` // 访问密码，为 nil 时不修改，为空字符串时清除
 }
@@ -1,6 +1,6 @@
 package param
 
 type Meta struct {
-	Key   string `json:"key" form:"key" binding:"required"`
-	Value string `json:"value" form:"value" binding:"required"`
+	Key   string `json:"key" form:"key" binding:"required,lte=255"`
+	Value string `json:"value" form:"value" binding:"required,lte=1023"`
 }
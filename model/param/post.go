@@ -16,6 +16,13 @@ type Post struct {
 	TopPriority     int32              `json:"top_priority" form:"top_priority" binding:"gte=0"`
 	TagIDs          []int32            `json:"tag_ids" form:"tag_ids"`
 	CategoryIDs     []int32            `json:"category_ids" form:"category_ids"`
+	PublishTime     *int64             `json:"publish_time" form:"publish_time"`                                      // 定时发布时间（毫秒时间戳），必须晚于当前时间，为空或 0 表示不定时
+	ExpireTime      *int64             `json:"expire_time" form:"expire_time"`                                        // 自动下线时间（毫秒时间戳），为空或 0 表示不自动下线
+	Password        *string            `json:"password" form:"password" binding:"omitempty,lte=72"`                   // 访问密码，为 nil 时不修改，为空字符串时清除
+	ParentID        *int32             `json:"parent_id" form:"parent_id" binding:"omitempty,gte=0"`                  // 父页面 ID，只对页面生效，0 表示顶级页面，更新时为 nil 表示不修改
+	Metas           []*Meta            `json:"metas" form:"metas" binding:"omitempty,dive"`                           // 自定义元数据，整体替换；更新时为 nil 表示不修改，空数组表示清空
+	MetaDescription *string            `json:"meta_description" form:"meta_description" binding:"omitempty,lte=1023"` // SEO 描述，为空时使用摘要，更新时为 nil 表示不修改
+	MetaKeywords    *string            `json:"meta_keywords" form:"meta_keywords" binding:"omitempty,lte=511"`        // SEO 关键词，多个以英文逗号分隔，更新时为 nil 表示不修改
 }
 
 type PostContent struct {
@@ -31,5 +38,35 @@ type PostQuery struct {
 	CategoryID *int32               `json:"category_id" form:"category_id"`
 	Detail     *bool                `json:"detail" form:"detail"`
 	TagID      *int32               `json:"tag_id" form:"tag_id"`
+	MetaKey    *string              `json:"meta_key" form:"meta_key"`
+	MetaValue  *string              `json:"meta_value" form:"meta_value"` // 需要和 meta_key 一起使用
+	// ExcludeCategoryIDs 排除属于这些分类的文章，用于对游客隐藏私密和加密分类
+	ExcludeCategoryIDs []int32 `json:"-" form:"-"`
 	// WithPassword *bool                `json:"-" form:"-"`
 }
+
+type SheetQuery struct {
+	Page
+	*Sort
+	Keyword  *string              `json:"keyword" form:"keyword"`
+	Statuses []*consts.PostStatus `json:"statuses" form:"statuses"`
+	ParentID *int32               `json:"parent_id" form:"parent_id"` // 只查询该页面的子页面，0 表示顶级页面
+}
+
+type Reaction struct {
+	Reaction string `json:"reaction" form:"reaction" binding:"required,lte=32"`
+}
+
+type RelatedPostQuery struct {
+	Size int `json:"size" form:"size" binding:"gte=0,lte=20"`
+}
+
+// PostDraft 文章的工作草稿，自动保存时整体覆盖，发布前不影响读者看到的内容
+type PostDraft struct {
+	Title           string             `json:"title" form:"title" binding:"lte=100"`
+	EditorType      *consts.EditorType `json:"editor_type" form:"editor_type"`
+	Content         string             `json:"content" form:"content"`
+	OriginalContent string             `json:"original_content" form:"original_content"`
+	Summary         string             `json:"summary" form:"summary"`
+	TagIDs          []int32            `json:"tag_ids" form:"tag_ids"`
+}
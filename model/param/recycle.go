@@ -0,0 +1,9 @@
+package param
+
+import "dash/consts"
+
+type RecycleQuery struct {
+	Page
+	Type    *consts.RecycleType `json:"type" form:"type"`
+	Keyword *string             `json:"keyword" form:"keyword"`
+}
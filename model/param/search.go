@@ -0,0 +1,21 @@
+package param
+
+import "dash/consts"
+
+type SearchQuery struct {
+	Page
+	Keyword string `json:"keyword" form:"keyword" binding:"required"`
+	// Statuses、ExcludeCategoryIDs 和 ExcludeProtected 由访问者身份决定，不从请求中读取
+	Statuses           []consts.PostStatus `json:"-" form:"-"`
+	ExcludeCategoryIDs []int32             `json:"-" form:"-"`
+	// ExcludeProtected 排除设置了访问密码的文章，避免通过摘要和命中结果泄露正文
+	ExcludeProtected bool `json:"-" form:"-"`
+}
+
+type SuggestQuery struct {
+	Prefix             string              `json:"prefix" form:"prefix" binding:"required"`
+	Size               int                 `json:"size" form:"size"`
+	Statuses           []consts.PostStatus `json:"-" form:"-"`
+	ExcludeCategoryIDs []int32             `json:"-" form:"-"`
+	ExcludeProtected   bool                `json:"-" form:"-"`
+}
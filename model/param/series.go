@@ -0,0 +1,13 @@
+package param
+
+type Series struct {
+	Name        string `json:"name" form:"name" binding:"gte=1,lte=255"`
+	Slug        string `json:"slug" form:"slug" binding:"lte=255"`
+	Description string `json:"description" form:"description" binding:"lte=1023"`
+	Thumbnail   string `json:"thumbnail" form:"thumbnail" binding:"lte=1023"`
+}
+
+// SeriesPosts 系列中的文章，按数组顺序排列，整体替换
+type SeriesPosts struct {
+	PostIDs []int32 `json:"post_ids" form:"post_ids"`
+}
@@ -0,0 +1,16 @@
+package param
+
+import "dash/consts"
+
+type SlugHistoryQuery struct {
+	Page
+	Type     *consts.SlugType `json:"type" form:"type"`
+	TargetID *int32           `json:"target_id" form:"target_id"`
+	Keyword  *string          `json:"keyword" form:"keyword"`
+}
+
+// SlugHistoryPrune 删除 Before（毫秒时间戳）之前记录的旧 slug，Type 为空时不限类型
+type SlugHistoryPrune struct {
+	Before int64            `json:"before" form:"before" binding:"required,gt=0"`
+	Type   *consts.SlugType `json:"type" form:"type"`
+}
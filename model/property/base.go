@@ -37,6 +37,11 @@ func (p *Property) ConvertToOption() *entity.Option {
 var AllProperty = []Property{
 	BlogTitle,
 	BlogURL,
+	SeoKeywords,
+	SeoDescription,
+	RobotsTxt,
+	FeedFullContent,
+	FeedSize,
 	PostPermalinkType,
 	SheetPermalinkType,
 	CategoriesPrefix,
@@ -50,6 +55,17 @@ var AllProperty = []Property{
 	IndexPageSize,
 	ArchivePageSize,
 	IndexSort,
+	RevisionRetention,
+	ExpireStatus,
+	PostReactions,
+	ReactionRateLimit,
+	RelatedPostSize,
+	RecycleRetentionDays,
+	RecycleCategoriesAndTags,
+	MarkdownHeadingAnchor,
+	MarkdownCodeHighlight,
+	MarkdownHighlightStyle,
+	MarkdownFootnote,
 	JWTAccessSecret,
 	JWTRefreshSecret,
 }
@@ -0,0 +1,18 @@
+package property
+
+import "reflect"
+
+var (
+	// FeedFullContent 订阅中输出全文，关闭时只输出摘要
+	FeedFullContent = Property{
+		DefaultValue: false,
+		KeyValue:     "feed_full_content",
+		Kind:         reflect.Bool,
+	}
+	// FeedSize 订阅中的文章数量
+	FeedSize = Property{
+		DefaultValue: 20,
+		KeyValue:     "feed_size",
+		Kind:         reflect.Int,
+	}
+)
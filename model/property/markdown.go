@@ -0,0 +1,28 @@
+package property
+
+import "reflect"
+
+var (
+	// MarkdownHeadingAnchor 是否在标题前输出锚点链接
+	MarkdownHeadingAnchor = Property{
+		KeyValue:     "markdown_heading_anchor",
+		DefaultValue: true,
+		Kind:         reflect.Bool,
+	}
+	MarkdownCodeHighlight = Property{
+		KeyValue:     "markdown_code_highlight",
+		DefaultValue: true,
+		Kind:         reflect.Bool,
+	}
+	// MarkdownHighlightStyle 代码高亮主题，可选值见 chroma styles
+	MarkdownHighlightStyle = Property{
+		KeyValue:     "markdown_highlight_style",
+		DefaultValue: "github",
+		Kind:         reflect.String,
+	}
+	MarkdownFootnote = Property{
+		KeyValue:     "markdown_footnote",
+		DefaultValue: true,
+		Kind:         reflect.Bool,
+	}
+)
@@ -23,4 +23,34 @@ var (
 		DefaultValue: 10,
 		Kind:         reflect.Int,
 	}
+	// ExpireStatus 文章到达下线时间后切换到的状态，可选 DRAFT 或 RECYCLE
+	ExpireStatus = Property{
+		KeyValue:     "post_expire_status",
+		DefaultValue: "DRAFT",
+		Kind:         reflect.String,
+	}
+	// PostReactions 访客可以使用的表情回应，多个用英文逗号分隔
+	PostReactions = Property{
+		KeyValue:     "post_reactions",
+		DefaultValue: "👍,❤️,😄,🎉,🤔",
+		Kind:         reflect.String,
+	}
+	// ReactionRateLimit 每个访客每分钟最多点赞和回应的次数
+	ReactionRateLimit = Property{
+		KeyValue:     "post_reaction_rate_limit",
+		DefaultValue: 30,
+		Kind:         reflect.Int,
+	}
+	// RelatedPostSize 文章详情中展示的相关文章数量
+	RelatedPostSize = Property{
+		KeyValue:     "post_related_size",
+		DefaultValue: 5,
+		Kind:         reflect.Int,
+	}
+	// RevisionRetention 每篇文章保留的历史版本数量，0 表示不限制
+	RevisionRetention = Property{
+		KeyValue:     "post_revision_retention",
+		DefaultValue: 50,
+		Kind:         reflect.Int,
+	}
 )
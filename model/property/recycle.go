@@ -0,0 +1,18 @@
+package property
+
+import "reflect"
+
+var (
+	// RecycleRetentionDays 回收站中的内容保留的天数，超过后自动彻底删除，0 表示不自动删除
+	RecycleRetentionDays = Property{
+		KeyValue:     "recycle_retention_days",
+		DefaultValue: 30,
+		Kind:         reflect.Int,
+	}
+	// RecycleCategoriesAndTags 删除分类和标签时是否先移入回收站，关闭后直接删除
+	RecycleCategoriesAndTags = Property{
+		KeyValue:     "recycle_categories_and_tags",
+		DefaultValue: true,
+		Kind:         reflect.Bool,
+	}
+)
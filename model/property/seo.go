@@ -0,0 +1,22 @@
+package property
+
+import "reflect"
+
+var (
+	SeoKeywords = Property{
+		DefaultValue: "",
+		KeyValue:     "seo_keywords",
+		Kind:         reflect.String,
+	}
+	SeoDescription = Property{
+		DefaultValue: "",
+		KeyValue:     "seo_description",
+		Kind:         reflect.String,
+	}
+	// RobotsTxt 自定义 robots.txt 内容，为空时使用默认规则，没有 Sitemap 行时自动加上站点地图地址
+	RobotsTxt = Property{
+		DefaultValue: "",
+		KeyValue:     "robots_txt",
+		Kind:         reflect.String,
+	}
+)
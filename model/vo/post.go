@@ -10,10 +10,53 @@ type Post struct {
 	Categories []*dto.Category `json:"categories"`
 }
 
+// ProtectedPost 设置了访问密码且未解锁的文章，只返回标题和摘要
+type ProtectedPost struct {
+	dto.PostOutline
+	Summary          string `json:"summary"`
+	Thumbnail        string `json:"thumbnail"`
+	PasswordRequired bool   `json:"password_required"`
+	// LockedCategories 文章所属的未解锁加密分类，需全部解锁（以及文章本身的密码）后才能阅读
+	LockedCategories []*dto.Category `json:"locked_categories"`
+}
+
 type PostDetail struct {
 	dto.PostDetail
 	Tags       []*dto.Tag       `json:"tags"`
 	Categories []*dto.Category  `json:"categories"`
+	Metas      []*dto.Meta      `json:"metas"`
+	Reactions  []*dto.Reaction  `json:"reactions"`
 	PrePost    *dto.PostOutline `json:"pre_post"`
 	NextPost   *dto.PostOutline `json:"next_post"`
+	// Related 相关文章，数量由 post_related_size 设置
+	Related []*dto.PostOutline `json:"related"`
+	// Series 文章所在的系列，不属于任何系列时为 null；属于系列时 PrePost、NextPost 与系列中的上一篇、下一篇相同
+	Series *dto.PostSeries `json:"series"`
+}
+
+// AdminPostDetail 后台编辑器使用的文章详情，同时返回已发布的内容和未发布的工作草稿
+type AdminPostDetail struct {
+	dto.PostDetail
+	// Draft 尚未发布的修改，没有草稿时为 null
+	Draft *dto.PostDraft `json:"draft"`
+}
+
+type Sheet struct {
+	dto.Post
+	ParentID int32 `json:"parent_id"`
+}
+
+type SheetDetail struct {
+	dto.PostDetail
+	ParentID int32              `json:"parent_id"`
+	Metas    []*dto.Meta        `json:"metas"`
+	Parent   *dto.PostOutline   `json:"parent"`
+	Children []*dto.PostOutline `json:"children"`
+}
+
+type SearchPost struct {
+	Post
+	Score          float64 `json:"score"`
+	TitleHighlight string  `json:"title_highlight"`
+	Snippet        string  `json:"snippet"`
 }
@@ -0,0 +1,8 @@
+package vo
+
+import "dash/model/dto"
+
+type SeriesDetail struct {
+	*dto.Series
+	Posts []*Post `json:"posts"`
+}
@@ -0,0 +1,149 @@
+package scheduler
+
+import (
+	"context"
+	"dash/cache"
+	"dash/config"
+	"dash/service"
+	"dash/utils"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// 定时任务的默认执行间隔
+const defaultInterval = 30 * time.Second
+
+type job struct {
+	name     string
+	interval time.Duration
+	fn       func(ctx context.Context) error
+}
+
+// Scheduler 后台定时任务调度器，多实例部署时通过 Redis 锁保证同一任务同一时刻只在一个实例上执行
+type Scheduler struct {
+	Logger *zap.Logger
+
+	instanceID string
+	jobs       []*job
+	cancel     context.CancelFunc
+	wg         sync.WaitGroup
+}
+
+func NewScheduler(conf *config.Config, logger *zap.Logger, postService service.PostService, visitService service.VisitService, recycleService service.RecycleService, backupService service.BackupService) *Scheduler {
+	s := &Scheduler{
+		Logger:     logger,
+		instanceID: utils.GenUUIDWithOutDash(),
+	}
+	s.Register("publish_scheduled_posts", defaultInterval, func(ctx context.Context) error {
+		count, err := postService.PublishScheduledPosts(ctx)
+		if count > 0 {
+			logger.Info("publish scheduled posts", zap.Int64("count", count))
+		}
+		return err
+	})
+	s.Register("expire_posts", defaultInterval, func(ctx context.Context) error {
+		count, err := postService.ExpirePosts(ctx)
+		if count > 0 {
+			logger.Info("expire posts", zap.Int64("count", count))
+		}
+		return err
+	})
+	visitFlushInterval := conf.Dash.VisitFlushInterval
+	if visitFlushInterval < 2*time.Second {
+		visitFlushInterval = defaultInterval
+	}
+	s.Register("flush_post_visits", visitFlushInterval, func(ctx context.Context) error {
+		_, err := visitService.FlushVisits(ctx)
+		return err
+	})
+	s.Register("purge_recycle_bin", time.Hour, func(ctx context.Context) error {
+		count, err := recycleService.PurgeExpired(ctx)
+		if count > 0 {
+			logger.Info("purge recycle bin", zap.Int64("count", count))
+		}
+		return err
+	})
+	if backup := conf.Dash.Backup; backup.Enable && backup.Interval > 0 {
+		// 每小时检查一次，距离上次备份超过 Interval 时才备份，避免每次重启都备份
+		s.Register("backup", time.Hour, func(ctx context.Context) error {
+			backups, err := backupService.ListBackups(ctx)
+			if err != nil {
+				return err
+			}
+			if len(backups) > 0 && time.Since(time.UnixMilli(backups[0].CreateTime)) < backup.Interval {
+				return nil
+			}
+			file, err := backupService.Backup(ctx)
+			if err != nil {
+				return err
+			}
+			logger.Info("backup", zap.String("file", file.Filename), zap.Int64("size", file.Size))
+			count, err := backupService.CleanBackups(ctx, backup.Keep)
+			if count > 0 {
+				logger.Info("clean backups", zap.Int("count", count))
+			}
+			return err
+		})
+	}
+	return s
+}
+
+// Register 注册定时任务，需在 Start 之前调用
+func (s *Scheduler) Register(name string, interval time.Duration, fn func(ctx context.Context) error) {
+	s.jobs = append(s.jobs, &job{name: name, interval: interval, fn: fn})
+}
+
+func (s *Scheduler) Start() {
+	ctx, cancel := context.WithCancel(context.Background())
+	s.cancel = cancel
+	for _, j := range s.jobs {
+		s.wg.Add(1)
+		go func(j *job) {
+			defer s.wg.Done()
+			s.run(ctx, j)
+			ticker := time.NewTicker(j.interval)
+			defer ticker.Stop()
+			for {
+				select {
+				case <-ctx.Done():
+					return
+				case <-ticker.C:
+					s.run(ctx, j)
+				}
+			}
+		}(j)
+	}
+}
+
+// Stop 停止调度并等待正在执行的任务结束
+func (s *Scheduler) Stop() {
+	if s.cancel == nil {
+		return
+	}
+	s.cancel()
+	s.wg.Wait()
+}
+
+func (s *Scheduler) run(ctx context.Context, j *job) {
+	defer func() {
+		if r := recover(); r != nil {
+			s.Logger.Error("job panic", zap.String("job", j.name), zap.Any("recover", r))
+		}
+	}()
+
+	// 锁的过期时间略短于执行间隔，避免实例宕机后锁一直不释放
+	lockKey := cache.BuildJobLockKey(j.name)
+	ok, err := cache.Redis.SetNX(ctx, lockKey, s.instanceID, j.interval-time.Second).Result()
+	if err != nil {
+		s.Logger.Error("acquire job lock failed", zap.String("job", j.name), zap.Error(err))
+		return
+	}
+	if !ok {
+		return
+	}
+	if err := j.fn(ctx); err != nil {
+		s.Logger.Error("job failed", zap.String("job", j.name), zap.Error(err))
+	}
+}
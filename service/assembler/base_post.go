@@ -2,12 +2,12 @@ package assembler
 
 import (
 	"context"
-	"dash/consts"
 	"dash/model/dto"
 	"dash/model/entity"
-	"dash/model/property"
 	"dash/service"
-	"strings"
+	"dash/utils"
+	"dash/utils/xerr"
+	"encoding/json"
 )
 
 type BasePostAssembler interface {
@@ -17,14 +17,16 @@ type BasePostAssembler interface {
 }
 
 type basePostAssemblerImpl struct {
-	BasePostService service.BasePostService
-	OptionService   service.OptionService
+	BasePostService  service.BasePostService
+	OptionService    service.OptionService
+	PermalinkService service.PermalinkService
 }
 
-func NewBasePostAssembler(basePostService service.BasePostService, optionService service.OptionService) BasePostAssembler {
+func NewBasePostAssembler(basePostService service.BasePostService, optionService service.OptionService, permalinkService service.PermalinkService) BasePostAssembler {
 	return &basePostAssemblerImpl{
-		BasePostService: basePostService,
-		OptionService:   optionService,
+		BasePostService:  basePostService,
+		OptionService:    optionService,
+		PermalinkService: permalinkService,
 	}
 }
 
@@ -40,27 +42,18 @@ func (b *basePostAssemblerImpl) ConvertToPostOutlineDTO(ctx context.Context, pos
 	if post.UpdateTime != nil {
 		postOutlineDTO.UpdateTime = post.UpdateTime.UnixMilli()
 	}
+	if post.PublishTime != nil {
+		postOutlineDTO.PublishTime = post.PublishTime.UnixMilli()
+	}
+	if post.ExpireTime != nil {
+		postOutlineDTO.ExpireTime = post.ExpireTime.UnixMilli()
+	}
 
-	fullPath := strings.Builder{}
-	fullPath.WriteString("/")
-
-	var prefix interface{}
-	var err error
-	if post.Type == consts.PostTypePost {
-		prefix, err = b.OptionService.GetOrByDefaultWithErr(ctx, property.ArchivesPrefix, property.ArchivesPrefix.DefaultValue)
-		if err != nil {
-			return nil, err
-		}
-	} else {
-		prefix, err = b.OptionService.GetOrByDefaultWithErr(ctx, property.SheetPrefix, property.SheetPrefix.DefaultValue)
-		if err != nil {
-			return nil, err
-		}
+	fullPath, err := b.PermalinkService.BuildFullPath(ctx, post)
+	if err != nil {
+		return nil, err
 	}
-	fullPath.WriteString(prefix.(string))
-	fullPath.WriteString("/")
-	fullPath.WriteString(post.Slug)
-	postOutlineDTO.FullPath = fullPath.String()
+	postOutlineDTO.FullPath = fullPath
 
 	return postOutlineDTO, nil
 }
@@ -82,6 +75,7 @@ func (b *basePostAssemblerImpl) ConvertToPostDTO(ctx context.Context, post *enti
 		Likes:       post.Likes,
 		WordCount:   post.WordCount,
 		Topped:      post.TopPriority > 0,
+		HasPassword: post.Password != "",
 	}
 	return postDTO, nil
 }
@@ -95,6 +89,14 @@ func (b *basePostAssemblerImpl) ConvertToDetailDTO(ctx context.Context, post *en
 		Post:            *postDTO,
 		OriginalContent: post.OriginalContent,
 		Content:         post.FormatContent,
+		MetaDescription: post.MetaDescription,
+		MetaKeywords:    post.MetaKeywords,
+	}
+	// posts saved before the toc was persisted get it built on the fly
+	if post.Toc == "" {
+		postDetailDTO.Content, postDetailDTO.Toc = utils.BuildToc(post.FormatContent)
+	} else if err := json.Unmarshal([]byte(post.Toc), &postDetailDTO.Toc); err != nil {
+		return nil, xerr.NoType.Wrap(err).WithMsg("parse post toc failed")
 	}
 	return postDetailDTO, nil
 }
@@ -4,7 +4,9 @@ import (
 	"context"
 	"dash/model/dto"
 	"dash/model/entity"
+	"dash/model/property"
 	"dash/service"
+	"dash/utils/xerr"
 	"sort"
 	"time"
 
@@ -14,7 +16,9 @@ import (
 type PostAssembler interface {
 	BasePostAssembler
 	ConvertToPostVOs(ctx context.Context, posts []*entity.Post) ([]*vo.Post, error)
-	ConvertToDetailVO(ctx context.Context, post *entity.Post) (*vo.PostDetail, error)
+	// ConvertToDetailVO hiddenCategoryIDs 为访问者不可见的分类，由调用方按解锁凭证计算，系列和相关文章中排除这些分类下的文章
+	ConvertToDetailVO(ctx context.Context, post *entity.Post, hiddenCategoryIDs []int32) (*vo.PostDetail, error)
+	ConvertToPostOutlineDTOs(ctx context.Context, posts []*entity.Post) ([]*dto.PostOutline, error)
 	ConvertToArchivesVOs(ctx context.Context, posts []*entity.Post) ([]*vo.Archive, error)
 	ConvertToCategoryVOs(ctx context.Context, posts []*entity.Post) ([]*vo.Category, error)
 	ConvertToTagVOs(ctx context.Context, posts []*entity.Post) ([]*vo.Tag, error)
@@ -29,10 +33,15 @@ type postAssemblerImpl struct {
 	// PostCommentService  service.PostCommentService
 	PostCategoryService service.PostCategoryService
 	CategoryService     service.CategoryService
-	// MetaService         service.MetaService
+	MetaService         service.MetaService
+	ReactionService     service.ReactionService
+	OptionService       service.OptionService
+	VisibilityService   service.VisibilityService
+	RelatedPostService  service.RelatedPostService
+	SeriesService       service.SeriesService
 }
 
-func NewPostAssembler(postService service.PostService, postTagService service.PostTagService, tagService service.TagService, postCategoryService service.PostCategoryService, categoryService service.CategoryService, basePostAssembler BasePostAssembler) PostAssembler {
+func NewPostAssembler(postService service.PostService, postTagService service.PostTagService, tagService service.TagService, postCategoryService service.PostCategoryService, categoryService service.CategoryService, metaService service.MetaService, reactionService service.ReactionService, optionService service.OptionService, visibilityService service.VisibilityService, relatedPostService service.RelatedPostService, seriesService service.SeriesService, basePostAssembler BasePostAssembler) PostAssembler {
 	return &postAssemblerImpl{
 		PostService:    postService,
 		PostTagService: postTagService,
@@ -41,8 +50,13 @@ func NewPostAssembler(postService service.PostService, postTagService service.Po
 		// PostCommentService:  postCommentService,
 		PostCategoryService: postCategoryService,
 		CategoryService:     categoryService,
-		// MetaService:         metaService,
-		BasePostAssembler: basePostAssembler,
+		MetaService:         metaService,
+		ReactionService:     reactionService,
+		OptionService:       optionService,
+		VisibilityService:   visibilityService,
+		RelatedPostService:  relatedPostService,
+		SeriesService:       seriesService,
+		BasePostAssembler:   basePostAssembler,
 	}
 }
 
@@ -122,7 +136,7 @@ func (p *postAssemblerImpl) ConvertToPostVOs(ctx context.Context, posts []*entit
 	return postVOs, nil
 }
 
-func (p *postAssemblerImpl) ConvertToDetailVO(ctx context.Context, post *entity.Post) (*vo.PostDetail, error) {
+func (p *postAssemblerImpl) ConvertToDetailVO(ctx context.Context, post *entity.Post, hiddenCategoryIDs []int32) (*vo.PostDetail, error) {
 	if post == nil {
 		return nil, nil
 	}
@@ -153,30 +167,135 @@ func (p *postAssemblerImpl) ConvertToDetailVO(ctx context.Context, post *entity.
 	}
 	postDetailVO.Categories = categoryDTOs
 
-	prePost, err := p.PostService.GetPrevPosts(ctx, post, 1)
+	metas, err := p.MetaService.ListByPostID(ctx, post.ID)
 	if err != nil {
 		return nil, err
 	}
-	nextPost, err := p.PostService.GetNextPosts(ctx, post, 1)
+	postDetailVO.Metas = p.MetaService.ConvertToMetaDTOs(ctx, metas)
+
+	postDetailVO.Reactions, err = p.ReactionService.ListReactionsByPostID(ctx, post.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	// 属于系列的文章，上一篇、下一篇为系列中的相邻文章
+	postDetailVO.Series, err = p.convertToPostSeries(ctx, post, hiddenCategoryIDs)
 	if err != nil {
 		return nil, err
 	}
-	if len(prePost) != 0 {
-		postDetailVO.PrePost, err = p.BasePostAssembler.ConvertToPostOutlineDTO(ctx, prePost[0])
+	if postDetailVO.Series != nil {
+		postDetailVO.PrePost = postDetailVO.Series.PrePost
+		postDetailVO.NextPost = postDetailVO.Series.NextPost
+	} else {
+		prePost, err := p.PostService.GetPrevPosts(ctx, post, 1)
 		if err != nil {
 			return nil, err
 		}
-	}
-	if len(nextPost) != 0 {
-		postDetailVO.NextPost, err = p.BasePostAssembler.ConvertToPostOutlineDTO(ctx, nextPost[0])
+		nextPost, err := p.PostService.GetNextPosts(ctx, post, 1)
 		if err != nil {
 			return nil, err
 		}
+		if len(prePost) != 0 {
+			postDetailVO.PrePost, err = p.BasePostAssembler.ConvertToPostOutlineDTO(ctx, prePost[0])
+			if err != nil {
+				return nil, err
+			}
+		}
+		if len(nextPost) != 0 {
+			postDetailVO.NextPost, err = p.BasePostAssembler.ConvertToPostOutlineDTO(ctx, nextPost[0])
+			if err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	relatedSize := p.OptionService.GetOrByDefault(ctx, property.RelatedPostSize).(int)
+	relatedPosts, err := p.RelatedPostService.ListRelatedPosts(ctx, post, relatedSize, hiddenCategoryIDs)
+	if err != nil {
+		return nil, err
+	}
+	postDetailVO.Related, err = p.ConvertToPostOutlineDTOs(ctx, relatedPosts)
+	if err != nil {
+		return nil, err
 	}
 
 	return postDetailVO, nil
 }
 
+// convertToPostSeries 位置和总数只计算访问者可见的文章，文章对访问者不可见或不属于任何系列时返回 nil
+func (p *postAssemblerImpl) convertToPostSeries(ctx context.Context, post *entity.Post, hiddenCategoryIDs []int32) (*dto.PostSeries, error) {
+	series, err := p.SeriesService.GetByPostID(ctx, post.ID)
+	if xerr.GetType(err) == xerr.NoRecord {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	posts, err := p.SeriesService.ListPosts(ctx, series.ID, p.VisibilityService.VisibleStatuses(ctx))
+	if err != nil {
+		return nil, err
+	}
+	if len(hiddenCategoryIDs) > 0 {
+		hiddenPostIDs, err := p.PostCategoryService.ListPostIDSetByCategoryIDs(ctx, hiddenCategoryIDs)
+		if err != nil {
+			return nil, err
+		}
+		visiblePosts := make([]*entity.Post, 0, len(posts))
+		for _, seriesPost := range posts {
+			if _, ok := hiddenPostIDs[seriesPost.ID]; !ok {
+				visiblePosts = append(visiblePosts, seriesPost)
+			}
+		}
+		posts = visiblePosts
+	}
+	index := -1
+	for i, seriesPost := range posts {
+		if seriesPost.ID == post.ID {
+			index = i
+			break
+		}
+	}
+	if index < 0 {
+		return nil, nil
+	}
+
+	seriesDTO, err := p.SeriesService.ConvertToSeriesDTO(ctx, series)
+	if err != nil {
+		return nil, err
+	}
+	seriesDTO.PostCount = int64(len(posts))
+	postSeries := &dto.PostSeries{
+		Series: seriesDTO,
+		Index:  index + 1,
+		Total:  len(posts),
+	}
+	if index > 0 {
+		postSeries.PrePost, err = p.BasePostAssembler.ConvertToPostOutlineDTO(ctx, posts[index-1])
+		if err != nil {
+			return nil, err
+		}
+	}
+	if index < len(posts)-1 {
+		postSeries.NextPost, err = p.BasePostAssembler.ConvertToPostOutlineDTO(ctx, posts[index+1])
+		if err != nil {
+			return nil, err
+		}
+	}
+	return postSeries, nil
+}
+
+func (p *postAssemblerImpl) ConvertToPostOutlineDTOs(ctx context.Context, posts []*entity.Post) ([]*dto.PostOutline, error) {
+	postOutlineDTOs := make([]*dto.PostOutline, 0, len(posts))
+	for _, post := range posts {
+		postOutlineDTO, err := p.ConvertToPostOutlineDTO(ctx, post)
+		if err != nil {
+			return nil, err
+		}
+		postOutlineDTOs = append(postOutlineDTOs, postOutlineDTO)
+	}
+	return postOutlineDTOs, nil
+}
+
 func (p *postAssemblerImpl) ConvertToArchivesVOs(ctx context.Context, posts []*entity.Post) ([]*vo.Archive, error) {
 	postVos, err := p.ConvertToPostVOs(ctx, posts)
 	if err != nil {
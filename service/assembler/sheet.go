@@ -0,0 +1,95 @@
+package assembler
+
+import (
+	"context"
+	"dash/model/dto"
+	"dash/model/entity"
+	"dash/model/vo"
+	"dash/service"
+	"dash/utils/xerr"
+)
+
+type SheetAssembler interface {
+	BasePostAssembler
+	ConvertToSheetVOs(ctx context.Context, sheets []*entity.Post) ([]*vo.Sheet, error)
+	ConvertToSheetDetailVO(ctx context.Context, sheet *entity.Post) (*vo.SheetDetail, error)
+}
+
+type sheetAssemblerImpl struct {
+	BasePostAssembler
+	SheetService      service.SheetService
+	MetaService       service.MetaService
+	VisibilityService service.VisibilityService
+}
+
+func NewSheetAssembler(basePostAssembler BasePostAssembler, sheetService service.SheetService, metaService service.MetaService, visibilityService service.VisibilityService) SheetAssembler {
+	return &sheetAssemblerImpl{
+		BasePostAssembler: basePostAssembler,
+		SheetService:      sheetService,
+		MetaService:       metaService,
+		VisibilityService: visibilityService,
+	}
+}
+
+func (s *sheetAssemblerImpl) ConvertToSheetVOs(ctx context.Context, sheets []*entity.Post) ([]*vo.Sheet, error) {
+	sheetVOs := make([]*vo.Sheet, 0, len(sheets))
+	for _, sheet := range sheets {
+		postDTO, err := s.ConvertToPostDTO(ctx, sheet)
+		if err != nil {
+			return nil, err
+		}
+		sheetVOs = append(sheetVOs, &vo.Sheet{
+			Post:     *postDTO,
+			ParentID: sheet.ParentID,
+		})
+	}
+	return sheetVOs, nil
+}
+
+func (s *sheetAssemblerImpl) ConvertToSheetDetailVO(ctx context.Context, sheet *entity.Post) (*vo.SheetDetail, error) {
+	postDetailDTO, err := s.ConvertToDetailDTO(ctx, sheet)
+	if err != nil {
+		return nil, err
+	}
+	sheetDetailVO := &vo.SheetDetail{
+		PostDetail: *postDetailDTO,
+		ParentID:   sheet.ParentID,
+	}
+
+	metas, err := s.MetaService.ListByPostID(ctx, sheet.ID)
+	if err != nil {
+		return nil, err
+	}
+	sheetDetailVO.Metas = s.MetaService.ConvertToMetaDTOs(ctx, metas)
+
+	if sheet.ParentID != 0 {
+		// 父页面已删除、在回收站或对访问者不可见时按顶级页面处理
+		parent, err := s.SheetService.GetSheetByID(ctx, sheet.ParentID)
+		if err == nil {
+			err = s.VisibilityService.CheckPostVisible(ctx, parent)
+		}
+		if err != nil && xerr.GetType(err) != xerr.NoRecord {
+			return nil, err
+		}
+		if err == nil {
+			sheetDetailVO.Parent, err = s.ConvertToPostOutlineDTO(ctx, parent)
+			if err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	children, err := s.SheetService.ListChildren(ctx, sheet.ID, s.VisibilityService.VisibleStatuses(ctx))
+	if err != nil {
+		return nil, err
+	}
+	sheetDetailVO.Children = make([]*dto.PostOutline, 0, len(children))
+	for _, child := range children {
+		childDTO, err := s.ConvertToPostOutlineDTO(ctx, child)
+		if err != nil {
+			return nil, err
+		}
+		sheetDetailVO.Children = append(sheetDetailVO.Children, childDTO)
+	}
+	return sheetDetailVO, nil
+}
@@ -0,0 +1,19 @@
+package service
+
+import (
+	"context"
+	"dash/model/dto"
+)
+
+type BackupService interface {
+	// Backup 备份所有数据表（JSON）和上传目录为 zip，与数据库类型无关，可以恢复到 SQLite 或 MySQL
+	Backup(ctx context.Context) (*dto.ExportFile, error)
+	ListBackups(ctx context.Context) ([]*dto.ExportFile, error)
+	// GetBackupFilePath 返回备份文件的路径，文件不存在时返回 NoRecord
+	GetBackupFilePath(ctx context.Context, filename string) (string, error)
+	DeleteBackup(ctx context.Context, filename string) error
+	// Restore 校验备份版本后在一个事务中清空并写入所有数据表，成功后再恢复上传目录
+	Restore(ctx context.Context, src string) (*dto.RestoreResult, error)
+	// CleanBackups 只保留最新的 keep 个备份文件，返回删除的文件数
+	CleanBackups(ctx context.Context, keep int) (int, error)
+}
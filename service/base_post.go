@@ -4,6 +4,7 @@ import (
 	"context"
 
 	"dash/consts"
+	"dash/model/dto"
 	"dash/model/entity"
 	"dash/model/param"
 )
@@ -20,6 +21,12 @@ type BasePostService interface {
 	GetPostByID(ctx context.Context, id int32) (*entity.Post, error)
 	GetPostBySlug(ctx context.Context, slug string) (*entity.Post, error)
 	GetPostsCount(ctx context.Context) (int64, error)
+	// UnlockPost 校验文章访问密码，通过后签发短期有效的解锁令牌，clientIP 用于限制输错次数
+	UnlockPost(ctx context.Context, post *entity.Post, password string, clientIP string) (*dto.UnlockToken, error)
+	// IsPostUnlocked 判断请求是否可以访问文章内容，未设置密码的文章总是返回 true
+	IsPostUnlocked(ctx context.Context, post *entity.Post, unlockTokens []string) bool
+	// RenderAll 使用当前渲染设置重新渲染所有 Markdown 文章，返回内容发生变化的数量
+	RenderAll(ctx context.Context) (int64, error)
 
 	ConvertToEntity(ctx context.Context, postParam *param.Post, postType consts.PostType) (*entity.Post, error)
 }
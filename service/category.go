@@ -17,6 +17,11 @@ type CategoryService interface {
 	GetCategoryByName(ctx context.Context, name string) (*entity.Category, error)
 	GetCategoryBySlug(ctx context.Context, slug string) (*entity.Category, error)
 	GetCategoriesCount(ctx context.Context) (int64, error)
+	// UnlockCategory 校验分类访问密码，通过后签发解锁令牌，分类下的文章在令牌有效期内均可访问
+	UnlockCategory(ctx context.Context, category *entity.Category, password string, clientIP string) (*dto.UnlockToken, error)
+	// ListHiddenCategoryIDs 返回对游客隐藏的分类，包括私密分类和未被 unlockTokens 解锁的加密分类
+	ListHiddenCategoryIDs(ctx context.Context, unlockTokens []string) ([]int32, error)
+	IsCategoryUnlocked(ctx context.Context, category *entity.Category, unlockTokens []string) bool
 
 	ConvertToCategoryDTO(ctx context.Context, category *entity.Category) (*dto.Category, error)
 	ConvertToCategoryDTOs(ctx context.Context, categories []*entity.Category) ([]*dto.Category, error)
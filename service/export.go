@@ -0,0 +1,13 @@
+package service
+
+import (
+	"context"
+	"dash/model/dto"
+)
+
+type ExportService interface {
+	// ExportMarkdown 导出所有文章和页面为带 YAML front matter 的 Markdown，分类、标签、菜单和设置导出为 JSON，打包为 zip
+	ExportMarkdown(ctx context.Context) (*dto.ExportFile, error)
+	// GetExportFilePath 返回导出文件的路径，文件不存在时返回 NoRecord
+	GetExportFilePath(ctx context.Context, filename string) (string, error)
+}
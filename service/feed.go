@@ -0,0 +1,17 @@
+package service
+
+import (
+	"context"
+	"dash/consts"
+	"dash/model/dto"
+)
+
+// FeedService 生成 RSS 2.0、Atom 和 JSON Feed 订阅，只包含游客可见且没有设置密码的文章
+type FeedService interface {
+	// GetFeed 整个博客的订阅
+	GetFeed(ctx context.Context, format consts.FeedFormat) (*dto.RawContent, error)
+	// GetCategoryFeed 分类下文章的订阅，私密分类和未解锁的加密分类返回 NotFound
+	GetCategoryFeed(ctx context.Context, format consts.FeedFormat, slug string) (*dto.RawContent, error)
+	// GetTagFeed 标签下文章的订阅
+	GetTagFeed(ctx context.Context, format consts.FeedFormat, slug string) (*dto.RawContent, error)
+}
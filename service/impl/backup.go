@@ -0,0 +1,539 @@
+package impl
+
+import (
+	"archive/zip"
+	"context"
+	"dash/cache"
+	"dash/config"
+	"dash/consts"
+	"dash/dal"
+	"dash/model/dto"
+	"dash/service"
+	"dash/utils"
+	"dash/utils/xerr"
+	"encoding/json"
+	"io"
+	"os"
+	"path"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"strings"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/schema"
+)
+
+const (
+	backupApp     = "dash"
+	backupVersion = 1
+	backupPrefix  = "dash-backup-"
+
+	backupManifestName = "manifest.json"
+	backupDataDir      = "data/"
+	backupUploadDir    = "upload/"
+	backupBatchSize    = 500
+)
+
+type backupServiceImpl struct {
+	Config             *config.Config
+	DB                 *gorm.DB
+	OptionService      service.OptionService
+	RelatedPostService service.RelatedPostService
+}
+
+func NewBackupService(conf *config.Config, db *gorm.DB, optionService service.OptionService, relatedPostService service.RelatedPostService) service.BackupService {
+	return &backupServiceImpl{
+		Config:             conf,
+		DB:                 db,
+		OptionService:      optionService,
+		RelatedPostService: relatedPostService,
+	}
+}
+
+func (b *backupServiceImpl) backupDir() string {
+	return b.Config.Dash.Backup.Dir
+}
+
+func (b *backupServiceImpl) uploadDir() string {
+	if b.Config.Dash.UploadDir != "" {
+		return b.Config.Dash.UploadDir
+	}
+	return filepath.Join(b.Config.Dash.WorkDir, consts.DashUploadDir)
+}
+
+func backupTableName(model interface{}) string {
+	return model.(schema.Tabler).TableName()
+}
+
+func (b *backupServiceImpl) Backup(ctx context.Context) (*dto.ExportFile, error) {
+	backupDir := b.backupDir()
+	err := utils.MakeDir(backupDir)
+	if err != nil {
+		return nil, xerr.NoType.Wrap(err).WithMsg("create backup dir failed")
+	}
+	now := time.Now()
+	filename := backupPrefix + now.Format("20060102150405") + "-" + utils.GenUUIDWithOutDash()[:8] + ".zip"
+	// 先写临时文件，完成后再改名，避免列表中出现未写完的备份
+	tempPath := filepath.Join(backupDir, filename+".tmp")
+	err = b.writeBackup(ctx, tempPath, now)
+	if err != nil {
+		os.Remove(tempPath)
+		return nil, err
+	}
+	backupPath := filepath.Join(backupDir, filename)
+	err = os.Rename(tempPath, backupPath)
+	if err != nil {
+		os.Remove(tempPath)
+		return nil, xerr.NoType.Wrap(err).WithMsg("backup failed")
+	}
+	info, err := os.Stat(backupPath)
+	if err != nil {
+		return nil, xerr.NoType.Wrap(err).WithMsg("backup failed")
+	}
+	return &dto.ExportFile{
+		Filename:   filename,
+		Size:       info.Size(),
+		CreateTime: now.UnixMilli(),
+	}, nil
+}
+
+func (b *backupServiceImpl) writeBackup(ctx context.Context, dst string, now time.Time) (err error) {
+	file, err := os.Create(dst)
+	if err != nil {
+		return xerr.NoType.Wrap(err).WithMsg("create backup file failed")
+	}
+	defer func() {
+		if closeErr := file.Close(); err == nil && closeErr != nil {
+			err = xerr.NoType.Wrap(closeErr).WithMsg("write backup file failed")
+		}
+	}()
+	zw := zip.NewWriter(file)
+	manifest := &dto.BackupManifest{
+		App:        backupApp,
+		Version:    backupVersion,
+		CreateTime: now.UnixMilli(),
+		DBType:     string(dal.DBType),
+		Tables:     make(map[string]int64),
+	}
+
+	// 在一个事务中读取所有表，保证各表数据一致
+	err = b.DB.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		for _, model := range dal.Models() {
+			count, err := writeBackupTable(tx, zw, model)
+			if err != nil {
+				return err
+			}
+			manifest.Tables[backupTableName(model)] = count
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	manifest.Uploads, err = writeBackupUploads(zw, b.uploadDir())
+	if err != nil {
+		return err
+	}
+
+	w, err := zw.Create(backupManifestName)
+	if err != nil {
+		return xerr.NoType.Wrap(err).WithMsg("write backup file failed")
+	}
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	err = encoder.Encode(manifest)
+	if err != nil {
+		return xerr.NoType.Wrap(err).WithMsg("write backup file failed")
+	}
+	err = zw.Close()
+	if err != nil {
+		return xerr.NoType.Wrap(err).WithMsg("write backup file failed")
+	}
+	return nil
+}
+
+// writeBackupTable 按主键分批读取，每张表写为 data/<table>.json 的 JSON 数组
+func writeBackupTable(tx *gorm.DB, zw *zip.Writer, model interface{}) (int64, error) {
+	w, err := zw.Create(backupDataDir + backupTableName(model) + ".json")
+	if err != nil {
+		return 0, xerr.NoType.Wrap(err).WithMsg("write backup file failed")
+	}
+	_, err = io.WriteString(w, "[")
+	if err != nil {
+		return 0, xerr.NoType.Wrap(err).WithMsg("write backup file failed")
+	}
+	var count int64
+	rows := reflect.New(reflect.SliceOf(reflect.TypeOf(model)))
+	result := tx.Model(model).FindInBatches(rows.Interface(), backupBatchSize, func(batchTx *gorm.DB, batch int) error {
+		for i := 0; i < rows.Elem().Len(); i++ {
+			data, err := json.Marshal(rows.Elem().Index(i).Interface())
+			if err != nil {
+				return err
+			}
+			if count > 0 {
+				_, err = io.WriteString(w, ",")
+				if err != nil {
+					return err
+				}
+			}
+			_, err = io.WriteString(w, "\n")
+			if err != nil {
+				return err
+			}
+			_, err = w.Write(data)
+			if err != nil {
+				return err
+			}
+			count++
+		}
+		return nil
+	})
+	if result.Error != nil {
+		return 0, WrapDBErr(result.Error)
+	}
+	_, err = io.WriteString(w, "\n]\n")
+	if err != nil {
+		return 0, xerr.NoType.Wrap(err).WithMsg("write backup file failed")
+	}
+	return count, nil
+}
+
+func writeBackupUploads(zw *zip.Writer, uploadDir string) (int64, error) {
+	if !utils.FileIsExisted(uploadDir) {
+		return 0, nil
+	}
+	var count int64
+	err := filepath.Walk(uploadDir, func(filePath string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if !info.Mode().IsRegular() {
+			return nil
+		}
+		rel, err := filepath.Rel(uploadDir, filePath)
+		if err != nil {
+			return err
+		}
+		header, err := zip.FileInfoHeader(info)
+		if err != nil {
+			return err
+		}
+		header.Name = backupUploadDir + filepath.ToSlash(rel)
+		header.Method = zip.Deflate
+		w, err := zw.CreateHeader(header)
+		if err != nil {
+			return err
+		}
+		src, err := os.Open(filePath)
+		if err != nil {
+			return err
+		}
+		defer src.Close()
+		_, err = io.Copy(w, src)
+		if err != nil {
+			return err
+		}
+		count++
+		return nil
+	})
+	if err != nil {
+		return 0, xerr.NoType.Wrap(err).WithMsg("backup upload files failed")
+	}
+	return count, nil
+}
+
+func (b *backupServiceImpl) ListBackups(ctx context.Context) ([]*dto.ExportFile, error) {
+	entries, err := os.ReadDir(b.backupDir())
+	if os.IsNotExist(err) {
+		return []*dto.ExportFile{}, nil
+	}
+	if err != nil {
+		return nil, xerr.NoType.Wrap(err).WithMsg("read backup dir failed")
+	}
+	backups := make([]*dto.ExportFile, 0, len(entries))
+	for _, entry := range entries {
+		if entry.IsDir() || !isBackupFilename(entry.Name()) {
+			continue
+		}
+		info, err := entry.Info()
+		if err != nil {
+			continue
+		}
+		backups = append(backups, &dto.ExportFile{
+			Filename:   entry.Name(),
+			Size:       info.Size(),
+			CreateTime: info.ModTime().UnixMilli(),
+		})
+	}
+	// 最新的在前，文件名中包含时间，时间相同时按文件名排序
+	sort.Slice(backups, func(i, j int) bool {
+		if backups[i].CreateTime != backups[j].CreateTime {
+			return backups[i].CreateTime > backups[j].CreateTime
+		}
+		return backups[i].Filename > backups[j].Filename
+	})
+	return backups, nil
+}
+
+func isBackupFilename(filename string) bool {
+	return filename == filepath.Base(filename) && strings.HasPrefix(filename, backupPrefix) && filepath.Ext(filename) == ".zip"
+}
+
+func (b *backupServiceImpl) GetBackupFilePath(ctx context.Context, filename string) (string, error) {
+	if !isBackupFilename(filename) {
+		return "", xerr.BadParam.New("invalid backup filename %s", filename).WithMsg("invalid filename").WithStatus(xerr.StatusBadRequest)
+	}
+	backupPath := filepath.Join(b.backupDir(), filename)
+	_, err := os.Stat(backupPath)
+	if os.IsNotExist(err) {
+		return "", xerr.NoRecord.Wrap(err).WithMsg("backup file not found").WithStatus(xerr.StatusNotFound)
+	}
+	if err != nil {
+		return "", xerr.NoType.Wrap(err).WithMsg("read backup file failed")
+	}
+	return backupPath, nil
+}
+
+func (b *backupServiceImpl) DeleteBackup(ctx context.Context, filename string) error {
+	backupPath, err := b.GetBackupFilePath(ctx, filename)
+	if err != nil {
+		return err
+	}
+	err = os.Remove(backupPath)
+	if err != nil {
+		return xerr.NoType.Wrap(err).WithMsg("delete backup file failed")
+	}
+	return nil
+}
+
+func (b *backupServiceImpl) CleanBackups(ctx context.Context, keep int) (int, error) {
+	if keep <= 0 {
+		return 0, nil
+	}
+	backups, err := b.ListBackups(ctx)
+	if err != nil {
+		return 0, err
+	}
+	count := 0
+	for i := keep; i < len(backups); i++ {
+		err = os.Remove(filepath.Join(b.backupDir(), backups[i].Filename))
+		if err != nil {
+			return count, xerr.NoType.Wrap(err).WithMsg("delete backup file failed")
+		}
+		count++
+	}
+	return count, nil
+}
+
+func (b *backupServiceImpl) Restore(ctx context.Context, src string) (*dto.RestoreResult, error) {
+	reader, err := zip.OpenReader(src)
+	if err != nil {
+		return nil, xerr.BadParam.Wrap(err).WithMsg("invalid backup file").WithStatus(xerr.StatusBadRequest)
+	}
+	defer reader.Close()
+	files := make(map[string]*zip.File, len(reader.File))
+	for _, file := range reader.File {
+		files[file.Name] = file
+	}
+	manifest, err := readBackupManifest(files[backupManifestName])
+	if err != nil {
+		return nil, err
+	}
+
+	// 先检查备份中的表都能识别，避免写入到一半才失败
+	models := make(map[string]interface{})
+	for _, model := range dal.Models() {
+		models[backupTableName(model)] = model
+	}
+	for table := range manifest.Tables {
+		if _, ok := models[table]; !ok {
+			return nil, xerr.BadParam.New("unknown table %s", table).WithMsg("backup contains unknown table " + table).WithStatus(xerr.StatusBadRequest)
+		}
+		if _, ok := files[backupDataDir+table+".json"]; !ok {
+			return nil, xerr.BadParam.New("missing table %s", table).WithMsg("backup file is incomplete").WithStatus(xerr.StatusBadRequest)
+		}
+	}
+
+	result := &dto.RestoreResult{
+		Version:    manifest.Version,
+		CreateTime: manifest.CreateTime,
+		Tables:     make(map[string]int64),
+	}
+	// 备份中没有的表也会被清空，恢复后的数据与备份时一致
+	err = b.DB.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		for _, model := range dal.Models() {
+			table := backupTableName(model)
+			err := tx.Session(&gorm.Session{AllowGlobalUpdate: true}).Delete(model).Error
+			if err != nil {
+				return WrapDBErr(err)
+			}
+			file, ok := files[backupDataDir+table+".json"]
+			if !ok {
+				continue
+			}
+			count, err := restoreBackupTable(tx, file, model)
+			if err != nil {
+				return err
+			}
+			result.Tables[table] = count
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	b.clearCache(ctx)
+
+	result.Uploads, err = restoreBackupUploads(reader.File, b.uploadDir())
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+func readBackupManifest(file *zip.File) (*dto.BackupManifest, error) {
+	if file == nil {
+		return nil, xerr.BadParam.New("").WithMsg("invalid backup file: manifest.json not found").WithStatus(xerr.StatusBadRequest)
+	}
+	reader, err := file.Open()
+	if err != nil {
+		return nil, xerr.BadParam.Wrap(err).WithMsg("invalid backup file").WithStatus(xerr.StatusBadRequest)
+	}
+	defer reader.Close()
+	manifest := &dto.BackupManifest{}
+	err = json.NewDecoder(reader).Decode(manifest)
+	if err != nil {
+		return nil, xerr.BadParam.Wrap(err).WithMsg("invalid backup file: bad manifest.json").WithStatus(xerr.StatusBadRequest)
+	}
+	if manifest.App != backupApp {
+		return nil, xerr.BadParam.New("app=%s", manifest.App).WithMsg("not a dash backup file").WithStatus(xerr.StatusBadRequest)
+	}
+	if manifest.Version < 1 || manifest.Version > backupVersion {
+		return nil, xerr.BadParam.New("version=%d", manifest.Version).WithMsg("unsupported backup version").WithStatus(xerr.StatusBadRequest)
+	}
+	return manifest, nil
+}
+
+// restoreBackupTable 流式读取 JSON 数组并分批写入
+// 按列名写入 map，避免 gorm 把零值字段（如已发布状态 0）替换为列的默认值
+func restoreBackupTable(tx *gorm.DB, file *zip.File, model interface{}) (int64, error) {
+	reader, err := file.Open()
+	if err != nil {
+		return 0, xerr.BadParam.Wrap(err).WithMsg("invalid backup file").WithStatus(xerr.StatusBadRequest)
+	}
+	defer reader.Close()
+	invalidErr := func(err error) error {
+		return xerr.BadParam.Wrap(err).WithMsg("invalid backup data: " + file.Name).WithStatus(xerr.StatusBadRequest)
+	}
+	decoder := json.NewDecoder(reader)
+	token, err := decoder.Token()
+	if err != nil {
+		return 0, invalidErr(err)
+	}
+	if delim, ok := token.(json.Delim); !ok || delim != '[' {
+		return 0, invalidErr(nil)
+	}
+	stmt := &gorm.Statement{DB: tx}
+	err = stmt.Parse(model)
+	if err != nil {
+		return 0, WrapDBErr(err)
+	}
+
+	var count int64
+	modelType := reflect.TypeOf(model).Elem()
+	batch := make([]map[string]interface{}, 0, backupBatchSize)
+	flush := func() error {
+		if len(batch) == 0 {
+			return nil
+		}
+		err := tx.Table(stmt.Schema.Table).Create(batch).Error
+		if err != nil {
+			return WrapDBErr(err)
+		}
+		count += int64(len(batch))
+		batch = make([]map[string]interface{}, 0, backupBatchSize)
+		return nil
+	}
+	for decoder.More() {
+		row := reflect.New(modelType)
+		err = decoder.Decode(row.Interface())
+		if err != nil {
+			return 0, invalidErr(err)
+		}
+		values := make(map[string]interface{}, len(stmt.Schema.DBNames))
+		for _, field := range stmt.Schema.Fields {
+			if field.DBName == "" {
+				continue
+			}
+			values[field.DBName], _ = field.ValueOf(tx.Statement.Context, row.Elem())
+		}
+		batch = append(batch, values)
+		if len(batch) >= backupBatchSize {
+			err = flush()
+			if err != nil {
+				return 0, err
+			}
+		}
+	}
+	err = flush()
+	if err != nil {
+		return 0, err
+	}
+	return count, nil
+}
+
+// restoreBackupUploads 把 upload/ 下的文件写回上传目录，已有的同名文件会被覆盖
+func restoreBackupUploads(files []*zip.File, uploadDir string) (int64, error) {
+	var count int64
+	cleanDir := filepath.Clean(uploadDir) + string(os.PathSeparator)
+	for _, file := range files {
+		if !strings.HasPrefix(file.Name, backupUploadDir) || file.FileInfo().IsDir() {
+			continue
+		}
+		name := path.Clean(strings.TrimPrefix(file.Name, backupUploadDir))
+		dst := filepath.Join(uploadDir, filepath.FromSlash(name))
+		if !strings.HasPrefix(dst, cleanDir) {
+			return count, xerr.BadParam.New("illegal file path %s", file.Name).WithMsg("invalid backup file").WithStatus(xerr.StatusBadRequest)
+		}
+		err := restoreBackupFile(file, dst)
+		if err != nil {
+			return count, xerr.NoType.Wrap(err).WithMsg("restore upload files failed")
+		}
+		count++
+	}
+	return count, nil
+}
+
+func restoreBackupFile(file *zip.File, dst string) error {
+	err := utils.MakeDir(filepath.Dir(dst))
+	if err != nil {
+		return err
+	}
+	reader, err := file.Open()
+	if err != nil {
+		return err
+	}
+	defer reader.Close()
+	out, err := os.OpenFile(dst, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0o644)
+	if err != nil {
+		return err
+	}
+	_, err = io.Copy(out, reader)
+	if closeErr := out.Close(); err == nil {
+		err = closeErr
+	}
+	return err
+}
+
+// clearCache 设置和相关文章缓存来自数据库，恢复后需要清除
+func (b *backupServiceImpl) clearCache(ctx context.Context) {
+	keys := make([]string, 0)
+	for key := range b.OptionService.OptionMap() {
+		keys = append(keys, key)
+	}
+	_ = cache.BatchDelete(keys)
+	b.RelatedPostService.Invalidate(ctx)
+	invalidateContentCache(ctx)
+}
@@ -4,24 +4,50 @@ import (
 	"context"
 	"dash/consts"
 	"dash/dal"
+	"dash/model/dto"
 	"dash/model/entity"
 	"dash/model/param"
 	"dash/service"
 	"dash/utils"
 	"dash/utils/xerr"
+	"encoding/json"
 	"regexp"
+	"strconv"
 	"time"
+
+	"gorm.io/gen/field"
 )
 
 type basePostServiceImpl struct {
-	OptionService service.OptionService
+	OptionService       service.OptionService
+	PostRevisionService service.PostRevisionService
+	MarkdownService     service.MarkdownService
+	OneTimeTokenService service.OneTimeTokenService
+	MetaService         service.MetaService
+	SearchService       service.SearchService
+	RelatedPostService  service.RelatedPostService
+	SlugHistoryService  service.SlugHistoryService
 }
 
 func NewBasePostService(
 	optionService service.OptionService,
+	postRevisionService service.PostRevisionService,
+	markdownService service.MarkdownService,
+	oneTimeTokenService service.OneTimeTokenService,
+	metaService service.MetaService,
+	searchService service.SearchService,
+	relatedPostService service.RelatedPostService,
+	slugHistoryService service.SlugHistoryService,
 ) service.BasePostService {
 	return &basePostServiceImpl{
-		OptionService: optionService,
+		OptionService:       optionService,
+		PostRevisionService: postRevisionService,
+		MarkdownService:     markdownService,
+		OneTimeTokenService: oneTimeTokenService,
+		MetaService:         metaService,
+		SearchService:       searchService,
+		RelatedPostService:  relatedPostService,
+		SlugHistoryService:  slugHistoryService,
 	}
 }
 
@@ -52,7 +78,7 @@ func (b *basePostServiceImpl) Create(ctx context.Context, postParam *param.Post,
 		}
 
 		if post.Summary == "" {
-			post.Summary = b.generateSummary(ctx, post.FormatContent)
+			post.Summary = generateSummary(ctx, b.OptionService, post.FormatContent)
 		}
 
 		status := post.Status
@@ -115,11 +141,29 @@ func (b *basePostServiceImpl) Create(ctx context.Context, postParam *param.Post,
 			}
 		}
 
-		return nil
+		err = b.MetaService.SaveByPostID(txCtx, post.ID, postParam.Metas)
+		if err != nil {
+			return err
+		}
+
+		err = b.SearchService.IndexPost(txCtx, post)
+		if err != nil {
+			return err
+		}
+
+		err = syncRecycleItems(txCtx, []*entity.Post{post})
+		if err != nil {
+			return err
+		}
+
+		_, err = b.PostRevisionService.Create(txCtx, post, "created")
+		return err
 	})
 	if err != nil {
 		return nil, err
 	}
+	b.RelatedPostService.Invalidate(ctx)
+	invalidateContentCache(ctx)
 	return post, nil
 }
 
@@ -130,6 +174,10 @@ func (b *basePostServiceImpl) DeleteByID(ctx context.Context, id int32) error {
 		postCategoryDAL := query.PostCategory
 		postTagDAL := query.PostTag
 
+		err := checkChildSheets(txCtx, []int32{id}, false)
+		if err != nil {
+			return err
+		}
 		// delete post
 		deleteResult, err := postDAL.WithContext(ctx).Where(postDAL.ID.Eq(id)).Delete()
 		if err != nil {
@@ -146,9 +194,43 @@ func (b *basePostServiceImpl) DeleteByID(ctx context.Context, id int32) error {
 		if err != nil {
 			return WrapDBErr(err)
 		}
-		return nil
+		err = b.MetaService.DeleteByPostID(txCtx, id)
+		if err != nil {
+			return err
+		}
+		_, err = query.PostReaction.WithContext(txCtx).Where(query.PostReaction.PostID.Eq(id)).Delete()
+		if err != nil {
+			return WrapDBErr(err)
+		}
+		err = b.SearchService.DeleteIndexByPostID(txCtx, id)
+		if err != nil {
+			return err
+		}
+		err = b.SlugHistoryService.DeleteByTargetID(txCtx, consts.SlugTypePost, id)
+		if err != nil {
+			return err
+		}
+		_, err = query.SeriesPost.WithContext(txCtx).Where(query.SeriesPost.PostID.Eq(id)).Delete()
+		if err != nil {
+			return WrapDBErr(err)
+		}
+		_, err = query.PostDraft.WithContext(txCtx).Where(query.PostDraft.PostID.Eq(id)).Delete()
+		if err != nil {
+			return WrapDBErr(err)
+		}
+		recycleItemDAL := query.RecycleItem
+		_, err = recycleItemDAL.WithContext(txCtx).Where(recycleItemDAL.Type.In(consts.RecycleTypePost, consts.RecycleTypeSheet), recycleItemDAL.TargetID.Eq(id)).Delete()
+		if err != nil {
+			return WrapDBErr(err)
+		}
+		return b.PostRevisionService.DeleteByPostID(txCtx, id)
 	})
-	return err
+	if err != nil {
+		return err
+	}
+	b.RelatedPostService.Invalidate(ctx)
+	invalidateContentCache(ctx)
+	return nil
 }
 
 func (b *basePostServiceImpl) DeleteBatchByID(ctx context.Context, ids []int32) error {
@@ -164,6 +246,24 @@ func (b *basePostServiceImpl) DeleteBatchByID(ctx context.Context, ids []int32)
 	return err
 }
 
+// checkChildSheets 页面还有子页面时不能放入回收站或彻底删除，同一批处理的页面之间不计入。
+// 放入回收站时只统计未回收的子页面，彻底删除时回收站中的子页面也要先删除
+func checkChildSheets(ctx context.Context, ids []int32, recycle bool) error {
+	postDAL := dal.GetQueryByCtx(ctx).Post
+	postDO := postDAL.WithContext(ctx).Where(postDAL.Type.Eq(consts.PostTypeSheet), postDAL.ParentID.In(ids...), postDAL.ID.NotIn(ids...))
+	if recycle {
+		postDO = postDO.Where(postDAL.Status.Neq(consts.PostStatusRecycle))
+	}
+	childCount, err := postDO.Count()
+	if err != nil {
+		return WrapDBErr(err)
+	}
+	if childCount > 0 {
+		return xerr.BadParam.New("sheet ids=%v have children", ids).WithMsg("sheet has child sheets").WithStatus(xerr.StatusBadRequest)
+	}
+	return nil
+}
+
 func (b *basePostServiceImpl) UpdateByID(ctx context.Context, id int32, postParam *param.Post, postType consts.PostType) (*entity.Post, error) {
 	post, err := b.ConvertToEntity(ctx, postParam, postType)
 	if err != nil {
@@ -183,7 +283,7 @@ func (b *basePostServiceImpl) UpdateByID(ctx context.Context, id int32, postPara
 		postTagDAL := query.PostTag
 
 		// determine if the post ID exists
-		_, err := postDAL.WithContext(txCtx).Where(postDAL.ID.Eq(id)).First()
+		oldPost, err := postDAL.WithContext(txCtx).Where(postDAL.ID.Eq(id)).First()
 		if err != nil {
 			return WrapDBErr(err)
 		}
@@ -191,7 +291,7 @@ func (b *basePostServiceImpl) UpdateByID(ctx context.Context, id int32, postPara
 		// determine if the post slug exists
 		slugCount, err := postDAL.WithContext(txCtx).
 			Where(
-				postDAL.Slug.Neq(post.Slug),
+				postDAL.ID.Neq(id),
 				postDAL.Slug.Eq(post.Slug),
 			).Count()
 		if err != nil {
@@ -204,7 +304,7 @@ func (b *basePostServiceImpl) UpdateByID(ctx context.Context, id int32, postPara
 
 		// generateSummary
 		if post.Summary == "" {
-			post.Summary = b.generateSummary(ctx, post.FormatContent)
+			post.Summary = generateSummary(ctx, b.OptionService, post.FormatContent)
 		}
 
 		// update post
@@ -215,6 +315,54 @@ func (b *basePostServiceImpl) UpdateByID(ctx context.Context, id int32, postPara
 		if updateResult.RowsAffected != 1 {
 			return xerr.NoType.New("").WithMsg("update post failed")
 		}
+		err = b.SlugHistoryService.Record(txCtx, consts.SlugTypePost, id, oldPost.Slug, post.Slug)
+		if err != nil {
+			return err
+		}
+
+		// Updates skips nil fields, so the schedule is written explicitly to allow clearing it
+		scheduleAssigns := []field.AssignExpr{postDAL.PublishTime.Null(), postDAL.ExpireTime.Null()}
+		if post.PublishTime != nil {
+			scheduleAssigns[0] = postDAL.PublishTime.Value(*post.PublishTime)
+		}
+		if post.ExpireTime != nil {
+			scheduleAssigns[1] = postDAL.ExpireTime.Value(*post.ExpireTime)
+		}
+		_, err = postDAL.WithContext(txCtx).Where(postDAL.ID.Eq(id)).UpdateSimple(scheduleAssigns...)
+		if err != nil {
+			return WrapDBErr(err)
+		}
+
+		// moving a sheet back to the top level sets parent_id to 0, Updates would skip it
+		if postType == consts.PostTypeSheet && postParam.ParentID != nil {
+			_, err = postDAL.WithContext(txCtx).Where(postDAL.ID.Eq(id)).UpdateSimple(postDAL.ParentID.Value(*postParam.ParentID))
+			if err != nil {
+				return WrapDBErr(err)
+			}
+		}
+
+		// clearing the seo fields sets them to empty strings, Updates would skip them
+		seoAssigns := make([]field.AssignExpr, 0, 2)
+		if postParam.MetaDescription != nil {
+			seoAssigns = append(seoAssigns, postDAL.MetaDescription.Value(post.MetaDescription))
+		}
+		if postParam.MetaKeywords != nil {
+			seoAssigns = append(seoAssigns, postDAL.MetaKeywords.Value(post.MetaKeywords))
+		}
+		if len(seoAssigns) > 0 {
+			_, err = postDAL.WithContext(txCtx).Where(postDAL.ID.Eq(id)).UpdateSimple(seoAssigns...)
+			if err != nil {
+				return WrapDBErr(err)
+			}
+		}
+
+		// an empty password removes the protection, Updates would skip it
+		if postParam.Password != nil && *postParam.Password == "" {
+			_, err = postDAL.WithContext(txCtx).Where(postDAL.ID.Eq(id)).UpdateSimple(postDAL.Password.Value(""))
+			if err != nil {
+				return WrapDBErr(err)
+			}
+		}
 
 		_, err = postCategoryDAL.WithContext(txCtx).Where(postCategoryDAL.PostID.Eq(id)).Delete()
 		if err != nil {
@@ -274,15 +422,36 @@ func (b *basePostServiceImpl) UpdateByID(ctx context.Context, id int32, postPara
 				return err
 			}
 		}
+		// re-record post metas, a payload without metas leaves them unchanged
+		if postParam.Metas != nil {
+			err = b.MetaService.SaveByPostID(txCtx, id, postParam.Metas)
+			if err != nil {
+				return err
+			}
+		}
 		post, err = postDAL.WithContext(ctx).Where(postDAL.ID.Eq(id)).First()
 		if err != nil {
 			return WrapDBErr(err)
 		}
-		return nil
+
+		err = b.SearchService.IndexPost(txCtx, post)
+		if err != nil {
+			return err
+		}
+
+		err = syncRecycleItems(txCtx, []*entity.Post{post})
+		if err != nil {
+			return err
+		}
+
+		_, err = b.PostRevisionService.Create(txCtx, post, "updated")
+		return err
 	})
 	if err != nil {
 		return nil, err
 	}
+	b.RelatedPostService.Invalidate(ctx)
+	invalidateContentCache(ctx)
 	return post, nil
 }
 
@@ -296,14 +465,29 @@ func (b *basePostServiceImpl) UpdateStatusByID(ctx context.Context, id int32, st
 	if err != nil {
 		return nil, WrapDBErr(err)
 	}
-	updateResult, err := postDAL.WithContext(ctx).Where(postDAL.ID.Eq(id)).UpdateColumnSimple(postDAL.Status.Value(status))
-	if err != nil {
-		return nil, WrapDBErr(err)
+	if status == consts.PostStatusRecycle {
+		err = checkChildSheets(ctx, []int32{id}, true)
+		if err != nil {
+			return nil, err
+		}
 	}
-	if updateResult.RowsAffected != 1 {
-		return nil, xerr.NoType.New("update post status failed ID=%v", id).WithMsg("update post status failed")
+	err = dal.Transaction(ctx, func(txCtx context.Context) error {
+		postDAL := dal.GetQueryByCtx(txCtx).Post
+		updateResult, err := postDAL.WithContext(txCtx).Where(postDAL.ID.Eq(id)).UpdateColumnSimple(postDAL.Status.Value(status))
+		if err != nil {
+			return WrapDBErr(err)
+		}
+		if updateResult.RowsAffected != 1 {
+			return xerr.NoType.New("update post status failed ID=%v", id).WithMsg("update post status failed")
+		}
+		post.Status = status
+		return syncRecycleItems(txCtx, []*entity.Post{post})
+	})
+	if err != nil {
+		return nil, err
 	}
-	post.Status = status
+	b.RelatedPostService.Invalidate(ctx)
+	invalidateContentCache(ctx)
 	return post, nil
 }
 
@@ -320,25 +504,33 @@ func (b *basePostServiceImpl) UpdateStatusBatch(ctx context.Context, ids []int32
 	for postID := range uniquePostIDMap {
 		uniqueIDs = append(uniqueIDs, postID)
 	}
-	err := dal.GetQueryByCtx(ctx).Transaction(func(tx *dal.Query) error {
-		postDAL := tx.Post
-		updateResult, err := postDAL.WithContext(ctx).Where(postDAL.ID.In(uniqueIDs...)).UpdateColumnSimple(postDAL.Status.Value(status))
+	if status == consts.PostStatusRecycle {
+		err := checkChildSheets(ctx, uniqueIDs, true)
+		if err != nil {
+			return nil, err
+		}
+	}
+	var posts []*entity.Post
+	err := dal.Transaction(ctx, func(txCtx context.Context) error {
+		postDAL := dal.GetQueryByCtx(txCtx).Post
+		updateResult, err := postDAL.WithContext(txCtx).Where(postDAL.ID.In(uniqueIDs...)).UpdateColumnSimple(postDAL.Status.Value(status))
 		if err != nil {
 			return WrapDBErr(err)
 		}
 		if updateResult.RowsAffected != int64(len(uniqueIDs)) {
 			return xerr.NoType.New("").WithMsg("update post status failed")
 		}
-		return nil
+		posts, err = postDAL.WithContext(txCtx).Where(postDAL.ID.In(uniqueIDs...)).Find()
+		if err != nil {
+			return WrapDBErr(err)
+		}
+		return syncRecycleItems(txCtx, posts)
 	})
 	if err != nil {
 		return nil, err
 	}
-	postDAL := dal.GetQueryByCtx(ctx).Post
-	posts, err := postDAL.WithContext(ctx).Where(postDAL.ID.In(uniqueIDs...)).Find()
-	if err != nil {
-		return nil, WrapDBErr(err)
-	}
+	b.RelatedPostService.Invalidate(ctx)
+	invalidateContentCache(ctx)
 	return posts, nil
 }
 
@@ -406,11 +598,29 @@ func (b *basePostServiceImpl) ConvertToEntity(ctx context.Context, postParam *pa
 		Summary:         postParam.Summary,
 		FormatContent:   postParam.Content,
 	}
+	if postParam.MetaDescription != nil {
+		post.MetaDescription = *postParam.MetaDescription
+	}
+	if postParam.MetaKeywords != nil {
+		post.MetaKeywords = *postParam.MetaKeywords
+	}
+	if postType == consts.PostTypeSheet && postParam.ParentID != nil {
+		post.ParentID = *postParam.ParentID
+	}
 	if postParam.EditorType != nil {
 		post.EditorType = *postParam.EditorType
 	} else {
 		post.EditorType = consts.EditorTypeMarkdown
 	}
+	// markdown posts are rendered on the server so every client gets the same html
+	if post.EditorType == consts.EditorTypeMarkdown && post.OriginalContent != "" {
+		formatContent, err := b.MarkdownService.Render(ctx, post.OriginalContent)
+		if err != nil {
+			return nil, err
+		}
+		post.FormatContent = formatContent
+	}
+	post.FormatContent, post.Toc = buildToc(post.FormatContent)
 	post.WordCount = utils.HTMLFormatWordCount(post.FormatContent)
 	if postParam.Slug == "" {
 		post.Slug = utils.Slug(postParam.Title)
@@ -418,15 +628,110 @@ func (b *basePostServiceImpl) ConvertToEntity(ctx context.Context, postParam *pa
 		post.Slug = utils.Slug(postParam.Slug)
 	}
 
+	if postParam.Password != nil && *postParam.Password != "" {
+		password, err := encryptAccessPassword(*postParam.Password)
+		if err != nil {
+			return nil, err
+		}
+		post.Password = password
+	}
+
+	now := time.Now()
+	// 0 is what the dto returns for an unscheduled post, so it is treated like nil
+	if postParam.PublishTime != nil && *postParam.PublishTime > 0 {
+		publishTime := time.UnixMilli(*postParam.PublishTime)
+		if !publishTime.After(now) {
+			return nil, xerr.BadParam.New("").WithMsg("publish time must be in the future").WithStatus(xerr.StatusBadRequest)
+		}
+		// a post scheduled in the future stays a draft until the scheduler publishes it
+		post.PublishTime = &publishTime
+		post.Status = consts.PostStatusDraft
+	}
+	if postParam.ExpireTime != nil && *postParam.ExpireTime > 0 {
+		expireTime := time.UnixMilli(*postParam.ExpireTime)
+		if !expireTime.After(now) {
+			return nil, xerr.BadParam.New("").WithMsg("expire time must be in the future").WithStatus(xerr.StatusBadRequest)
+		}
+		if post.PublishTime != nil && !expireTime.After(*post.PublishTime) {
+			return nil, xerr.BadParam.New("").WithMsg("expire time must be later than publish time").WithStatus(xerr.StatusBadRequest)
+		}
+		post.ExpireTime = &expireTime
+	}
+
 	return post, nil
 }
 
+func (b *basePostServiceImpl) RenderAll(ctx context.Context) (int64, error) {
+	postDAL := dal.GetQueryByCtx(ctx).Post
+	var count int64
+	var lastID int32
+	for {
+		posts, err := postDAL.WithContext(ctx).
+			Where(postDAL.ID.Gt(lastID), postDAL.EditorType.Eq(consts.EditorTypeMarkdown)).
+			Order(postDAL.ID).
+			Limit(100).
+			Find()
+		if err != nil {
+			return count, WrapDBErr(err)
+		}
+		if len(posts) == 0 {
+			return count, nil
+		}
+		for _, post := range posts {
+			lastID = post.ID
+			if post.OriginalContent == "" {
+				continue
+			}
+			formatContent, err := b.MarkdownService.Render(ctx, post.OriginalContent)
+			if err != nil {
+				return count, err
+			}
+			formatContent, toc := buildToc(formatContent)
+			if formatContent == post.FormatContent && toc == post.Toc {
+				continue
+			}
+			_, err = postDAL.WithContext(ctx).Where(postDAL.ID.Eq(post.ID)).UpdateColumnSimple(
+				postDAL.FormatContent.Value(formatContent),
+				postDAL.Toc.Value(toc),
+				postDAL.WordCount.Value(utils.HTMLFormatWordCount(formatContent)),
+			)
+			if err != nil {
+				return count, WrapDBErr(err)
+			}
+			post.FormatContent = formatContent
+			err = b.SearchService.IndexPost(ctx, post)
+			if err != nil {
+				return count, err
+			}
+			count++
+		}
+	}
+}
+
+func (b *basePostServiceImpl) UnlockPost(ctx context.Context, post *entity.Post, password string, clientIP string) (*dto.UnlockToken, error) {
+	return issueUnlockToken(ctx, b.OneTimeTokenService, post.Password, password, consts.PostUnlockTokenPrefix+strconv.Itoa(int(post.ID)), clientIP)
+}
+
+func (b *basePostServiceImpl) IsPostUnlocked(ctx context.Context, post *entity.Post, unlockTokens []string) bool {
+	if post.Password == "" {
+		return true
+	}
+	return hasUnlockToken(b.OneTimeTokenService, unlockTokens, consts.PostUnlockTokenPrefix+strconv.Itoa(int(post.ID)))
+}
+
+// buildToc 为标题补全锚点 id，并返回补全后的内容与 JSON 格式的目录
+func buildToc(formatContent string) (string, string) {
+	formatContent, toc := utils.BuildToc(formatContent)
+	tocBytes, _ := json.Marshal(toc)
+	return formatContent, string(tocBytes)
+}
+
 var summaryPattern = regexp.MustCompile(`[\t\r\n]`)
 
-func (b *basePostServiceImpl) generateSummary(ctx context.Context, htmlContent string) string {
+func generateSummary(ctx context.Context, optionService service.OptionService, htmlContent string) string {
 	text := utils.CleanHTMLTag(htmlContent)
 	text = summaryPattern.ReplaceAllString(text, "")
-	summaryLength := b.OptionService.GetPostSummaryLength(ctx)
+	summaryLength := optionService.GetPostSummaryLength(ctx)
 	end := summaryLength
 	textRune := []rune(text)
 	if len(textRune) < end {
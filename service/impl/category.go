@@ -2,6 +2,7 @@ package impl
 
 import (
 	"context"
+	"dash/consts"
 	"dash/dal"
 	"dash/model/dto"
 	"dash/model/entity"
@@ -10,6 +11,7 @@ import (
 	"dash/service"
 	"dash/utils"
 	"dash/utils/xerr"
+	"strconv"
 	"strings"
 	"time"
 
@@ -17,12 +19,16 @@ import (
 )
 
 type categoryServiceImpl struct {
-	OptionService service.OptionService
+	OptionService       service.OptionService
+	OneTimeTokenService service.OneTimeTokenService
+	SlugHistoryService  service.SlugHistoryService
 }
 
-func NewCategoryService(optionService service.OptionService) service.CategoryService {
+func NewCategoryService(optionService service.OptionService, oneTimeTokenService service.OneTimeTokenService, slugHistoryService service.SlugHistoryService) service.CategoryService {
 	return &categoryServiceImpl{
-		OptionService: optionService,
+		OptionService:       optionService,
+		OneTimeTokenService: oneTimeTokenService,
+		SlugHistoryService:  slugHistoryService,
 	}
 }
 
@@ -57,10 +63,20 @@ func (c *categoryServiceImpl) Create(ctx context.Context, categoryParam *param.C
 		Thumbnail:   categoryParam.Thumbnail,
 		Priority:    categoryParam.Priority,
 	}
+	if categoryParam.Type != nil {
+		category.Type = *categoryParam.Type
+	}
+	if categoryParam.Password != nil && *categoryParam.Password != "" {
+		category.Password, err = encryptAccessPassword(*categoryParam.Password)
+		if err != nil {
+			return nil, err
+		}
+	}
 	err = categoryDAL.WithContext(ctx).Create(category)
 	if err != nil {
 		return nil, WrapDBErr(err)
 	}
+	invalidateContentCache(ctx)
 	return category, nil
 }
 
@@ -77,9 +93,13 @@ func (c *categoryServiceImpl) DeleteByID(ctx context.Context, id int32) error {
 		if err != nil {
 			return WrapDBErr(err)
 		}
-		return nil
+		return c.SlugHistoryService.DeleteByTargetID(txCtx, consts.SlugTypeCategory, id)
 	})
-	return err
+	if err != nil {
+		return err
+	}
+	invalidateContentCache(ctx)
+	return nil
 }
 
 func (c *categoryServiceImpl) UpdateByID(ctx context.Context, id int32, categoryParam *param.Category) (*entity.Category, error) {
@@ -91,7 +111,7 @@ func (c *categoryServiceImpl) UpdateByID(ctx context.Context, id int32, category
 
 	categoryDAL := dal.GetQueryByCtx(ctx).Category
 	// determine if category exist
-	_, err := categoryDAL.WithContext(ctx).Where(categoryDAL.ID.Eq(id)).First()
+	oldCategory, err := categoryDAL.WithContext(ctx).Where(categoryDAL.ID.Eq(id)).First()
 	if err != nil {
 		return nil, WrapDBErr(err)
 	}
@@ -114,20 +134,42 @@ func (c *categoryServiceImpl) UpdateByID(ctx context.Context, id int32, category
 	}
 
 	// update record
-	updateResult, err := categoryDAL.WithContext(ctx).Where(categoryDAL.ID.Eq(id)).UpdateSimple(
+	assigns := []field.AssignExpr{
 		categoryDAL.UpdateTime.Value(time.Now()),
 		categoryDAL.Name.Value(categoryParam.Name),
 		categoryDAL.Slug.Value(categoryParam.Slug),
 		categoryDAL.Description.Value(categoryParam.Description),
 		categoryDAL.Thumbnail.Value(categoryParam.Thumbnail),
 		categoryDAL.Priority.Value(categoryParam.Priority),
-	)
-	if err != nil {
-		return nil, WrapDBErr(err)
 	}
-	if updateResult.RowsAffected != 1 {
-		return nil, xerr.NoType.New("update category failed id=%v", id).WithStatus(xerr.StatusInternalServerError).WithMsg("update tag failed")
+	if categoryParam.Type != nil {
+		assigns = append(assigns, categoryDAL.Type.Value(*categoryParam.Type))
+	}
+	if categoryParam.Password != nil {
+		password := ""
+		if *categoryParam.Password != "" {
+			password, err = encryptAccessPassword(*categoryParam.Password)
+			if err != nil {
+				return nil, err
+			}
+		}
+		assigns = append(assigns, categoryDAL.Password.Value(password))
+	}
+	err = dal.Transaction(ctx, func(txCtx context.Context) error {
+		categoryDAL := dal.GetQueryByCtx(txCtx).Category
+		updateResult, err := categoryDAL.WithContext(txCtx).Where(categoryDAL.ID.Eq(id)).UpdateSimple(assigns...)
+		if err != nil {
+			return WrapDBErr(err)
+		}
+		if updateResult.RowsAffected != 1 {
+			return xerr.NoType.New("update category failed id=%v", id).WithStatus(xerr.StatusInternalServerError).WithMsg("update tag failed")
+		}
+		return c.SlugHistoryService.Record(txCtx, consts.SlugTypeCategory, id, oldCategory.Slug, categoryParam.Slug)
+	})
+	if err != nil {
+		return nil, err
 	}
+	invalidateContentCache(ctx)
 	category, err := categoryDAL.WithContext(ctx).Where(categoryDAL.ID.Value(id)).First()
 	if err != nil {
 		return nil, WrapDBErr(err)
@@ -191,6 +233,38 @@ func (c categoryServiceImpl) GetCategoriesCount(ctx context.Context) (int64, err
 	return count, nil
 }
 
+func (c *categoryServiceImpl) UnlockCategory(ctx context.Context, category *entity.Category, password string, clientIP string) (*dto.UnlockToken, error) {
+	return issueUnlockToken(ctx, c.OneTimeTokenService, category.Password, password, consts.CategoryUnlockTokenPrefix+strconv.Itoa(int(category.ID)), clientIP)
+}
+
+func (c *categoryServiceImpl) ListHiddenCategoryIDs(ctx context.Context, unlockTokens []string) ([]int32, error) {
+	categoryDAL := dal.GetQueryByCtx(ctx).Category
+	categories, err := categoryDAL.WithContext(ctx).
+		Where(field.Or(categoryDAL.Type.Eq(consts.CategoryTypeIntimate), categoryDAL.Password.Neq(""))).
+		Find()
+	if err != nil {
+		return nil, WrapDBErr(err)
+	}
+	hiddenIDs := make([]int32, 0, len(categories))
+	for _, category := range categories {
+		if !c.IsCategoryUnlocked(ctx, category, unlockTokens) {
+			hiddenIDs = append(hiddenIDs, category.ID)
+		}
+	}
+	return hiddenIDs, nil
+}
+
+func (c *categoryServiceImpl) IsCategoryUnlocked(ctx context.Context, category *entity.Category, unlockTokens []string) bool {
+	// a private category can not be unlocked, a password only grants access to protected ones
+	if category.Type == consts.CategoryTypeIntimate {
+		return false
+	}
+	if category.Password == "" {
+		return true
+	}
+	return hasUnlockToken(c.OneTimeTokenService, unlockTokens, consts.CategoryUnlockTokenPrefix+strconv.Itoa(int(category.ID)))
+}
+
 func (c *categoryServiceImpl) ConvertToCategoryDTO(ctx context.Context, category *entity.Category) (*dto.Category, error) {
 	categoryDTO := &dto.Category{
 		ID:          category.ID,
@@ -200,6 +274,8 @@ func (c *categoryServiceImpl) ConvertToCategoryDTO(ctx context.Context, category
 		Thumbnail:   category.Thumbnail,
 		CreateTime:  category.CreateTime.UnixMilli(),
 		Priority:    category.Priority,
+		Type:        category.Type,
+		HasPassword: category.Password != "",
 	}
 
 	fullPath := strings.Builder{}
@@ -235,6 +311,8 @@ func (c *categoryServiceImpl) ConvertToCategoryDTOs(ctx context.Context, categor
 		categoryDTO.Description = category.Description
 		categoryDTO.Slug = category.Slug
 		categoryDTO.Priority = category.Priority
+		categoryDTO.Type = category.Type
+		categoryDTO.HasPassword = category.Password != ""
 
 		fullPath := strings.Builder{}
 		fullPath.WriteString("/")
@@ -1,17 +1,87 @@
 package impl
 
 import (
+	"context"
+	"dash/cache"
+	"dash/consts"
+	"dash/model/dto"
 	"dash/model/param"
+	"dash/service"
 	"dash/utils/xerr"
 	"errors"
 	"reflect"
 	"strings"
+	"time"
 	"unicode"
 
+	"github.com/redis/go-redis/v9"
+	"golang.org/x/crypto/bcrypt"
 	"gorm.io/gen/field"
 	"gorm.io/gorm"
 )
 
+const (
+	// 文章、分类解锁令牌的有效期
+	unlockTokenExpiration = 30 * time.Minute
+	// 同一客户端对同一文章或分类在时间窗口内最多输错的次数，超过后拒绝校验
+	unlockMaxFailures   = 5
+	unlockFailureWindow = 10 * time.Minute
+)
+
+// invalidateContentCache 文章、页面、分类、标签或设置变化后调用，使站点地图等按内容生成的缓存失效
+func invalidateContentCache(ctx context.Context) {
+	_ = cache.Redis.Incr(ctx, consts.ContentVersionKey).Err()
+}
+
+// encryptAccessPassword 加密文章、分类的访问密码
+func encryptAccessPassword(plainPassword string) (string, error) {
+	password, err := bcrypt.GenerateFromPassword([]byte(plainPassword), bcrypt.DefaultCost)
+	if err != nil {
+		return "", xerr.NoType.Wrap(err).WithMsg("encrypt password failed")
+	}
+	return string(password), nil
+}
+
+// issueUnlockToken 校验访问密码，通过后签发值为 value 的解锁令牌；同一客户端输错次数过多时返回 429，防止暴力破解
+func issueUnlockToken(ctx context.Context, oneTimeTokenService service.OneTimeTokenService, hashedPassword, plainPassword, value string, clientIP string) (*dto.UnlockToken, error) {
+	if hashedPassword == "" {
+		return nil, xerr.BadParam.New("").WithMsg("not password protected").WithStatus(xerr.StatusBadRequest)
+	}
+	failureKey := cache.BuildUnlockFailureKey(value, clientIP)
+	failures, err := cache.Redis.Get(ctx, failureKey).Int64()
+	if err != nil && !errors.Is(err, redis.Nil) {
+		return nil, xerr.NoType.Wrap(err).WithMsg("unlock failed")
+	}
+	if failures >= unlockMaxFailures {
+		return nil, xerr.Forbidden.New("client=%v exceeded unlock attempts of %v", clientIP, value).WithMsg("too many wrong passwords, please try again later").WithStatus(xerr.StatusTooManyRequests)
+	}
+	if bcrypt.CompareHashAndPassword([]byte(hashedPassword), []byte(plainPassword)) != nil {
+		failures, err = cache.Redis.Incr(ctx, failureKey).Result()
+		if err == nil && failures == 1 {
+			err = cache.Redis.Expire(ctx, failureKey, unlockFailureWindow).Err()
+		}
+		if err != nil {
+			return nil, xerr.NoType.Wrap(err).WithMsg("unlock failed")
+		}
+		return nil, xerr.Forbidden.New("").WithMsg("wrong password").WithStatus(xerr.StatusForbidden)
+	}
+	cache.Redis.Del(ctx, failureKey)
+	return &dto.UnlockToken{
+		Token:      oneTimeTokenService.CreateWithExpiration(value, unlockTokenExpiration),
+		ExpireTime: time.Now().Add(unlockTokenExpiration).UnixMilli(),
+	}, nil
+}
+
+// hasUnlockToken 判断 unlockTokens 中是否有值为 value 的有效令牌
+func hasUnlockToken(oneTimeTokenService service.OneTimeTokenService, unlockTokens []string, value string) bool {
+	for _, token := range unlockTokens {
+		if v, ok := oneTimeTokenService.Get(token); ok && v == value {
+			return true
+		}
+	}
+	return false
+}
+
 func WrapDBErr(err error) error {
 	if err == nil {
 		return nil
@@ -0,0 +1,302 @@
+package impl
+
+import (
+	"context"
+	"dash/config"
+	"dash/consts"
+	"dash/dal"
+	"dash/model/dto"
+	"dash/model/entity"
+	"dash/service"
+	"dash/utils"
+	"dash/utils/xerr"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	markdownExportPrefix = "dash-markdown-"
+	// 导出文件保留一天，每次导出时清理过期的文件
+	exportFileRetention = 24 * time.Hour
+)
+
+type exportServiceImpl struct {
+	Config *config.Config
+}
+
+func NewExportService(conf *config.Config) service.ExportService {
+	return &exportServiceImpl{
+		Config: conf,
+	}
+}
+
+// markdownFrontMatter 导出的 front matter，字段名和 Hexo/Hugo 保持一致，可以直接被导入功能读取
+type markdownFrontMatter struct {
+	Title      string            `yaml:"title"`
+	Slug       string            `yaml:"slug"`
+	Status     string            `yaml:"status"`
+	Draft      bool              `yaml:"draft,omitempty"`
+	Date       time.Time         `yaml:"date"`
+	Updated    *time.Time        `yaml:"updated,omitempty"`
+	Tags       []string          `yaml:"tags"`
+	Categories []string          `yaml:"categories"`
+	Summary    string            `yaml:"description,omitempty"`
+	Thumbnail  string            `yaml:"thumbnail,omitempty"`
+	Metas      map[string]string `yaml:"metas,omitempty"`
+}
+
+func (e *exportServiceImpl) exportDir() string {
+	return filepath.Join(e.Config.Dash.WorkDir, consts.DashExportDir)
+}
+
+func (e *exportServiceImpl) ExportMarkdown(ctx context.Context) (*dto.ExportFile, error) {
+	exportDir := e.exportDir()
+	err := utils.MakeDir(exportDir)
+	if err != nil {
+		return nil, xerr.NoType.Wrap(err).WithMsg("create export dir failed")
+	}
+	e.cleanExpiredFiles(exportDir)
+
+	now := time.Now()
+	name := markdownExportPrefix + now.Format("20060102150405") + "-" + utils.GenUUIDWithOutDash()[:8]
+	tempDir, err := os.MkdirTemp("", "dash-export-*")
+	if err != nil {
+		return nil, xerr.NoType.Wrap(err).WithMsg("create temp dir failed")
+	}
+	defer os.RemoveAll(tempDir)
+	contentDir := filepath.Join(tempDir, name)
+
+	err = e.writePosts(ctx, contentDir)
+	if err != nil {
+		return nil, err
+	}
+	err = e.writeJSONFiles(ctx, contentDir)
+	if err != nil {
+		return nil, err
+	}
+
+	filename := name + ".zip"
+	zipPath := filepath.Join(exportDir, filename)
+	err = utils.ZipFile(zipPath, contentDir)
+	if err != nil {
+		os.Remove(zipPath)
+		return nil, err
+	}
+	info, err := os.Stat(zipPath)
+	if err != nil {
+		return nil, xerr.NoType.Wrap(err).WithMsg("export failed")
+	}
+	return &dto.ExportFile{
+		Filename:   filename,
+		Size:       info.Size(),
+		CreateTime: now.UnixMilli(),
+	}, nil
+}
+
+func (e *exportServiceImpl) GetExportFilePath(ctx context.Context, filename string) (string, error) {
+	// 只允许下载导出目录下的导出文件
+	if filename != filepath.Base(filename) || !strings.HasPrefix(filename, markdownExportPrefix) || filepath.Ext(filename) != ".zip" {
+		return "", xerr.BadParam.New("invalid export filename %s", filename).WithMsg("invalid filename").WithStatus(xerr.StatusBadRequest)
+	}
+	path := filepath.Join(e.exportDir(), filename)
+	_, err := os.Stat(path)
+	if os.IsNotExist(err) {
+		return "", xerr.NoRecord.Wrap(err).WithMsg("export file not found").WithStatus(xerr.StatusNotFound)
+	}
+	if err != nil {
+		return "", xerr.NoType.Wrap(err).WithMsg("read export file failed")
+	}
+	return path, nil
+}
+
+func (e *exportServiceImpl) cleanExpiredFiles(exportDir string) {
+	entries, err := os.ReadDir(exportDir)
+	if err != nil {
+		return
+	}
+	for _, entry := range entries {
+		if entry.IsDir() || !strings.HasPrefix(entry.Name(), markdownExportPrefix) {
+			continue
+		}
+		info, err := entry.Info()
+		if err == nil && time.Since(info.ModTime()) > exportFileRetention {
+			os.Remove(filepath.Join(exportDir, entry.Name()))
+		}
+	}
+}
+
+// writePosts 文章写入 posts 目录，页面写入 sheets 目录，文件名为 slug
+func (e *exportServiceImpl) writePosts(ctx context.Context, contentDir string) error {
+	query := dal.GetQueryByCtx(ctx)
+	for _, dir := range []string{"posts", "sheets"} {
+		err := utils.MakeDir(filepath.Join(contentDir, dir))
+		if err != nil {
+			return xerr.NoType.Wrap(err).WithMsg("create export dir failed")
+		}
+	}
+
+	tags, err := query.Tag.WithContext(ctx).Find()
+	if err != nil {
+		return WrapDBErr(err)
+	}
+	tagNames := make(map[int32]string, len(tags))
+	for _, tag := range tags {
+		tagNames[tag.ID] = tag.Name
+	}
+	categories, err := query.Category.WithContext(ctx).Find()
+	if err != nil {
+		return WrapDBErr(err)
+	}
+	categoryNames := make(map[int32]string, len(categories))
+	for _, category := range categories {
+		categoryNames[category.ID] = category.Name
+	}
+
+	postDAL := query.Post
+	var lastID int32
+	for {
+		posts, err := postDAL.WithContext(ctx).Where(postDAL.ID.Gt(lastID)).Order(postDAL.ID).Limit(200).Find()
+		if err != nil {
+			return WrapDBErr(err)
+		}
+		if len(posts) == 0 {
+			return nil
+		}
+		lastID = posts[len(posts)-1].ID
+		postIDs := make([]int32, 0, len(posts))
+		for _, post := range posts {
+			postIDs = append(postIDs, post.ID)
+		}
+
+		postTags, err := query.PostTag.WithContext(ctx).Where(query.PostTag.PostID.In(postIDs...)).Order(query.PostTag.ID).Find()
+		if err != nil {
+			return WrapDBErr(err)
+		}
+		postTagNames := make(map[int32][]string)
+		for _, postTag := range postTags {
+			if name, ok := tagNames[postTag.TagID]; ok {
+				postTagNames[postTag.PostID] = append(postTagNames[postTag.PostID], name)
+			}
+		}
+		postCategories, err := query.PostCategory.WithContext(ctx).Where(query.PostCategory.PostID.In(postIDs...)).Order(query.PostCategory.ID).Find()
+		if err != nil {
+			return WrapDBErr(err)
+		}
+		postCategoryNames := make(map[int32][]string)
+		for _, postCategory := range postCategories {
+			if name, ok := categoryNames[postCategory.CategoryID]; ok {
+				postCategoryNames[postCategory.PostID] = append(postCategoryNames[postCategory.PostID], name)
+			}
+		}
+		metas, err := query.PostMeta.WithContext(ctx).Where(query.PostMeta.PostID.In(postIDs...)).Find()
+		if err != nil {
+			return WrapDBErr(err)
+		}
+		postMetas := make(map[int32]map[string]string)
+		for _, meta := range metas {
+			if postMetas[meta.PostID] == nil {
+				postMetas[meta.PostID] = make(map[string]string)
+			}
+			postMetas[meta.PostID][meta.MetaKey] = meta.MetaValue
+		}
+
+		for _, post := range posts {
+			err = writeMarkdownFile(contentDir, post, postTagNames[post.ID], postCategoryNames[post.ID], postMetas[post.ID])
+			if err != nil {
+				return err
+			}
+		}
+	}
+}
+
+func writeMarkdownFile(contentDir string, post *entity.Post, tags []string, categories []string, metas map[string]string) error {
+	status, _ := post.Status.MarshalJSON()
+	frontMatter := &markdownFrontMatter{
+		Title:      post.Title,
+		Slug:       post.Slug,
+		Status:     strings.Trim(string(status), `"`),
+		Draft:      post.Status != consts.PostStatusPublished,
+		Date:       post.CreateTime,
+		Updated:    post.UpdateTime,
+		Tags:       tags,
+		Categories: categories,
+		Summary:    post.Summary,
+		Thumbnail:  post.Thumbnail,
+		Metas:      metas,
+	}
+	if post.EditTime != nil {
+		frontMatter.Updated = post.EditTime
+	}
+	if frontMatter.Tags == nil {
+		frontMatter.Tags = []string{}
+	}
+	if frontMatter.Categories == nil {
+		frontMatter.Categories = []string{}
+	}
+	matter, err := yaml.Marshal(frontMatter)
+	if err != nil {
+		return xerr.NoType.Wrap(err).WithMsg("export post failed")
+	}
+	content := "---\n" + string(matter) + "---\n\n" + post.OriginalContent
+	if post.OriginalContent == "" {
+		// 富文本编辑器的文章没有 Markdown 原文，导出 HTML
+		content += post.FormatContent
+	}
+
+	dir := "posts"
+	if post.Type == consts.PostTypeSheet {
+		dir = "sheets"
+	}
+	name := post.Slug
+	if name == "" || name != filepath.Base(name) {
+		name = strconv.Itoa(int(post.ID))
+	}
+	err = os.WriteFile(filepath.Join(contentDir, dir, name+".md"), []byte(content), 0o644)
+	if err != nil {
+		return xerr.NoType.Wrap(err).WithMsg("export post failed")
+	}
+	return nil
+}
+
+func (e *exportServiceImpl) writeJSONFiles(ctx context.Context, contentDir string) error {
+	query := dal.GetQueryByCtx(ctx)
+	categories, err := query.Category.WithContext(ctx).Order(query.Category.ID).Find()
+	if err != nil {
+		return WrapDBErr(err)
+	}
+	tags, err := query.Tag.WithContext(ctx).Order(query.Tag.ID).Find()
+	if err != nil {
+		return WrapDBErr(err)
+	}
+	menus, err := query.Menu.WithContext(ctx).Order(query.Menu.ID).Find()
+	if err != nil {
+		return WrapDBErr(err)
+	}
+	options, err := query.Option.WithContext(ctx).Order(query.Option.ID).Find()
+	if err != nil {
+		return WrapDBErr(err)
+	}
+	files := map[string]interface{}{
+		"categories.json": categories,
+		"tags.json":       tags,
+		"menus.json":      menus,
+		"options.json":    options,
+	}
+	for name, data := range files {
+		content, err := json.MarshalIndent(data, "", "  ")
+		if err != nil {
+			return xerr.NoType.Wrap(err).WithMsg("export " + name + " failed")
+		}
+		err = os.WriteFile(filepath.Join(contentDir, name), content, 0o644)
+		if err != nil {
+			return xerr.NoType.Wrap(err).WithMsg("export " + name + " failed")
+		}
+	}
+	return nil
+}
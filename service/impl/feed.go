@@ -0,0 +1,421 @@
+package impl
+
+import (
+	"context"
+	"dash/consts"
+	"dash/dal"
+	"dash/model/dto"
+	"dash/model/property"
+	"dash/service"
+	"dash/utils/xerr"
+	"encoding/json"
+	"encoding/xml"
+	"html"
+	"regexp"
+	"strings"
+	"time"
+
+	"gorm.io/gen"
+)
+
+const feedGenerator = "Dash"
+
+// feedRelativeURLRegexp 正文中以 / 开头的站内链接和图片，订阅阅读器不在博客域名下打开，需要转换成完整 URL
+var feedRelativeURLRegexp = regexp.MustCompile(`(?i)(\s(?:src|href|poster)\s*=\s*["'])/([^/])`)
+
+type feedServiceImpl struct {
+	OptionService       service.OptionService
+	PermalinkService    service.PermalinkService
+	CategoryService     service.CategoryService
+	TagService          service.TagService
+	PostCategoryService service.PostCategoryService
+	PostTagService      service.PostTagService
+	UserService         service.UserService
+}
+
+func NewFeedService(
+	optionService service.OptionService,
+	permalinkService service.PermalinkService,
+	categoryService service.CategoryService,
+	tagService service.TagService,
+	postCategoryService service.PostCategoryService,
+	postTagService service.PostTagService,
+	userService service.UserService,
+) service.FeedService {
+	return &feedServiceImpl{
+		OptionService:       optionService,
+		PermalinkService:    permalinkService,
+		CategoryService:     categoryService,
+		TagService:          tagService,
+		PostCategoryService: postCategoryService,
+		PostTagService:      postTagService,
+		UserService:         userService,
+	}
+}
+
+// feedChannel 三种格式共用的订阅信息，homePath 为订阅对应的网页，feedPath 为订阅自身
+type feedChannel struct {
+	title       string
+	description string
+	homePath    string
+	feedPath    string
+	author      string
+	blogURL     string
+	updated     time.Time
+	items       []*feedItem
+}
+
+type feedItem struct {
+	title      string
+	url        string
+	summary    string
+	content    string // 输出全文时为正文 html，否则为空
+	image      string
+	published  time.Time
+	updated    time.Time
+	categories []string
+}
+
+// feedPath 订阅地址，和路由保持一致：/feed.xml、/atom.xml、/feed.json，分类和标签为 /feed/categories/{slug}/feed.xml 等
+func feedPath(format consts.FeedFormat, scope string) string {
+	filename := "feed.xml"
+	switch format {
+	case consts.FeedFormatAtom:
+		filename = "atom.xml"
+	case consts.FeedFormatJSON:
+		filename = "feed.json"
+	}
+	if scope == "" {
+		return "/" + filename
+	}
+	return "/feed/" + scope + "/" + filename
+}
+
+func (f *feedServiceImpl) GetFeed(ctx context.Context, format consts.FeedFormat) (*dto.RawContent, error) {
+	channel, err := f.newChannel(ctx, "", "/", feedPath(format, ""))
+	if err != nil {
+		return nil, err
+	}
+	err = f.fillItems(ctx, channel, nil, nil)
+	if err != nil {
+		return nil, err
+	}
+	return f.render(format, channel)
+}
+
+func (f *feedServiceImpl) GetCategoryFeed(ctx context.Context, format consts.FeedFormat, slug string) (*dto.RawContent, error) {
+	category, err := f.CategoryService.GetCategoryBySlug(ctx, slug)
+	if err != nil {
+		return nil, err
+	}
+	hiddenCategoryIDs, err := f.CategoryService.ListHiddenCategoryIDs(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	for _, id := range hiddenCategoryIDs {
+		if id == category.ID {
+			return nil, xerr.NoRecord.New("category slug=%v is hidden", slug).WithMsg("The resource does not exist or has been deleted").WithStatus(xerr.StatusNotFound)
+		}
+	}
+	categoryPrefix, err := f.OptionService.GetOrByDefaultWithErr(ctx, property.CategoriesPrefix, property.CategoriesPrefix.DefaultValue)
+	if err != nil {
+		return nil, err
+	}
+	channel, err := f.newChannel(ctx, category.Name, "/"+categoryPrefix.(string)+"/"+category.Slug, feedPath(format, "categories/"+category.Slug))
+	if err != nil {
+		return nil, err
+	}
+	if category.Description != "" {
+		channel.description = category.Description
+	}
+	err = f.fillItems(ctx, channel, &category.ID, nil)
+	if err != nil {
+		return nil, err
+	}
+	return f.render(format, channel)
+}
+
+func (f *feedServiceImpl) GetTagFeed(ctx context.Context, format consts.FeedFormat, slug string) (*dto.RawContent, error) {
+	tag, err := f.TagService.GetTagBySlug(ctx, slug)
+	if err != nil {
+		return nil, err
+	}
+	tagPrefix, err := f.OptionService.GetOrByDefaultWithErr(ctx, property.TagsPrefix, property.TagsPrefix.DefaultValue)
+	if err != nil {
+		return nil, err
+	}
+	channel, err := f.newChannel(ctx, tag.Name, "/"+tagPrefix.(string)+"/"+tag.Slug, feedPath(format, "tags/"+tag.Slug))
+	if err != nil {
+		return nil, err
+	}
+	err = f.fillItems(ctx, channel, nil, &tag.ID)
+	if err != nil {
+		return nil, err
+	}
+	return f.render(format, channel)
+}
+
+func (f *feedServiceImpl) newChannel(ctx context.Context, name, homePath, selfPath string) (*feedChannel, error) {
+	blogURL, err := f.OptionService.GetBlogBaseURL(ctx)
+	if err != nil {
+		return nil, err
+	}
+	blogTitle, err := f.OptionService.GetOrByDefaultWithErr(ctx, property.BlogTitle, property.BlogTitle.DefaultValue)
+	if err != nil {
+		return nil, err
+	}
+	description, err := f.OptionService.GetOrByDefaultWithErr(ctx, property.SeoDescription, property.SeoDescription.DefaultValue)
+	if err != nil {
+		return nil, err
+	}
+	birthday, err := f.OptionService.GetOrByDefaultWithErr(ctx, property.BirthDay, property.BirthDay.DefaultValue)
+	if err != nil {
+		return nil, err
+	}
+	channel := &feedChannel{
+		title:       blogTitle.(string),
+		description: description.(string),
+		homePath:    homePath,
+		feedPath:    selfPath,
+		blogURL:     strings.TrimRight(blogURL, "/"),
+		// 没有文章时用博客的创建时间，保证内容不变时订阅也不变
+		updated: time.UnixMilli(birthday.(int64)),
+	}
+	if name != "" {
+		channel.title = name + " - " + channel.title
+	}
+	if channel.description == "" {
+		channel.description = channel.title
+	}
+	user, err := f.UserService.GetFirst(ctx)
+	if err != nil && xerr.GetType(err) != xerr.NoRecord {
+		return nil, err
+	}
+	if user != nil {
+		channel.author = user.Nickname
+	}
+	return channel, nil
+}
+
+// fillItems 按创建时间倒序取 feed_size 篇已发布的文章，排除设置了密码和属于私密、加密分类的文章
+func (f *feedServiceImpl) fillItems(ctx context.Context, channel *feedChannel, categoryID, tagID *int32) error {
+	size := f.OptionService.GetOrByDefault(ctx, property.FeedSize).(int)
+	fullContent := f.OptionService.GetOrByDefault(ctx, property.FeedFullContent).(bool)
+	hiddenCategoryIDs, err := f.CategoryService.ListHiddenCategoryIDs(ctx, nil)
+	if err != nil {
+		return err
+	}
+
+	query := dal.GetQueryByCtx(ctx)
+	postDAL := query.Post
+	postCategoryDAL := query.PostCategory
+	postTagDAL := query.PostTag
+	conditions := []gen.Condition{
+		postDAL.Type.Eq(consts.PostTypePost),
+		postDAL.Status.Eq(consts.PostStatusPublished),
+		postDAL.Password.Eq(""),
+	}
+	if categoryID != nil {
+		conditions = append(conditions, postDAL.WithContext(ctx).Columns(postDAL.ID).In(
+			postCategoryDAL.WithContext(ctx).Where(postCategoryDAL.CategoryID.Eq(*categoryID)).Select(postCategoryDAL.PostID)))
+	}
+	if tagID != nil {
+		conditions = append(conditions, postDAL.WithContext(ctx).Columns(postDAL.ID).In(
+			postTagDAL.WithContext(ctx).Where(postTagDAL.TagID.Eq(*tagID)).Select(postTagDAL.PostID)))
+	}
+	if len(hiddenCategoryIDs) > 0 {
+		conditions = append(conditions, postDAL.WithContext(ctx).Columns(postDAL.ID).NotIn(
+			postCategoryDAL.WithContext(ctx).Where(postCategoryDAL.CategoryID.In(hiddenCategoryIDs...)).Select(postCategoryDAL.PostID)))
+	}
+	posts, err := postDAL.WithContext(ctx).Where(conditions...).Order(postDAL.CreateTime.Desc(), postDAL.ID.Desc()).Limit(size).Find()
+	if err != nil {
+		return WrapDBErr(err)
+	}
+
+	postIDs := make([]int32, 0, len(posts))
+	for _, post := range posts {
+		postIDs = append(postIDs, post.ID)
+	}
+	categoryMap, err := f.PostCategoryService.ListCategoryMapByPostID(ctx, postIDs)
+	if err != nil {
+		return err
+	}
+	tagMap, err := f.PostTagService.ListTagMapByPostID(ctx, postIDs)
+	if err != nil {
+		return err
+	}
+
+	channel.items = make([]*feedItem, 0, len(posts))
+	for i, post := range posts {
+		path, err := f.PermalinkService.BuildPath(ctx, post)
+		if err != nil {
+			return err
+		}
+		item := &feedItem{
+			title:      post.Title,
+			url:        channel.blogURL + path,
+			summary:    post.Summary,
+			published:  post.CreateTime,
+			updated:    postLastModified(post),
+			categories: make([]string, 0),
+		}
+		if fullContent {
+			item.content = feedRelativeURLRegexp.ReplaceAllString(post.FormatContent, "${1}"+channel.blogURL+"/${2}")
+		}
+		if post.Thumbnail != "" {
+			item.image = post.Thumbnail
+			if strings.HasPrefix(item.image, "/") && !strings.HasPrefix(item.image, "//") {
+				item.image = channel.blogURL + item.image
+			}
+		}
+		for _, category := range categoryMap[post.ID] {
+			item.categories = append(item.categories, category.Name)
+		}
+		for _, tag := range tagMap[post.ID] {
+			item.categories = append(item.categories, tag.Name)
+		}
+		if i == 0 || item.updated.After(channel.updated) {
+			channel.updated = item.updated
+		}
+		channel.items = append(channel.items, item)
+	}
+	return nil
+}
+
+func (f *feedServiceImpl) render(format consts.FeedFormat, channel *feedChannel) (*dto.RawContent, error) {
+	var (
+		content     []byte
+		contentType string
+		err         error
+	)
+	switch format {
+	case consts.FeedFormatAtom:
+		content, err = xml.Marshal(buildAtomFeed(channel))
+		content = append([]byte(xml.Header), content...)
+		contentType = "application/atom+xml; charset=utf-8"
+	case consts.FeedFormatJSON:
+		content, err = json.Marshal(buildJSONFeed(channel))
+		contentType = "application/feed+json; charset=utf-8"
+	default:
+		content, err = xml.Marshal(buildRSS(channel))
+		content = append([]byte(xml.Header), content...)
+		contentType = "application/rss+xml; charset=utf-8"
+	}
+	if err != nil {
+		return nil, xerr.NoType.Wrap(err).WithMsg("generate feed failed")
+	}
+	// 不输出 Last-Modified：删除、下线文章或修改站点信息不会让最新编辑时间前进，只按内容 ETag 做条件请求
+	return &dto.RawContent{
+		ContentType: contentType,
+		Data:        content,
+	}, nil
+}
+
+func buildRSS(channel *feedChannel) *dto.RSS {
+	rssChannel := &dto.RSSChannel{
+		Title:       channel.title,
+		Link:        channel.blogURL + channel.homePath,
+		Description: channel.description,
+		AtomLink: &dto.AtomLink{
+			Href: channel.blogURL + channel.feedPath,
+			Rel:  "self",
+			Type: "application/rss+xml",
+		},
+		Generator:     feedGenerator,
+		LastBuildDate: channel.updated.Format(time.RFC1123Z),
+		Items:         make([]*dto.RSSItem, 0, len(channel.items)),
+	}
+	for _, item := range channel.items {
+		// description 会被阅读器当作 html，摘要是纯文本，需要转义
+		description := html.EscapeString(item.summary)
+		if item.content != "" {
+			description = item.content
+		}
+		rssChannel.Items = append(rssChannel.Items, &dto.RSSItem{
+			Title:       item.title,
+			Link:        item.url,
+			GUID:        &dto.RSSGUID{IsPermaLink: "true", Value: item.url},
+			PubDate:     item.published.Format(time.RFC1123Z),
+			Description: description,
+			Categories:  item.categories,
+		})
+	}
+	return &dto.RSS{
+		Version: "2.0",
+		AtomNS:  "http://www.w3.org/2005/Atom",
+		Channel: rssChannel,
+	}
+}
+
+func buildAtomFeed(channel *feedChannel) *dto.AtomFeed {
+	atomFeed := &dto.AtomFeed{
+		Xmlns:    "http://www.w3.org/2005/Atom",
+		Title:    channel.title,
+		Subtitle: channel.description,
+		ID:       channel.blogURL + channel.feedPath,
+		Updated:  channel.updated.Format(time.RFC3339),
+		Links: []*dto.AtomLink{
+			{Href: channel.blogURL + channel.homePath, Rel: "alternate", Type: "text/html"},
+			{Href: channel.blogURL + channel.feedPath, Rel: "self", Type: "application/atom+xml"},
+		},
+		Generator: feedGenerator,
+		Entries:   make([]*dto.AtomEntry, 0, len(channel.items)),
+	}
+	if channel.author != "" {
+		atomFeed.Author = &dto.AtomAuthor{Name: channel.author}
+	}
+	for _, item := range channel.items {
+		entry := &dto.AtomEntry{
+			Title:      item.title,
+			ID:         item.url,
+			Links:      []*dto.AtomLink{{Href: item.url, Rel: "alternate", Type: "text/html"}},
+			Published:  item.published.Format(time.RFC3339),
+			Updated:    item.updated.Format(time.RFC3339),
+			Categories: make([]*dto.AtomCategory, 0, len(item.categories)),
+		}
+		if item.summary != "" {
+			entry.Summary = &dto.AtomText{Type: "text", Body: item.summary}
+		}
+		if item.content != "" {
+			entry.Content = &dto.AtomText{Type: "html", Body: item.content}
+		}
+		for _, category := range item.categories {
+			entry.Categories = append(entry.Categories, &dto.AtomCategory{Term: category})
+		}
+		atomFeed.Entries = append(atomFeed.Entries, entry)
+	}
+	return atomFeed
+}
+
+func buildJSONFeed(channel *feedChannel) *dto.JSONFeed {
+	jsonFeed := &dto.JSONFeed{
+		Version:     "https://jsonfeed.org/version/1.1",
+		Title:       channel.title,
+		HomePageURL: channel.blogURL + channel.homePath,
+		FeedURL:     channel.blogURL + channel.feedPath,
+		Description: channel.description,
+		Items:       make([]*dto.JSONFeedItem, 0, len(channel.items)),
+	}
+	if channel.author != "" {
+		jsonFeed.Authors = []*dto.JSONFeedAuthor{{Name: channel.author}}
+	}
+	for _, item := range channel.items {
+		jsonFeedItem := &dto.JSONFeedItem{
+			ID:            item.url,
+			URL:           item.url,
+			Title:         item.title,
+			Summary:       item.summary,
+			Image:         item.image,
+			DatePublished: item.published.Format(time.RFC3339),
+			DateModified:  item.updated.Format(time.RFC3339),
+			Tags:          item.categories,
+		}
+		if item.content != "" {
+			jsonFeedItem.ContentHTML = item.content
+		} else {
+			jsonFeedItem.ContentText = item.summary
+		}
+		jsonFeed.Items = append(jsonFeed.Items, jsonFeedItem)
+	}
+	return jsonFeed
+}
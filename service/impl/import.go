@@ -0,0 +1,471 @@
+package impl
+
+import (
+	"context"
+	"dash/consts"
+	"dash/dal"
+	"dash/model/dto"
+	"dash/model/entity"
+	"dash/model/param"
+	"dash/service"
+	"dash/utils"
+	"dash/utils/xerr"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+type importServiceImpl struct {
+	PostService      service.PostService
+	TagService       service.TagService
+	CategoryService  service.CategoryService
+	PermalinkService service.PermalinkService
+}
+
+func NewImportService(postService service.PostService, tagService service.TagService, categoryService service.CategoryService, permalinkService service.PermalinkService) service.ImportService {
+	return &importServiceImpl{
+		PostService:      postService,
+		TagService:       tagService,
+		CategoryService:  categoryService,
+		PermalinkService: permalinkService,
+	}
+}
+
+// importDocument 解析后的待导入文章，Err 不为空表示解析失败
+type importDocument struct {
+	File       string
+	PostType   consts.PostType
+	Post       *param.Post
+	Tags       []string
+	Categories []string
+	CreateTime *time.Time
+	UpdateTime *time.Time
+	OldURL     string
+	Err        error
+}
+
+// importState 一次导入中已解析的标签、分类和 slug，避免重复查询和同一批文章 slug 冲突
+type importState struct {
+	tagIDs      map[string]int32
+	categoryIDs map[string]int32
+	slugs       map[string]struct{}
+	// 新建标签和分类时使用的 slug，key 为小写的名称，没有时由名称生成
+	tagSlugs      map[string]string
+	categorySlugs map[string]string
+}
+
+func newImportState() *importState {
+	return &importState{
+		tagIDs:        make(map[string]int32),
+		categoryIDs:   make(map[string]int32),
+		slugs:         make(map[string]struct{}),
+		tagSlugs:      make(map[string]string),
+		categorySlugs: make(map[string]string),
+	}
+}
+
+func newImportResult(dryRun bool) *dto.ImportResult {
+	return &dto.ImportResult{
+		DryRun:            dryRun,
+		CreatedTags:       make([]string, 0),
+		CreatedCategories: make([]string, 0),
+		Items:             make([]*dto.ImportItem, 0),
+	}
+}
+
+// jekyllFileName Jekyll 的文章文件名为 yyyy-mm-dd-slug.md
+var jekyllFileName = regexp.MustCompile(`^(\d{4}-\d{2}-\d{2})-(.+)$`)
+
+func (i *importServiceImpl) ImportMarkdown(ctx context.Context, src string, dryRun bool) (*dto.ImportResult, error) {
+	info, err := os.Stat(src)
+	if err != nil {
+		return nil, xerr.BadParam.Wrap(err).WithMsg("import source not found").WithStatus(xerr.StatusBadRequest)
+	}
+	root := src
+	var files []string
+	switch {
+	case info.IsDir():
+	case strings.EqualFold(filepath.Ext(src), ".zip"):
+		tempDir, err := os.MkdirTemp("", "dash-import-*")
+		if err != nil {
+			return nil, xerr.NoType.Wrap(err).WithMsg("create temp dir failed")
+		}
+		defer os.RemoveAll(tempDir)
+		_, err = utils.Unzip(src, tempDir)
+		if err != nil {
+			return nil, xerr.BadParam.Wrap(err).WithMsg("unzip import file failed").WithStatus(xerr.StatusBadRequest)
+		}
+		root = tempDir
+	case isMarkdownFile(src):
+		root = filepath.Dir(src)
+		files = []string{src}
+	default:
+		return nil, xerr.BadParam.New("unsupported import file %s", src).WithMsg("only zip, directory or markdown file is supported").WithStatus(xerr.StatusBadRequest)
+	}
+	if files == nil {
+		files, err = listMarkdownFiles(root)
+		if err != nil {
+			return nil, xerr.NoType.Wrap(err).WithMsg("read import files failed")
+		}
+	}
+
+	documents := make([]*importDocument, 0, len(files))
+	for _, file := range files {
+		relPath, err := filepath.Rel(root, file)
+		if err != nil {
+			relPath = filepath.Base(file)
+		}
+		documents = append(documents, parseMarkdownDocument(filepath.ToSlash(relPath), file))
+	}
+	result := newImportResult(dryRun)
+	i.importDocuments(ctx, documents, newImportState(), result)
+	return result, nil
+}
+
+func isMarkdownFile(path string) bool {
+	ext := strings.ToLower(filepath.Ext(path))
+	return ext == ".md" || ext == ".markdown"
+}
+
+func listMarkdownFiles(root string) ([]string, error) {
+	files := make([]string, 0)
+	err := filepath.WalkDir(root, func(path string, d os.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		name := d.Name()
+		if d.IsDir() {
+			// 跳过 .git、node_modules 以及 zip 中的 __MACOSX 等目录
+			if path != root && (strings.HasPrefix(name, ".") || name == "node_modules" || name == "__MACOSX") {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		// Hugo 的 _index.md 是栏目页，不是文章
+		if isMarkdownFile(name) && !strings.HasPrefix(name, ".") && name != "_index.md" {
+			files = append(files, path)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	sort.Strings(files)
+	return files, nil
+}
+
+func parseMarkdownDocument(relPath string, file string) *importDocument {
+	document := &importDocument{File: relPath}
+	content, err := os.ReadFile(file)
+	if err != nil {
+		document.Err = xerr.NoType.Wrap(err).WithMsg("read file failed")
+		return document
+	}
+	frontMatter, body, err := utils.ParseFrontMatter(content)
+	if err != nil {
+		document.Err = xerr.BadParam.Wrap(err).WithMsg(err.Error())
+		return document
+	}
+
+	// 文件名作为 slug 的默认值，Hugo 的 page bundle（post/foo/index.md）使用目录名
+	name := strings.TrimSuffix(filepath.Base(file), filepath.Ext(file))
+	if name == "index" {
+		name = filepath.Base(filepath.Dir(file))
+	}
+	var fileDate *time.Time
+	if matches := jekyllFileName.FindStringSubmatch(name); matches != nil {
+		if t, err := time.ParseInLocation("2006-01-02", matches[1], time.Local); err == nil {
+			fileDate = &t
+			name = matches[2]
+		}
+	}
+
+	postParam := &param.Post{
+		Title:           frontMatterString(frontMatter["title"]),
+		Slug:            frontMatterString(frontMatter["slug"]),
+		Summary:         frontMatterString(frontMatter["description"]),
+		EditorType:      consts.EditorTypeMarkdown.Ptr(),
+		OriginalContent: body,
+		Status:          consts.PostStatusPublished,
+	}
+	if postParam.Title == "" {
+		postParam.Title = name
+	}
+	if postParam.Slug == "" {
+		postParam.Slug = name
+	}
+	if draft, ok := frontMatter["draft"].(bool); ok && draft {
+		postParam.Status = consts.PostStatusDraft
+	}
+	if published, ok := frontMatter["published"].(bool); ok && !published { // Jekyll
+		postParam.Status = consts.PostStatusDraft
+	}
+	document.Post = postParam
+
+	if createTime, ok := utils.ParseFrontMatterTime(frontMatter["date"], time.Local); ok {
+		document.CreateTime = &createTime
+	} else if fileDate != nil {
+		document.CreateTime = fileDate
+	}
+	updated := frontMatter["updated"]
+	if updated == nil {
+		updated = frontMatter["lastmod"] // Hugo
+	}
+	if updateTime, ok := utils.ParseFrontMatterTime(updated, time.Local); ok {
+		document.UpdateTime = &updateTime
+	}
+	document.Tags = frontMatterStrings(frontMatter["tags"])
+	document.Categories = frontMatterStrings(frontMatter["categories"])
+	return document
+}
+
+func frontMatterString(value interface{}) string {
+	if value == nil {
+		return ""
+	}
+	return strings.TrimSpace(fmt.Sprint(value))
+}
+
+// frontMatterStrings 支持字符串（逗号分隔）、列表以及 Hexo 的多级分类（嵌套列表），结果去重
+func frontMatterStrings(value interface{}) []string {
+	result := make([]string, 0)
+	seen := make(map[string]struct{})
+	var collect func(v interface{})
+	collect = func(v interface{}) {
+		switch v := v.(type) {
+		case nil:
+		case []interface{}:
+			for _, e := range v {
+				collect(e)
+			}
+		case string:
+			for _, s := range strings.Split(v, ",") {
+				s = strings.TrimSpace(s)
+				if _, ok := seen[strings.ToLower(s)]; s == "" || ok {
+					continue
+				}
+				seen[strings.ToLower(s)] = struct{}{}
+				result = append(result, s)
+			}
+		default:
+			collect(fmt.Sprint(v))
+		}
+	}
+	collect(value)
+	return result
+}
+
+// importDocuments 逐个导入文章，单篇失败时记录错误并继续，结果写入 result
+func (i *importServiceImpl) importDocuments(ctx context.Context, documents []*importDocument, state *importState, result *dto.ImportResult) {
+	result.Total += len(documents)
+	for _, document := range documents {
+		item := &dto.ImportItem{
+			File:       document.File,
+			Type:       document.PostType,
+			Tags:       document.Tags,
+			Categories: document.Categories,
+			OldURL:     document.OldURL,
+		}
+		result.Items = append(result.Items, item)
+		err := document.Err
+		if err == nil {
+			item.Title = document.Post.Title
+			item.Slug = utils.Slug(document.Post.Slug)
+			item.Status = document.Post.Status
+			if document.CreateTime != nil {
+				item.CreateTime = document.CreateTime.UnixMilli()
+			}
+			err = i.importDocument(ctx, document, item, state, result)
+		}
+		if err != nil {
+			item.Error = importErrMsg(err)
+			result.Failed++
+			continue
+		}
+		result.Imported++
+	}
+}
+
+func (i *importServiceImpl) importDocument(ctx context.Context, document *importDocument, item *dto.ImportItem, state *importState, result *dto.ImportResult) error {
+	dryRun := result.DryRun
+	postParam := document.Post
+	if postParam.Title == "" || utf8.RuneCountInString(postParam.Title) > 100 {
+		return xerr.BadParam.New("").WithMsg("title must be 1 to 100 characters")
+	}
+	if _, ok := state.slugs[item.Slug]; ok {
+		return xerr.BadParam.New("").WithMsg(fmt.Sprintf("slug %s is duplicated in this import", item.Slug))
+	}
+	_, err := i.PostService.GetPostBySlug(ctx, item.Slug)
+	if err == nil {
+		return xerr.BadParam.New("").WithMsg(fmt.Sprintf("post slug %s already exists", item.Slug))
+	}
+	if xerr.GetType(err) != xerr.NoRecord {
+		return err
+	}
+	// 未来的发布时间按定时发布处理
+	if document.CreateTime != nil && postParam.Status == consts.PostStatusPublished && document.CreateTime.After(time.Now()) {
+		publishTime := document.CreateTime.UnixMilli()
+		postParam.PublishTime = &publishTime
+	}
+
+	// 新建的标签和分类在事务提交后才记入 state，失败的文件不会留下标签和分类
+	newTagIDs := make(map[string]int32)
+	newCategoryIDs := make(map[string]int32)
+	err = dal.Transaction(ctx, func(txCtx context.Context) error {
+		tagIDs, err := i.resolveTags(txCtx, document.Tags, state, newTagIDs, dryRun)
+		if err != nil {
+			return err
+		}
+		categoryIDs, err := i.resolveCategories(txCtx, document.Categories, state, newCategoryIDs, dryRun)
+		if err != nil {
+			return err
+		}
+		if dryRun {
+			return nil
+		}
+		postParam.TagIDs = tagIDs
+		postParam.CategoryIDs = categoryIDs
+		post, err := i.PostService.Create(txCtx, postParam, document.PostType)
+		if err != nil {
+			return err
+		}
+		item.PostID = post.ID
+		return updateImportedPostTime(txCtx, post, document.CreateTime, document.UpdateTime)
+	})
+	if err != nil {
+		return err
+	}
+	for key, id := range newTagIDs {
+		state.tagIDs[key] = id
+	}
+	for key, id := range newCategoryIDs {
+		state.categoryIDs[key] = id
+	}
+	for _, name := range document.Tags {
+		if _, ok := newTagIDs[strings.ToLower(name)]; ok {
+			result.CreatedTags = append(result.CreatedTags, name)
+		}
+	}
+	for _, name := range document.Categories {
+		if _, ok := newCategoryIDs[strings.ToLower(name)]; ok {
+			result.CreatedCategories = append(result.CreatedCategories, name)
+		}
+	}
+	state.slugs[item.Slug] = struct{}{}
+	return nil
+}
+
+// resolveTags 按名称查找标签，名称不存在时按 slug 查找，都不存在时新建
+func (i *importServiceImpl) resolveTags(ctx context.Context, names []string, state *importState, newIDs map[string]int32, dryRun bool) ([]int32, error) {
+	ids := make([]int32, 0, len(names))
+	for _, name := range names {
+		key := strings.ToLower(name)
+		if id, ok := state.tagIDs[key]; ok {
+			ids = append(ids, id)
+			continue
+		}
+		slug := state.tagSlugs[key]
+		if slug == "" {
+			slug = name
+		}
+		tag, err := i.TagService.GetTagByName(ctx, name)
+		if xerr.GetType(err) == xerr.NoRecord {
+			tag, err = i.TagService.GetTagBySlug(ctx, utils.Slug(slug))
+		}
+		if xerr.GetType(err) == xerr.NoRecord {
+			if dryRun {
+				newIDs[key] = 0
+				continue
+			}
+			tag, err = i.TagService.Create(ctx, &param.Tag{Name: name, Slug: slug})
+			if err != nil {
+				return nil, err
+			}
+			newIDs[key] = tag.ID
+		} else if err != nil {
+			return nil, err
+		} else {
+			state.tagIDs[key] = tag.ID
+		}
+		ids = append(ids, tag.ID)
+	}
+	return ids, nil
+}
+
+// resolveCategories 按名称查找分类，名称不存在时按 slug 查找，都不存在时新建
+func (i *importServiceImpl) resolveCategories(ctx context.Context, names []string, state *importState, newIDs map[string]int32, dryRun bool) ([]int32, error) {
+	ids := make([]int32, 0, len(names))
+	for _, name := range names {
+		key := strings.ToLower(name)
+		if id, ok := state.categoryIDs[key]; ok {
+			ids = append(ids, id)
+			continue
+		}
+		slug := state.categorySlugs[key]
+		if slug == "" {
+			slug = name
+		}
+		category, err := i.CategoryService.GetCategoryByName(ctx, name)
+		if xerr.GetType(err) == xerr.NoRecord {
+			category, err = i.CategoryService.GetCategoryBySlug(ctx, utils.Slug(slug))
+		}
+		if xerr.GetType(err) == xerr.NoRecord {
+			if dryRun {
+				newIDs[key] = 0
+				continue
+			}
+			category, err = i.CategoryService.Create(ctx, &param.Category{Name: name, Slug: slug})
+			if err != nil {
+				return nil, err
+			}
+			newIDs[key] = category.ID
+		} else if err != nil {
+			return nil, err
+		} else {
+			state.categoryIDs[key] = category.ID
+		}
+		ids = append(ids, category.ID)
+	}
+	return ids, nil
+}
+
+// updateImportedPostTime 保留原博客的发布和更新时间
+func updateImportedPostTime(ctx context.Context, post *entity.Post, createTime *time.Time, updateTime *time.Time) error {
+	if createTime == nil && updateTime == nil {
+		return nil
+	}
+	if createTime == nil {
+		createTime = &post.CreateTime
+	}
+	if updateTime == nil || updateTime.Before(*createTime) {
+		updateTime = createTime
+	}
+	postDAL := dal.GetQueryByCtx(ctx).Post
+	_, err := postDAL.WithContext(ctx).Where(postDAL.ID.Eq(post.ID)).UpdateSimple(
+		postDAL.CreateTime.Value(*createTime),
+		postDAL.UpdateTime.Value(*updateTime),
+		postDAL.EditTime.Value(*updateTime),
+	)
+	if err != nil {
+		return WrapDBErr(err)
+	}
+	post.CreateTime = *createTime
+	post.UpdateTime = updateTime
+	post.EditTime = updateTime
+	return nil
+}
+
+// importErrMsg 优先返回给用户看的错误信息，没有时返回原始错误
+func importErrMsg(err error) string {
+	msg := xerr.GetMessage(err)
+	if msg == http.StatusText(http.StatusInternalServerError) {
+		return err.Error()
+	}
+	return msg
+}
@@ -0,0 +1,354 @@
+package impl
+
+import (
+	"context"
+	"dash/consts"
+	"dash/dal"
+	"dash/model/dto"
+	"dash/model/param"
+	"dash/utils"
+	"dash/utils/xerr"
+	"encoding/xml"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+// WordPress eXtended RSS（WXR）导出文件的结构，wp 命名空间的版本号会变化，这里只按标签名匹配
+type wxrRSS struct {
+	Channel wxrChannel `xml:"channel"`
+}
+
+type wxrChannel struct {
+	Categories []wxrCategory `xml:"category"`
+	Tags       []wxrTag      `xml:"tag"`
+	Items      []wxrItem     `xml:"item"`
+}
+
+type wxrCategory struct {
+	Nicename    string `xml:"category_nicename"`
+	Parent      string `xml:"category_parent"` // 父分类的 nicename
+	Name        string `xml:"cat_name"`
+	Description string `xml:"category_description"`
+}
+
+type wxrTag struct {
+	Slug string `xml:"tag_slug"`
+	Name string `xml:"tag_name"`
+}
+
+type wxrItem struct {
+	Title           string            `xml:"title"`
+	Link            string            `xml:"link"`
+	Encoded         []wxrEncoded      `xml:"encoded"` // content:encoded 和 excerpt:encoded
+	PostID          int64             `xml:"post_id"`
+	PostDate        string            `xml:"post_date"`
+	PostDateGMT     string            `xml:"post_date_gmt"`
+	PostModified    string            `xml:"post_modified"`
+	PostModifiedGMT string            `xml:"post_modified_gmt"`
+	PostName        string            `xml:"post_name"`
+	Status          string            `xml:"status"`
+	PostParent      int64             `xml:"post_parent"`
+	PostType        string            `xml:"post_type"`
+	PostPassword    string            `xml:"post_password"`
+	IsSticky        int               `xml:"is_sticky"`
+	Categories      []wxrItemTaxonomy `xml:"category"`
+}
+
+type wxrEncoded struct {
+	XMLName xml.Name
+	Value   string `xml:",chardata"`
+}
+
+type wxrItemTaxonomy struct {
+	Domain   string `xml:"domain,attr"`
+	Nicename string `xml:"nicename,attr"`
+	Name     string `xml:",chardata"`
+}
+
+const wxrTimeLayout = "2006-01-02 15:04:05"
+
+func (i *importServiceImpl) ImportWordPress(ctx context.Context, src string, dryRun bool) (*dto.ImportResult, error) {
+	file, err := os.Open(src)
+	if err != nil {
+		return nil, xerr.BadParam.Wrap(err).WithMsg("import source not found").WithStatus(xerr.StatusBadRequest)
+	}
+	defer file.Close()
+	rss := &wxrRSS{}
+	decoder := xml.NewDecoder(file)
+	decoder.Strict = false
+	err = decoder.Decode(rss)
+	if err != nil {
+		return nil, xerr.BadParam.Wrap(err).WithMsg("invalid WordPress export file").WithStatus(xerr.StatusBadRequest)
+	}
+
+	result := newImportResult(dryRun)
+	state := newImportState()
+	for _, tag := range rss.Channel.Tags {
+		if tag.Name != "" && tag.Slug != "" {
+			state.tagSlugs[strings.ToLower(tag.Name)] = tag.Slug
+		}
+	}
+	err = i.importWordPressCategories(ctx, rss.Channel.Categories, state, result)
+	if err != nil {
+		return nil, err
+	}
+
+	documents := make([]*importDocument, 0, len(rss.Channel.Items))
+	documentWordPressIDs := make([]int64, 0, len(rss.Channel.Items))
+	parentIDs := make(map[int64]int64)
+	for index := range rss.Channel.Items {
+		item := &rss.Channel.Items[index]
+		document := parseWordPressItem(item, state)
+		if document == nil {
+			result.Skipped++
+			continue
+		}
+		documents = append(documents, document)
+		documentWordPressIDs = append(documentWordPressIDs, item.PostID)
+		if item.PostType == "page" && item.PostParent > 0 {
+			parentIDs[item.PostID] = item.PostParent
+		}
+	}
+	i.importDocuments(ctx, documents, state, result)
+	if dryRun {
+		return result, nil
+	}
+
+	// 导入完成后按 WordPress 的 ID 关联父页面，再生成新链接，页面的链接包含父页面的 slug
+	wordPressIDs := make(map[int64]int32)
+	for index, item := range result.Items {
+		if item.PostID > 0 {
+			wordPressIDs[documentWordPressIDs[index]] = item.PostID
+		}
+	}
+	postDAL := dal.GetQueryByCtx(ctx).Post
+	for wordPressID, parentWordPressID := range parentIDs {
+		postID, ok := wordPressIDs[wordPressID]
+		parentID, parentOK := wordPressIDs[parentWordPressID]
+		if !ok || !parentOK {
+			continue
+		}
+		_, err = postDAL.WithContext(ctx).Where(postDAL.ID.Eq(postID), postDAL.Type.Eq(consts.PostTypeSheet)).UpdateSimple(postDAL.ParentID.Value(parentID))
+		if err != nil {
+			return nil, WrapDBErr(err)
+		}
+	}
+	for _, item := range result.Items {
+		if item.PostID == 0 {
+			continue
+		}
+		post, err := i.PostService.GetPostByID(ctx, item.PostID)
+		if err != nil {
+			return nil, err
+		}
+		item.NewURL, err = i.PermalinkService.BuildFullPath(ctx, post)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return result, nil
+}
+
+// importWordPressCategories 按父分类在前的顺序创建分类，已存在的分类（名称或 slug 相同）直接使用，不修改其父分类
+func (i *importServiceImpl) importWordPressCategories(ctx context.Context, categories []wxrCategory, state *importState, result *dto.ImportResult) error {
+	nicenameToName := make(map[string]string, len(categories))
+	for _, category := range categories {
+		if category.Name == "" || category.Nicename == "" {
+			continue
+		}
+		nicenameToName[category.Nicename] = category.Name
+		state.categorySlugs[strings.ToLower(category.Name)] = category.Nicename
+	}
+	pending := categories
+	for len(pending) > 0 {
+		next := make([]wxrCategory, 0)
+		for _, category := range pending {
+			if category.Name == "" || category.Nicename == "" {
+				continue
+			}
+			key := strings.ToLower(category.Name)
+			if _, ok := state.categoryIDs[key]; ok {
+				continue
+			}
+			var parentID int32
+			if parentName, ok := nicenameToName[category.Parent]; ok {
+				id, resolved := state.categoryIDs[strings.ToLower(parentName)]
+				if !resolved {
+					next = append(next, category)
+					continue
+				}
+				parentID = id
+			}
+			err := i.importWordPressCategory(ctx, category, parentID, state, result)
+			if err != nil {
+				return err
+			}
+		}
+		// 父分类不存在或循环引用时作为顶级分类创建
+		if len(next) == len(pending) {
+			for _, category := range next {
+				err := i.importWordPressCategory(ctx, category, 0, state, result)
+				if err != nil {
+					return err
+				}
+			}
+			break
+		}
+		pending = next
+	}
+	return nil
+}
+
+func (i *importServiceImpl) importWordPressCategory(ctx context.Context, category wxrCategory, parentID int32, state *importState, result *dto.ImportResult) error {
+	key := strings.ToLower(category.Name)
+	existing, err := i.CategoryService.GetCategoryByName(ctx, category.Name)
+	if xerr.GetType(err) == xerr.NoRecord {
+		existing, err = i.CategoryService.GetCategoryBySlug(ctx, utils.Slug(category.Nicename))
+	}
+	if err == nil {
+		state.categoryIDs[key] = existing.ID
+		return nil
+	}
+	if xerr.GetType(err) != xerr.NoRecord {
+		return err
+	}
+	result.CreatedCategories = append(result.CreatedCategories, category.Name)
+	if result.DryRun {
+		state.categoryIDs[key] = 0
+		return nil
+	}
+	description := category.Description
+	if utf8.RuneCountInString(description) > 100 {
+		description = string([]rune(description)[:100])
+	}
+	return dal.Transaction(ctx, func(txCtx context.Context) error {
+		created, err := i.CategoryService.Create(txCtx, &param.Category{
+			Name:        category.Name,
+			Slug:        category.Nicename,
+			Description: description,
+		})
+		if err != nil {
+			return err
+		}
+		if parentID > 0 {
+			categoryDAL := dal.GetQueryByCtx(txCtx).Category
+			_, err = categoryDAL.WithContext(txCtx).Where(categoryDAL.ID.Eq(created.ID)).UpdateSimple(categoryDAL.ParentID.Value(parentID))
+			if err != nil {
+				return WrapDBErr(err)
+			}
+		}
+		state.categoryIDs[key] = created.ID
+		return nil
+	})
+}
+
+// parseWordPressItem 只导入文章和页面，附件、菜单项、修订版本以及回收站和自动草稿中的内容返回 nil
+func parseWordPressItem(item *wxrItem, state *importState) *importDocument {
+	var postType consts.PostType
+	switch item.PostType {
+	case "post":
+		postType = consts.PostTypePost
+	case "page":
+		postType = consts.PostTypeSheet
+	default:
+		return nil
+	}
+	var status consts.PostStatus
+	switch item.Status {
+	case "publish", "future":
+		status = consts.PostStatusPublished
+	case "draft", "pending":
+		status = consts.PostStatusDraft
+	case "private":
+		status = consts.PostStatusIntimate
+	default:
+		return nil
+	}
+
+	document := &importDocument{
+		File:       item.PostType + "-" + strconv.FormatInt(item.PostID, 10),
+		PostType:   postType,
+		OldURL:     item.Link,
+		Tags:       make([]string, 0),
+		Categories: make([]string, 0),
+	}
+	var content, excerpt string
+	for _, encoded := range item.Encoded {
+		if strings.Contains(encoded.XMLName.Space, "excerpt") {
+			excerpt = encoded.Value
+		} else {
+			content = encoded.Value
+		}
+	}
+	markdown, err := utils.HTMLToMarkdown(content)
+	if err != nil {
+		document.Err = xerr.BadParam.Wrap(err).WithMsg("convert content to markdown failed")
+		return document
+	}
+	postParam := &param.Post{
+		Title:           strings.TrimSpace(item.Title),
+		Slug:            item.PostName,
+		Status:          status,
+		EditorType:      consts.EditorTypeMarkdown.Ptr(),
+		OriginalContent: markdown,
+		Summary:         strings.TrimSpace(excerpt),
+	}
+	if postParam.Slug == "" {
+		postParam.Slug = strconv.FormatInt(item.PostID, 10)
+	}
+	if postParam.Title == "" {
+		postParam.Title = postParam.Slug
+	}
+	if item.PostPassword != "" {
+		postParam.Password = &item.PostPassword
+	}
+	if item.IsSticky == 1 {
+		postParam.TopPriority = 1
+	}
+	document.Post = postParam
+	document.CreateTime = parseWordPressTime(item.PostDateGMT, item.PostDate)
+	document.UpdateTime = parseWordPressTime(item.PostModifiedGMT, item.PostModified)
+
+	for _, taxonomy := range item.Categories {
+		name := strings.TrimSpace(taxonomy.Name)
+		if name == "" {
+			continue
+		}
+		switch taxonomy.Domain {
+		case "post_tag":
+			document.Tags = appendUniqueName(document.Tags, name)
+			if _, ok := state.tagSlugs[strings.ToLower(name)]; !ok && taxonomy.Nicename != "" {
+				state.tagSlugs[strings.ToLower(name)] = taxonomy.Nicename
+			}
+		case "category":
+			document.Categories = appendUniqueName(document.Categories, name)
+			if _, ok := state.categorySlugs[strings.ToLower(name)]; !ok && taxonomy.Nicename != "" {
+				state.categorySlugs[strings.ToLower(name)] = taxonomy.Nicename
+			}
+		}
+	}
+	return document
+}
+
+// parseWordPressTime 优先使用 GMT 时间，草稿的 GMT 时间为 0000-00-00 00:00:00，此时使用站点本地时间
+func parseWordPressTime(gmt string, local string) *time.Time {
+	if t, err := time.ParseInLocation(wxrTimeLayout, gmt, time.UTC); err == nil && t.Year() > 1 {
+		return &t
+	}
+	if t, err := time.ParseInLocation(wxrTimeLayout, local, time.Local); err == nil && t.Year() > 1 {
+		return &t
+	}
+	return nil
+}
+
+func appendUniqueName(names []string, name string) []string {
+	for _, n := range names {
+		if strings.EqualFold(n, name) {
+			return names
+		}
+	}
+	return append(names, name)
+}
@@ -0,0 +1,109 @@
+package impl
+
+import (
+	"bytes"
+	"context"
+	"dash/model/property"
+	"dash/service"
+	"dash/utils"
+	"dash/utils/xerr"
+
+	chromahtml "github.com/alecthomas/chroma/v2/formatters/html"
+	"github.com/yuin/goldmark"
+	highlighting "github.com/yuin/goldmark-highlighting/v2"
+	"github.com/yuin/goldmark/ast"
+	"github.com/yuin/goldmark/extension"
+	"github.com/yuin/goldmark/parser"
+	"github.com/yuin/goldmark/renderer"
+	"github.com/yuin/goldmark/renderer/html"
+	"github.com/yuin/goldmark/util"
+)
+
+type markdownServiceImpl struct {
+	OptionService service.OptionService
+}
+
+func NewMarkdownService(optionService service.OptionService) service.MarkdownService {
+	return &markdownServiceImpl{
+		OptionService: optionService,
+	}
+}
+
+func (m *markdownServiceImpl) Render(ctx context.Context, markdown string) (string, error) {
+	md := m.newMarkdown(ctx)
+	var buf bytes.Buffer
+	// ids must be fresh for every document, otherwise duplicated headings across posts get suffixed
+	pc := parser.NewContext(parser.WithIDs(newHeadingIDs()))
+	if err := md.Convert([]byte(markdown), &buf, parser.WithContext(pc)); err != nil {
+		return "", xerr.NoType.Wrap(err).WithMsg("render markdown failed")
+	}
+	return buf.String(), nil
+}
+
+func (m *markdownServiceImpl) newMarkdown(ctx context.Context) goldmark.Markdown {
+	extensions := []goldmark.Extender{extension.GFM}
+	if m.OptionService.GetOrByDefault(ctx, property.MarkdownFootnote).(bool) {
+		extensions = append(extensions, extension.Footnote)
+	}
+	if m.OptionService.GetOrByDefault(ctx, property.MarkdownCodeHighlight).(bool) {
+		extensions = append(extensions, highlighting.NewHighlighting(
+			highlighting.WithStyle(m.OptionService.GetOrByDefault(ctx, property.MarkdownHighlightStyle).(string)),
+			highlighting.WithFormatOptions(chromahtml.WithLineNumbers(false)),
+		))
+	}
+	rendererOptions := []renderer.Option{html.WithUnsafe()}
+	if m.OptionService.GetOrByDefault(ctx, property.MarkdownHeadingAnchor).(bool) {
+		rendererOptions = append(rendererOptions, renderer.WithNodeRenderers(util.Prioritized(&headingAnchorRenderer{}, 100)))
+	}
+	return goldmark.New(
+		goldmark.WithExtensions(extensions...),
+		goldmark.WithParserOptions(parser.WithAutoHeadingID()),
+		goldmark.WithRendererOptions(rendererOptions...),
+	)
+}
+
+// headingIDs 将 utils.HeadingIDs 适配为 goldmark 的 parser.IDs，保证与目录中的 id 规则一致
+type headingIDs struct {
+	*utils.HeadingIDs
+}
+
+func newHeadingIDs() parser.IDs {
+	return &headingIDs{HeadingIDs: utils.NewHeadingIDs()}
+}
+
+func (h *headingIDs) Generate(value []byte, kind ast.NodeKind) []byte {
+	return []byte(h.HeadingIDs.Generate(string(value)))
+}
+
+func (h *headingIDs) Put(value []byte) {
+	h.HeadingIDs.Put(string(value))
+}
+
+// headingAnchorRenderer 在标题内输出指向自身的锚点链接
+type headingAnchorRenderer struct{}
+
+func (r *headingAnchorRenderer) RegisterFuncs(reg renderer.NodeRendererFuncRegisterer) {
+	reg.Register(ast.KindHeading, r.renderHeading)
+}
+
+func (r *headingAnchorRenderer) renderHeading(w util.BufWriter, source []byte, node ast.Node, entering bool) (ast.WalkStatus, error) {
+	n := node.(*ast.Heading)
+	if entering {
+		_, _ = w.WriteString("<h")
+		_ = w.WriteByte("0123456"[n.Level])
+		if n.Attributes() != nil {
+			html.RenderAttributes(w, node, html.HeadingAttributeFilter)
+		}
+		_ = w.WriteByte('>')
+		if id, ok := n.AttributeString("id"); ok {
+			_, _ = w.WriteString(`<a class="heading-anchor" href="#`)
+			_, _ = w.Write(util.EscapeHTML(id.([]byte)))
+			_, _ = w.WriteString(`" aria-hidden="true">#</a>`)
+		}
+	} else {
+		_, _ = w.WriteString("</h")
+		_ = w.WriteByte("0123456"[n.Level])
+		_, _ = w.WriteString(">\n")
+	}
+	return ast.WalkContinue, nil
+}
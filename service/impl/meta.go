@@ -0,0 +1,74 @@
+package impl
+
+import (
+	"context"
+	"dash/dal"
+	"dash/model/dto"
+	"dash/model/entity"
+	"dash/model/param"
+	"dash/service"
+	"dash/utils/xerr"
+	"time"
+)
+
+type metaServiceImpl struct{}
+
+func NewMetaService() service.MetaService {
+	return &metaServiceImpl{}
+}
+
+func (m *metaServiceImpl) ListByPostID(ctx context.Context, postID int32) ([]*entity.PostMeta, error) {
+	postMetaDAL := dal.GetQueryByCtx(ctx).PostMeta
+	metas, err := postMetaDAL.WithContext(ctx).Where(postMetaDAL.PostID.Eq(postID)).Order(postMetaDAL.ID).Find()
+	return metas, WrapDBErr(err)
+}
+
+func (m *metaServiceImpl) SaveByPostID(ctx context.Context, postID int32, metas []*param.Meta) error {
+	keySet := make(map[string]struct{}, len(metas))
+	for _, meta := range metas {
+		if _, ok := keySet[meta.Key]; ok {
+			return xerr.BadParam.New("duplicate meta key=%v", meta.Key).WithMsg("meta key duplicated: " + meta.Key).WithStatus(xerr.StatusBadRequest)
+		}
+		keySet[meta.Key] = struct{}{}
+	}
+
+	postMetaDAL := dal.GetQueryByCtx(ctx).PostMeta
+	_, err := postMetaDAL.WithContext(ctx).Where(postMetaDAL.PostID.Eq(postID)).Delete()
+	if err != nil {
+		return WrapDBErr(err)
+	}
+	if len(metas) == 0 {
+		return nil
+	}
+	now := time.Now()
+	postMetas := make([]*entity.PostMeta, 0, len(metas))
+	for _, meta := range metas {
+		postMetas = append(postMetas, &entity.PostMeta{
+			CreateTime: now,
+			PostID:     postID,
+			MetaKey:    meta.Key,
+			MetaValue:  meta.Value,
+		})
+	}
+	return WrapDBErr(postMetaDAL.WithContext(ctx).Create(postMetas...))
+}
+
+func (m *metaServiceImpl) DeleteByPostID(ctx context.Context, postID int32) error {
+	postMetaDAL := dal.GetQueryByCtx(ctx).PostMeta
+	_, err := postMetaDAL.WithContext(ctx).Where(postMetaDAL.PostID.Eq(postID)).Delete()
+	return WrapDBErr(err)
+}
+
+func (m *metaServiceImpl) ConvertToMetaDTOs(ctx context.Context, metas []*entity.PostMeta) []*dto.Meta {
+	metaDTOs := make([]*dto.Meta, 0, len(metas))
+	for _, meta := range metas {
+		metaDTOs = append(metaDTOs, &dto.Meta{
+			ID:         meta.ID,
+			PostID:     meta.PostID,
+			Key:        meta.MetaKey,
+			Value:      meta.MetaValue,
+			CreateTime: meta.CreateTime.UnixMilli(),
+		})
+	}
+	return metaDTOs
+}
@@ -30,8 +30,12 @@ func (o *oneTimeTokenServiceImpl) Get(oneTimeToken string) (string, bool) {
 }
 
 func (o *oneTimeTokenServiceImpl) Create(value string) string {
+	return o.CreateWithExpiration(value, ottExpirationTime)
+}
+
+func (o *oneTimeTokenServiceImpl) CreateWithExpiration(value string, expiration time.Duration) string {
 	ctx := context.Background()
 	uuid := utils.GenUUIDWithOutDash()
-	cache.Redis.Set(ctx, oneTimeTokenPrefix+uuid, value, ottExpirationTime)
+	cache.Redis.Set(ctx, oneTimeTokenPrefix+uuid, value, expiration)
 	return uuid
 }
@@ -318,5 +318,7 @@ func (o *optionServiceImpl) Save(ctx context.Context, saveMap map[string]string)
 	if err != nil {
 		return err
 	}
+	// 博客地址、链接格式等设置会影响站点地图中的链接
+	invalidateContentCache(ctx)
 	return nil
 }
@@ -0,0 +1,293 @@
+package impl
+
+import (
+	"context"
+	"dash/consts"
+	"dash/model/entity"
+	"dash/model/property"
+	"dash/service"
+	"dash/utils/xerr"
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+type permalinkServiceImpl struct {
+	OptionService   service.OptionService
+	BasePostService service.BasePostService
+	SheetService    service.SheetService
+}
+
+func NewPermalinkService(optionService service.OptionService, basePostService service.BasePostService, sheetService service.SheetService) service.PermalinkService {
+	return &permalinkServiceImpl{
+		OptionService:   optionService,
+		BasePostService: basePostService,
+		SheetService:    sheetService,
+	}
+}
+
+// permalinkOptions 生成和解析链接需要的设置
+type permalinkOptions struct {
+	postType       consts.PostPermalinkType
+	sheetType      consts.SheetPermaLinkType
+	archivesPrefix string
+	sheetPrefix    string
+	suffix         string
+}
+
+func (p *permalinkServiceImpl) getOptions(ctx context.Context) (*permalinkOptions, error) {
+	options := &permalinkOptions{}
+	value, err := p.OptionService.GetOrByDefaultWithErr(ctx, property.PostPermalinkType, property.PostPermalinkType.DefaultValue)
+	if err != nil {
+		return nil, err
+	}
+	options.postType = consts.PostPermalinkType(value.(string))
+	value, err = p.OptionService.GetOrByDefaultWithErr(ctx, property.SheetPermalinkType, property.SheetPermalinkType.DefaultValue)
+	if err != nil {
+		return nil, err
+	}
+	options.sheetType = consts.SheetPermaLinkType(value.(string))
+	options.archivesPrefix, err = p.OptionService.GetArchivePrefix(ctx)
+	if err != nil {
+		return nil, err
+	}
+	value, err = p.OptionService.GetOrByDefaultWithErr(ctx, property.SheetPrefix, property.SheetPrefix.DefaultValue)
+	if err != nil {
+		return nil, err
+	}
+	options.sheetPrefix = value.(string)
+	options.suffix, err = p.OptionService.GetPathSuffix(ctx)
+	if err != nil {
+		return nil, err
+	}
+	options.archivesPrefix = strings.Trim(options.archivesPrefix, "/")
+	options.sheetPrefix = strings.Trim(options.sheetPrefix, "/")
+	return options, nil
+}
+
+func (p *permalinkServiceImpl) BuildPath(ctx context.Context, post *entity.Post) (string, error) {
+	options, err := p.getOptions(ctx)
+	if err != nil {
+		return "", err
+	}
+	segments := make([]string, 0, 4)
+	if post.Type == consts.PostTypeSheet {
+		if options.sheetType != consts.SheetPermaLinkTypeRoot {
+			segments = append(segments, options.sheetPrefix)
+		}
+		if post.ParentID != 0 { // 子页面的路径包含所有父页面，如 /s/docs/install
+			slugPath, err := p.SheetService.GetSlugPath(ctx, post)
+			if err != nil {
+				return "", err
+			}
+			segments = append(segments, slugPath...)
+		} else {
+			segments = append(segments, post.Slug)
+		}
+		return "/" + strings.Join(segments, "/") + options.suffix, nil
+	}
+
+	createTime := post.CreateTime
+	switch options.postType {
+	case consts.PostPermalinkTypeDate:
+		segments = append(segments, createTime.Format("2006"), createTime.Format("01"), post.Slug)
+	case consts.PostPermalinkTypeDay:
+		segments = append(segments, createTime.Format("2006"), createTime.Format("01"), createTime.Format("02"), post.Slug)
+	case consts.PostPermalinkTypeYear:
+		segments = append(segments, createTime.Format("2006"), post.Slug)
+	case consts.PostPermalinkTypeID:
+		segments = append(segments, options.archivesPrefix, strconv.Itoa(int(post.ID)))
+	case consts.PostPermalinkTypeIDSlug:
+		segments = append(segments, options.archivesPrefix, strconv.Itoa(int(post.ID)), post.Slug)
+	default:
+		segments = append(segments, options.archivesPrefix, post.Slug)
+	}
+	return "/" + strings.Join(segments, "/") + options.suffix, nil
+}
+
+func (p *permalinkServiceImpl) BuildFullPath(ctx context.Context, post *entity.Post) (string, error) {
+	path, err := p.BuildPath(ctx, post)
+	if err != nil {
+		return "", err
+	}
+	isAbsolute, err := p.OptionService.IsEnabledAbsolutePath(ctx)
+	if err != nil {
+		return "", err
+	}
+	if !isAbsolute {
+		return path, nil
+	}
+	blogURL, err := p.OptionService.GetBlogBaseURL(ctx)
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimRight(blogURL, "/") + path, nil
+}
+
+func (p *permalinkServiceImpl) Resolve(ctx context.Context, path string) (*entity.Post, error) {
+	notFound := xerr.NoRecord.New("permalink path=%v not found", path).WithMsg("The resource does not exist or has been deleted").WithStatus(xerr.StatusNotFound)
+	options, err := p.getOptions(ctx)
+	if err != nil {
+		return nil, err
+	}
+	// 完整 URL 只取路径部分，忽略查询参数和锚点
+	u, err := url.Parse(path)
+	if err != nil {
+		return nil, notFound
+	}
+	cleanPath := strings.Trim(u.Path, "/")
+	if options.suffix != "" {
+		cleanPath = strings.TrimSuffix(cleanPath, options.suffix)
+	}
+	if cleanPath == "" {
+		return nil, notFound
+	}
+	segments := strings.Split(cleanPath, "/")
+
+	candidates := make([]*entity.Post, 0, 2)
+	addCandidate := func(post *entity.Post) {
+		for _, candidate := range candidates {
+			if candidate.ID == post.ID {
+				return
+			}
+		}
+		candidates = append(candidates, post)
+	}
+	post, err := p.resolvePost(ctx, options, segments)
+	if err != nil {
+		return nil, err
+	}
+	if post != nil {
+		addCandidate(post)
+	}
+	sheets, err := p.resolveSheets(ctx, options, segments)
+	if err != nil {
+		return nil, err
+	}
+	for _, sheet := range sheets {
+		addCandidate(sheet)
+	}
+
+	switch len(candidates) {
+	case 0:
+		return nil, notFound
+	case 1:
+		return candidates[0], nil
+	default:
+		slugs := make([]string, 0, len(candidates))
+		for _, candidate := range candidates {
+			slugs = append(slugs, candidate.Slug)
+		}
+		return nil, xerr.BadParam.New("permalink path=%v matches %v", path, slugs).
+			WithMsg(fmt.Sprintf("the path matches more than one post or sheet: %s", strings.Join(slugs, ", "))).
+			WithStatus(xerr.StatusConflict)
+	}
+}
+
+// resolvePost 按文章的各种链接格式匹配，找不到时返回 nil
+func (p *permalinkServiceImpl) resolvePost(ctx context.Context, options *permalinkOptions, segments []string) (*entity.Post, error) {
+	if segments[0] == options.archivesPrefix {
+		switch len(segments) {
+		case 2:
+			// DEFAULT 和 ID 格式相同，纯数字的 slug 按当前设置的格式优先
+			if id, ok := parsePermalinkID(segments[1]); ok && options.postType == consts.PostPermalinkTypeID {
+				post, err := p.getPostByID(ctx, id)
+				if post != nil || err != nil {
+					return post, err
+				}
+			}
+			post, err := p.getPostBySlug(ctx, segments[1])
+			if post != nil || err != nil {
+				return post, err
+			}
+			if id, ok := parsePermalinkID(segments[1]); ok {
+				return p.getPostByID(ctx, id)
+			}
+		case 3:
+			if id, ok := parsePermalinkID(segments[1]); ok {
+				post, err := p.getPostByID(ctx, id)
+				if post == nil || err != nil || post.Slug != segments[2] {
+					return nil, err
+				}
+				return post, nil
+			}
+		}
+		return nil, nil
+	}
+
+	// YEAR /2025/slug，DATE /2025/03/slug，DAY /2025/03/14/slug
+	layouts := map[int][]string{2: {"2006"}, 3: {"2006", "01"}, 4: {"2006", "01", "02"}}
+	layout, ok := layouts[len(segments)]
+	if !ok {
+		return nil, nil
+	}
+	for i := range layout {
+		if len(segments[i]) != len(layout[i]) {
+			return nil, nil
+		}
+		if _, ok := parsePermalinkID(segments[i]); !ok {
+			return nil, nil
+		}
+	}
+	post, err := p.getPostBySlug(ctx, segments[len(segments)-1])
+	if post == nil || err != nil {
+		return nil, err
+	}
+	for i := range layout {
+		if post.CreateTime.Format(layout[i]) != segments[i] {
+			return nil, nil
+		}
+	}
+	return post, nil
+}
+
+// resolveSheets 同时尝试 SECONDARY 和 ROOT 两种格式，ROOT 格式的页面可能和文章链接冲突
+func (p *permalinkServiceImpl) resolveSheets(ctx context.Context, options *permalinkOptions, segments []string) ([]*entity.Post, error) {
+	sheets := make([]*entity.Post, 0, 2)
+	paths := [][]string{segments}
+	if segments[0] == options.sheetPrefix && len(segments) > 1 {
+		paths = append(paths, segments[1:])
+	}
+	for _, slugs := range paths {
+		sheet, err := p.SheetService.GetSheetByPath(ctx, slugs)
+		if xerr.GetType(err) == xerr.NoRecord {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		sheets = append(sheets, sheet)
+	}
+	return sheets, nil
+}
+
+func (p *permalinkServiceImpl) getPostByID(ctx context.Context, id int32) (*entity.Post, error) {
+	post, err := p.BasePostService.GetPostByID(ctx, id)
+	if xerr.GetType(err) == xerr.NoRecord {
+		return nil, nil
+	}
+	if err != nil || post.Type != consts.PostTypePost {
+		return nil, err
+	}
+	return post, nil
+}
+
+func (p *permalinkServiceImpl) getPostBySlug(ctx context.Context, slug string) (*entity.Post, error) {
+	post, err := p.BasePostService.GetPostBySlug(ctx, slug)
+	if xerr.GetType(err) == xerr.NoRecord {
+		return nil, nil
+	}
+	if err != nil || post.Type != consts.PostTypePost {
+		return nil, err
+	}
+	return post, nil
+}
+
+func parsePermalinkID(segment string) (int32, bool) {
+	id, err := strconv.ParseInt(segment, 10, 32)
+	if err != nil || id <= 0 {
+		return 0, false
+	}
+	return int32(id), true
+}
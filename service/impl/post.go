@@ -11,7 +11,9 @@ import (
 	"dash/utils/xerr"
 	"database/sql/driver"
 	"errors"
+	"time"
 
+	"gorm.io/gen"
 	"gorm.io/gen/field"
 	"gorm.io/gorm"
 )
@@ -19,15 +21,21 @@ import (
 type postServiceImpl struct {
 	service.BasePostService
 	service.OptionService
+	VisibilityService  service.VisibilityService
+	RelatedPostService service.RelatedPostService
 }
 
 func NewPostService(
 	basePostService service.BasePostService,
 	optionService service.OptionService,
+	visibilityService service.VisibilityService,
+	relatedPostService service.RelatedPostService,
 ) service.PostService {
 	return &postServiceImpl{
-		BasePostService: basePostService,
-		OptionService:   optionService,
+		BasePostService:    basePostService,
+		OptionService:      optionService,
+		VisibilityService:  visibilityService,
+		RelatedPostService: relatedPostService,
 	}
 }
 
@@ -58,10 +66,22 @@ func (p *postServiceImpl) Page(ctx context.Context, postQuery param.PostQuery) (
 		postDo = postDo.Where(postDAL.Status.In(statuesValue...))
 	}
 	if postQuery.CategoryID != nil { // 文章分类过滤，只查询指定分类的文章
-		postDo.Join(&entity.PostCategory{}, postDAL.ID.EqCol(postCategoryDAL.PostID)).Where(postCategoryDAL.CategoryID.Eq(*postQuery.CategoryID))
+		postDo = postDo.Join(&entity.PostCategory{}, postDAL.ID.EqCol(postCategoryDAL.PostID)).Where(postCategoryDAL.CategoryID.Eq(*postQuery.CategoryID))
 	}
 	if postQuery.TagID != nil { // 文章标签过滤，只查询指定标签的文章
-		postDo.Join(&entity.PostTag{}, postDAL.ID.EqCol(postTagDAL.PostID)).Where(postTagDAL.TagID.Eq(*postQuery.TagID))
+		postDo = postDo.Join(&entity.PostTag{}, postDAL.ID.EqCol(postTagDAL.PostID)).Where(postTagDAL.TagID.Eq(*postQuery.TagID))
+	}
+	if postQuery.MetaKey != nil { // 文章元数据过滤，只查询带有指定元数据的文章
+		postMetaDAL := dal.GetQueryByCtx(ctx).PostMeta
+		metaPostIDs := postMetaDAL.WithContext(ctx).Where(postMetaDAL.MetaKey.Eq(*postQuery.MetaKey))
+		if postQuery.MetaValue != nil {
+			metaPostIDs = metaPostIDs.Where(postMetaDAL.MetaValue.Eq(*postQuery.MetaValue))
+		}
+		postDo = postDo.Where(postDAL.WithContext(ctx).Columns(postDAL.ID).In(metaPostIDs.Select(postMetaDAL.PostID)))
+	}
+	if len(postQuery.ExcludeCategoryIDs) > 0 { // 排除隐藏分类下的文章
+		excludePostIDs := postCategoryDAL.WithContext(ctx).Where(postCategoryDAL.CategoryID.In(postQuery.ExcludeCategoryIDs...)).Select(postCategoryDAL.PostID)
+		postDo = postDo.Where(postDAL.WithContext(ctx).Columns(postDAL.ID).NotIn(excludePostIDs))
 	}
 
 	posts, totalCount, err := postDo.FindByPage(postQuery.PageNum*postQuery.PageSize, postQuery.PageSize) // 分页查询文章列表
@@ -73,8 +93,12 @@ func (p *postServiceImpl) Page(ctx context.Context, postQuery param.PostQuery) (
 
 func (p *postServiceImpl) GetPrevPosts(ctx context.Context, post *entity.Post, size int) ([]*entity.Post, error) {
 	postSort := p.OptionService.GetOrByDefault(ctx, property.IndexSort)
+	conditions, err := p.buildNavigationConditions(ctx)
+	if err != nil {
+		return nil, err
+	}
 	postDAL := dal.GetQueryByCtx(ctx).Post
-	postDO := postDAL.WithContext(ctx).Where(postDAL.Status.Eq(consts.PostStatusPublished), postDAL.Type.Eq(consts.PostTypePost))
+	postDO := postDAL.WithContext(ctx).Where(conditions...)
 
 	switch postSort {
 	case "create_time":
@@ -91,7 +115,7 @@ func (p *postServiceImpl) GetPrevPosts(ctx context.Context, post *entity.Post, s
 		return nil, nil
 	}
 
-	posts, err := postDO.Find()
+	posts, err := postDO.Limit(size).Find()
 	if err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
 			return nil, nil
@@ -101,10 +125,37 @@ func (p *postServiceImpl) GetPrevPosts(ctx context.Context, post *entity.Post, s
 	return posts, nil
 }
 
+// buildNavigationConditions 上一篇、下一篇只在访问者可见的文章中查找
+func (p *postServiceImpl) buildNavigationConditions(ctx context.Context) ([]gen.Condition, error) {
+	query := dal.GetQueryByCtx(ctx)
+	postDAL := query.Post
+	postCategoryDAL := query.PostCategory
+
+	statuses := make([]driver.Valuer, 0)
+	for _, status := range p.VisibilityService.VisibleStatuses(ctx) {
+		statuses = append(statuses, status)
+	}
+	conditions := []gen.Condition{postDAL.Status.In(statuses...), postDAL.Type.Eq(consts.PostTypePost)}
+
+	hiddenCategoryIDs, err := p.VisibilityService.ListHiddenCategoryIDs(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	if len(hiddenCategoryIDs) > 0 {
+		hiddenPostIDs := postCategoryDAL.WithContext(ctx).Where(postCategoryDAL.CategoryID.In(hiddenCategoryIDs...)).Select(postCategoryDAL.PostID)
+		conditions = append(conditions, postDAL.WithContext(ctx).Columns(postDAL.ID).NotIn(hiddenPostIDs))
+	}
+	return conditions, nil
+}
+
 func (p *postServiceImpl) GetNextPosts(ctx context.Context, post *entity.Post, size int) ([]*entity.Post, error) {
 	postSort := p.OptionService.GetOrByDefault(ctx, property.IndexSort)
+	conditions, err := p.buildNavigationConditions(ctx)
+	if err != nil {
+		return nil, err
+	}
 	postDAL := dal.GetQueryByCtx(ctx).Post
-	postDO := postDAL.WithContext(ctx).Where(postDAL.Status.Eq(consts.PostStatusPublished), postDAL.Type.Eq(consts.PostTypePost))
+	postDO := postDAL.WithContext(ctx).Where(conditions...)
 
 	switch postSort {
 	case "create_time":
@@ -121,7 +172,7 @@ func (p *postServiceImpl) GetNextPosts(ctx context.Context, post *entity.Post, s
 		return nil, nil
 	}
 
-	posts, err := postDO.Find()
+	posts, err := postDO.Limit(size).Find()
 	if err != nil {
 		return nil, WrapDBErr(err)
 	}
@@ -156,3 +207,86 @@ func (p *postServiceImpl) GetLikeCount(ctx context.Context) (int64, error) {
 	}
 	return int64(count), nil
 }
+
+func (p *postServiceImpl) ListScheduledPosts(ctx context.Context) ([]*entity.Post, error) {
+	postDAL := dal.GetQueryByCtx(ctx).Post
+	posts, err := postDAL.WithContext(ctx).
+		Where(postDAL.Type.Eq(consts.PostTypePost)).
+		Where(field.Or(postDAL.PublishTime.IsNotNull(), postDAL.ExpireTime.IsNotNull())).
+		Order(postDAL.PublishTime, postDAL.ExpireTime).
+		Find()
+	if err != nil {
+		return nil, WrapDBErr(err)
+	}
+	return posts, nil
+}
+
+func (p *postServiceImpl) PublishScheduledPosts(ctx context.Context) (int64, error) {
+	now := time.Now()
+	postDAL := dal.GetQueryByCtx(ctx).Post
+	posts, err := postDAL.WithContext(ctx).Where(postDAL.Status.Eq(consts.PostStatusDraft), postDAL.PublishTime.Lte(now)).Find()
+	if err != nil {
+		return 0, WrapDBErr(err)
+	}
+	var count int64
+	for _, post := range posts {
+		// the status and publish time are part of the condition, so when several instances
+		// race for the same post only one of them updates the row
+		updateResult, err := postDAL.WithContext(ctx).
+			Where(postDAL.ID.Eq(post.ID), postDAL.Status.Eq(consts.PostStatusDraft), postDAL.PublishTime.Eq(*post.PublishTime)).
+			UpdateSimple(
+				postDAL.Status.Value(consts.PostStatusPublished),
+				postDAL.CreateTime.Value(*post.PublishTime),
+				postDAL.PublishTime.Null(),
+			)
+		if err != nil {
+			return count, WrapDBErr(err)
+		}
+		count += updateResult.RowsAffected
+	}
+	if count > 0 {
+		p.RelatedPostService.Invalidate(ctx)
+		invalidateContentCache(ctx)
+	}
+	return count, nil
+}
+
+func (p *postServiceImpl) ExpirePosts(ctx context.Context) (int64, error) {
+	expireStatus, err := consts.PostStatusFromString(p.OptionService.GetOrByDefault(ctx, property.ExpireStatus).(string))
+	if err != nil || (expireStatus != consts.PostStatusDraft && expireStatus != consts.PostStatusRecycle) {
+		expireStatus = consts.PostStatusDraft
+	}
+
+	now := time.Now()
+	postDAL := dal.GetQueryByCtx(ctx).Post
+	posts, err := postDAL.WithContext(ctx).Where(postDAL.Status.Eq(consts.PostStatusPublished), postDAL.ExpireTime.Lte(now)).Find()
+	if err != nil {
+		return 0, WrapDBErr(err)
+	}
+	var count int64
+	for _, post := range posts {
+		updateResult, err := postDAL.WithContext(ctx).
+			Where(postDAL.ID.Eq(post.ID), postDAL.Status.Eq(consts.PostStatusPublished), postDAL.ExpireTime.Eq(*post.ExpireTime)).
+			UpdateSimple(
+				postDAL.Status.Value(expireStatus),
+				postDAL.ExpireTime.Null(),
+			)
+		if err != nil {
+			return count, WrapDBErr(err)
+		}
+		if updateResult.RowsAffected == 0 {
+			continue
+		}
+		count += updateResult.RowsAffected
+		post.Status = expireStatus
+		err = syncRecycleItems(ctx, []*entity.Post{post})
+		if err != nil {
+			return count, err
+		}
+	}
+	if count > 0 {
+		p.RelatedPostService.Invalidate(ctx)
+		invalidateContentCache(ctx)
+	}
+	return count, nil
+}
@@ -83,3 +83,19 @@ func (p *postCategoryServiceImpl) ListCategoryMapByPostID(ctx context.Context, p
 	}
 	return result, nil
 }
+
+func (p *postCategoryServiceImpl) ListPostIDSetByCategoryIDs(ctx context.Context, categoryIDs []int32) (map[int32]struct{}, error) {
+	result := make(map[int32]struct{})
+	if len(categoryIDs) == 0 {
+		return result, nil
+	}
+	postCategoryDAL := dal.GetQueryByCtx(ctx).PostCategory
+	postCategories, err := postCategoryDAL.WithContext(ctx).Where(postCategoryDAL.CategoryID.In(categoryIDs...)).Find()
+	if err != nil {
+		return nil, WrapDBErr(err)
+	}
+	for _, postCategory := range postCategories {
+		result[postCategory.PostID] = struct{}{}
+	}
+	return result, nil
+}
@@ -0,0 +1,273 @@
+package impl
+
+import (
+	"context"
+	"dash/consts"
+	"dash/dal"
+	"dash/model/dto"
+	"dash/model/entity"
+	"dash/model/param"
+	"dash/service"
+	"dash/utils"
+	"dash/utils/xerr"
+	"encoding/json"
+	"time"
+)
+
+type postDraftServiceImpl struct {
+	OptionService       service.OptionService
+	MarkdownService     service.MarkdownService
+	SearchService       service.SearchService
+	PostRevisionService service.PostRevisionService
+	RelatedPostService  service.RelatedPostService
+}
+
+func NewPostDraftService(
+	optionService service.OptionService,
+	markdownService service.MarkdownService,
+	searchService service.SearchService,
+	postRevisionService service.PostRevisionService,
+	relatedPostService service.RelatedPostService,
+) service.PostDraftService {
+	return &postDraftServiceImpl{
+		OptionService:       optionService,
+		MarkdownService:     markdownService,
+		SearchService:       searchService,
+		PostRevisionService: postRevisionService,
+		RelatedPostService:  relatedPostService,
+	}
+}
+
+func (p *postDraftServiceImpl) Save(ctx context.Context, postID int32, draftParam *param.PostDraft) (*entity.PostDraft, error) {
+	editorType := consts.EditorTypeMarkdown
+	if draftParam.EditorType != nil {
+		editorType = *draftParam.EditorType
+	}
+	formatContent := draftParam.Content
+	// 和保存文章时一样渲染，编辑器可以直接预览草稿
+	if editorType == consts.EditorTypeMarkdown && draftParam.OriginalContent != "" {
+		var err error
+		formatContent, err = p.MarkdownService.Render(ctx, draftParam.OriginalContent)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	tagIDs := make([]int32, 0, len(draftParam.TagIDs))
+	tagIDSet := make(map[int32]struct{}, len(draftParam.TagIDs))
+	for _, tagID := range draftParam.TagIDs {
+		if _, ok := tagIDSet[tagID]; ok {
+			continue
+		}
+		tagIDSet[tagID] = struct{}{}
+		tagIDs = append(tagIDs, tagID)
+	}
+	tagIDsBytes, _ := json.Marshal(tagIDs)
+
+	err := p.checkPostExist(ctx, postID)
+	if err != nil {
+		return nil, err
+	}
+	tagDAL := dal.GetQueryByCtx(ctx).Tag
+	if len(tagIDs) > 0 {
+		tagCount, err := tagDAL.WithContext(ctx).Where(tagDAL.ID.In(tagIDs...)).Count()
+		if err != nil {
+			return nil, WrapDBErr(err)
+		}
+		if int(tagCount) != len(tagIDs) {
+			return nil, xerr.BadParam.New("").WithMsg("tag not exist").WithStatus(xerr.StatusBadRequest)
+		}
+	}
+
+	now := time.Now()
+	draft := &entity.PostDraft{
+		CreateTime:      now,
+		UpdateTime:      now,
+		PostID:          postID,
+		Title:           draftParam.Title,
+		Summary:         draftParam.Summary,
+		EditorType:      editorType,
+		OriginalContent: draftParam.OriginalContent,
+		FormatContent:   formatContent,
+		TagIDs:          string(tagIDsBytes),
+	}
+	// 先更新已有草稿，没有草稿时再插入；两次自动保存同时插入时由唯一索引拦截，失败的一方改为更新
+	ok, err := p.updateDraft(ctx, draft)
+	if err != nil {
+		return nil, err
+	}
+	if !ok {
+		postDraftDAL := dal.GetQueryByCtx(ctx).PostDraft
+		createErr := postDraftDAL.WithContext(ctx).Create(draft)
+		if createErr != nil {
+			ok, err = p.updateDraft(ctx, draft)
+			if err != nil || !ok {
+				return nil, WrapDBErr(createErr)
+			}
+		}
+	}
+	return p.GetByPostID(ctx, postID)
+}
+
+// updateDraft 覆盖文章已有的草稿，没有草稿时返回 false
+func (p *postDraftServiceImpl) updateDraft(ctx context.Context, draft *entity.PostDraft) (bool, error) {
+	postDraftDAL := dal.GetQueryByCtx(ctx).PostDraft
+	updateResult, err := postDraftDAL.WithContext(ctx).Where(postDraftDAL.PostID.Eq(draft.PostID)).UpdateSimple(
+		postDraftDAL.UpdateTime.Value(draft.UpdateTime),
+		postDraftDAL.Title.Value(draft.Title),
+		postDraftDAL.Summary.Value(draft.Summary),
+		postDraftDAL.EditorType.Value(draft.EditorType),
+		postDraftDAL.OriginalContent.Value(draft.OriginalContent),
+		postDraftDAL.FormatContent.Value(draft.FormatContent),
+		postDraftDAL.TagIDs.Value(draft.TagIDs),
+	)
+	if err != nil {
+		return false, WrapDBErr(err)
+	}
+	if updateResult.RowsAffected > 0 {
+		return true, nil
+	}
+	// MySQL 在内容没有变化时返回 0 行，需要再确认草稿是否存在
+	count, err := postDraftDAL.WithContext(ctx).Where(postDraftDAL.PostID.Eq(draft.PostID)).Count()
+	if err != nil {
+		return false, WrapDBErr(err)
+	}
+	return count > 0, nil
+}
+
+// checkPostExist 草稿只用于文章，页面的 ID 按不存在处理
+func (p *postDraftServiceImpl) checkPostExist(ctx context.Context, postID int32) error {
+	postDAL := dal.GetQueryByCtx(ctx).Post
+	_, err := postDAL.WithContext(ctx).Where(postDAL.ID.Eq(postID), postDAL.Type.Eq(consts.PostTypePost)).Take()
+	return WrapDBErr(err)
+}
+
+func (p *postDraftServiceImpl) GetByPostID(ctx context.Context, postID int32) (*entity.PostDraft, error) {
+	postDraftDAL := dal.GetQueryByCtx(ctx).PostDraft
+	draft, err := postDraftDAL.WithContext(ctx).Where(postDraftDAL.PostID.Eq(postID)).First()
+	if err != nil {
+		return nil, WrapDBErr(err)
+	}
+	return draft, nil
+}
+
+func (p *postDraftServiceImpl) Publish(ctx context.Context, postID int32) (*entity.Post, error) {
+	var post *entity.Post
+	err := dal.Transaction(ctx, func(txCtx context.Context) error {
+		query := dal.GetQueryByCtx(txCtx)
+		postDAL := query.Post
+		postTagDAL := query.PostTag
+
+		draft, err := p.GetByPostID(txCtx, postID)
+		if err != nil {
+			return err
+		}
+		if draft.Title == "" {
+			return xerr.BadParam.New("").WithMsg("draft title is empty").WithStatus(xerr.StatusBadRequest)
+		}
+		err = p.checkPostExist(txCtx, postID)
+		if err != nil {
+			return err
+		}
+
+		// 草稿保存后被删除的标签直接忽略，不让发布失败
+		draftTagIDs := p.parseTagIDs(draft)
+		tagIDs := make([]int32, 0, len(draftTagIDs))
+		if len(draftTagIDs) > 0 {
+			err = query.Tag.WithContext(txCtx).Where(query.Tag.ID.In(draftTagIDs...)).Pluck(query.Tag.ID, &tagIDs)
+			if err != nil {
+				return WrapDBErr(err)
+			}
+		}
+
+		now := time.Now()
+		formatContent, toc := buildToc(draft.FormatContent)
+		summary := draft.Summary
+		if summary == "" {
+			summary = generateSummary(txCtx, p.OptionService, formatContent)
+		}
+		_, err = postDAL.WithContext(txCtx).Where(postDAL.ID.Eq(postID)).UpdateSimple(
+			postDAL.UpdateTime.Value(now),
+			postDAL.EditTime.Value(now),
+			postDAL.Title.Value(draft.Title),
+			postDAL.Summary.Value(summary),
+			postDAL.EditorType.Value(draft.EditorType),
+			postDAL.OriginalContent.Value(draft.OriginalContent),
+			postDAL.FormatContent.Value(formatContent),
+			postDAL.Toc.Value(toc),
+			postDAL.WordCount.Value(utils.HTMLFormatWordCount(formatContent)),
+		)
+		if err != nil {
+			return WrapDBErr(err)
+		}
+
+		_, err = postTagDAL.WithContext(txCtx).Where(postTagDAL.PostID.Eq(postID)).Delete()
+		if err != nil {
+			return WrapDBErr(err)
+		}
+		if len(tagIDs) > 0 {
+			pts := make([]*entity.PostTag, 0, len(tagIDs))
+			for _, tagID := range tagIDs {
+				pts = append(pts, &entity.PostTag{
+					CreateTime: now,
+					PostID:     postID,
+					TagID:      tagID,
+				})
+			}
+			err = postTagDAL.WithContext(txCtx).Create(pts...)
+			if err != nil {
+				return WrapDBErr(err)
+			}
+		}
+
+		_, err = query.PostDraft.WithContext(txCtx).Where(query.PostDraft.ID.Eq(draft.ID)).Delete()
+		if err != nil {
+			return WrapDBErr(err)
+		}
+
+		post, err = postDAL.WithContext(txCtx).Where(postDAL.ID.Eq(postID)).First()
+		if err != nil {
+			return WrapDBErr(err)
+		}
+		err = p.SearchService.IndexPost(txCtx, post)
+		if err != nil {
+			return err
+		}
+		_, err = p.PostRevisionService.Create(txCtx, post, "publish draft")
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	p.RelatedPostService.Invalidate(ctx)
+	invalidateContentCache(ctx)
+	return post, nil
+}
+
+func (p *postDraftServiceImpl) DeleteByPostID(ctx context.Context, postID int32) error {
+	postDraftDAL := dal.GetQueryByCtx(ctx).PostDraft
+	_, err := postDraftDAL.WithContext(ctx).Where(postDraftDAL.PostID.Eq(postID)).Delete()
+	return WrapDBErr(err)
+}
+
+func (p *postDraftServiceImpl) ConvertToPostDraftDTO(ctx context.Context, draft *entity.PostDraft) *dto.PostDraft {
+	return &dto.PostDraft{
+		PostID:          draft.PostID,
+		Title:           draft.Title,
+		Summary:         draft.Summary,
+		EditorType:      draft.EditorType,
+		OriginalContent: draft.OriginalContent,
+		Content:         draft.FormatContent,
+		TagIDs:          p.parseTagIDs(draft),
+		CreateTime:      draft.CreateTime.UnixMilli(),
+		UpdateTime:      draft.UpdateTime.UnixMilli(),
+	}
+}
+
+func (p *postDraftServiceImpl) parseTagIDs(draft *entity.PostDraft) []int32 {
+	tagIDs := make([]int32, 0)
+	if draft.TagIDs != "" {
+		_ = json.Unmarshal([]byte(draft.TagIDs), &tagIDs)
+	}
+	return tagIDs
+}
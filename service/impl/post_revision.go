@@ -0,0 +1,223 @@
+package impl
+
+import (
+	"context"
+	"dash/dal"
+	"dash/model/dto"
+	"dash/model/entity"
+	"dash/model/property"
+	"dash/service"
+	"dash/utils"
+	"dash/utils/xerr"
+	"fmt"
+	"time"
+
+	"gorm.io/gorm/clause"
+)
+
+type postRevisionServiceImpl struct {
+	OptionService      service.OptionService
+	SearchService      service.SearchService
+	RelatedPostService service.RelatedPostService
+}
+
+func NewPostRevisionService(optionService service.OptionService, searchService service.SearchService, relatedPostService service.RelatedPostService) service.PostRevisionService {
+	return &postRevisionServiceImpl{
+		OptionService:      optionService,
+		SearchService:      searchService,
+		RelatedPostService: relatedPostService,
+	}
+}
+
+func (p *postRevisionServiceImpl) Create(ctx context.Context, post *entity.Post, remark string) (*entity.PostRevision, error) {
+	query := dal.GetQueryByCtx(ctx)
+	postDAL := query.Post
+	postRevisionDAL := query.PostRevision
+
+	// 锁住文章行，同一篇文章并发保存时依次分配版本号；最新版本也用加锁读取，确保读到其他事务刚提交的版本
+	_, err := postDAL.WithContext(ctx).Clauses(clause.Locking{Strength: "UPDATE"}).Select(postDAL.ID).Where(postDAL.ID.Eq(post.ID)).Take()
+	if err != nil {
+		return nil, WrapDBErr(err)
+	}
+	var version int32 = 1
+	latest, err := postRevisionDAL.WithContext(ctx).Clauses(clause.Locking{Strength: "UPDATE"}).Where(postRevisionDAL.PostID.Eq(post.ID)).Order(postRevisionDAL.Version.Desc()).Take()
+	if err != nil {
+		if err = WrapDBErr(err); xerr.GetType(err) != xerr.NoRecord {
+			return nil, err
+		}
+	} else {
+		version = latest.Version + 1
+	}
+
+	revision := &entity.PostRevision{
+		CreateTime:      time.Now(),
+		PostID:          post.ID,
+		Version:         version,
+		Title:           post.Title,
+		Slug:            post.Slug,
+		Summary:         post.Summary,
+		EditorType:      post.EditorType,
+		OriginalContent: post.OriginalContent,
+		FormatContent:   post.FormatContent,
+		Remark:          remark,
+	}
+	err = postRevisionDAL.WithContext(ctx).Create(revision)
+	if err != nil {
+		return nil, WrapDBErr(err)
+	}
+
+	// remove revisions beyond the retention limit, oldest first
+	retention := p.OptionService.GetOrByDefault(ctx, property.RevisionRetention).(int)
+	if retention > 0 {
+		expired, err := postRevisionDAL.WithContext(ctx).
+			Where(postRevisionDAL.PostID.Eq(post.ID)).
+			Order(postRevisionDAL.Version.Desc()).
+			Offset(retention).
+			Find()
+		if err != nil {
+			return nil, WrapDBErr(err)
+		}
+		if len(expired) > 0 {
+			expiredIDs := make([]int32, 0, len(expired))
+			for _, e := range expired {
+				expiredIDs = append(expiredIDs, e.ID)
+			}
+			_, err = postRevisionDAL.WithContext(ctx).Where(postRevisionDAL.ID.In(expiredIDs...)).Delete()
+			if err != nil {
+				return nil, WrapDBErr(err)
+			}
+		}
+	}
+	return revision, nil
+}
+
+func (p *postRevisionServiceImpl) ListByPostID(ctx context.Context, postID int32) ([]*entity.PostRevision, error) {
+	postRevisionDAL := dal.GetQueryByCtx(ctx).PostRevision
+	revisions, err := postRevisionDAL.WithContext(ctx).Where(postRevisionDAL.PostID.Eq(postID)).Order(postRevisionDAL.Version.Desc()).Find()
+	if err != nil {
+		return nil, WrapDBErr(err)
+	}
+	return revisions, nil
+}
+
+func (p *postRevisionServiceImpl) GetByID(ctx context.Context, postID int32, revisionID int32) (*entity.PostRevision, error) {
+	postRevisionDAL := dal.GetQueryByCtx(ctx).PostRevision
+	revision, err := postRevisionDAL.WithContext(ctx).Where(postRevisionDAL.ID.Eq(revisionID), postRevisionDAL.PostID.Eq(postID)).First()
+	if err != nil {
+		return nil, WrapDBErr(err)
+	}
+	return revision, nil
+}
+
+func (p *postRevisionServiceImpl) DeleteByPostID(ctx context.Context, postID int32) error {
+	postRevisionDAL := dal.GetQueryByCtx(ctx).PostRevision
+	_, err := postRevisionDAL.WithContext(ctx).Where(postRevisionDAL.PostID.Eq(postID)).Delete()
+	return WrapDBErr(err)
+}
+
+func (p *postRevisionServiceImpl) Diff(ctx context.Context, postID int32, fromID int32, toID int32) (*dto.PostRevisionDiff, error) {
+	from, err := p.GetByID(ctx, postID, fromID)
+	if err != nil {
+		return nil, err
+	}
+	to, err := p.GetByID(ctx, postID, toID)
+	if err != nil {
+		return nil, err
+	}
+	lines := utils.DiffLines(from.OriginalContent, to.OriginalContent)
+	diff := &dto.PostRevisionDiff{
+		From:  p.ConvertToPostRevisionDTO(ctx, from),
+		To:    p.ConvertToPostRevisionDTO(ctx, to),
+		Lines: lines,
+	}
+	for _, line := range lines {
+		switch line.Op {
+		case utils.DiffInsert:
+			diff.Added++
+		case utils.DiffDelete:
+			diff.Deleted++
+		}
+	}
+	return diff, nil
+}
+
+func (p *postRevisionServiceImpl) Restore(ctx context.Context, postID int32, revisionID int32) (*entity.Post, error) {
+	var post *entity.Post
+	err := dal.Transaction(ctx, func(txCtx context.Context) error {
+		postDAL := dal.GetQueryByCtx(txCtx).Post
+
+		revision, err := p.GetByID(txCtx, postID, revisionID)
+		if err != nil {
+			return err
+		}
+		_, err = postDAL.WithContext(txCtx).Where(postDAL.ID.Eq(postID)).First()
+		if err != nil {
+			return WrapDBErr(err)
+		}
+
+		// the slug is left untouched, restoring it could break links or collide with another post
+		now := time.Now()
+		formatContent, toc := buildToc(revision.FormatContent)
+		_, err = postDAL.WithContext(txCtx).Where(postDAL.ID.Eq(postID)).UpdateSimple(
+			postDAL.UpdateTime.Value(now),
+			postDAL.EditTime.Value(now),
+			postDAL.Title.Value(revision.Title),
+			postDAL.Summary.Value(revision.Summary),
+			postDAL.EditorType.Value(revision.EditorType),
+			postDAL.OriginalContent.Value(revision.OriginalContent),
+			postDAL.FormatContent.Value(formatContent),
+			postDAL.Toc.Value(toc),
+			postDAL.WordCount.Value(utils.HTMLFormatWordCount(formatContent)),
+		)
+		if err != nil {
+			return WrapDBErr(err)
+		}
+
+		post, err = postDAL.WithContext(txCtx).Where(postDAL.ID.Eq(postID)).First()
+		if err != nil {
+			return WrapDBErr(err)
+		}
+		err = p.SearchService.IndexPost(txCtx, post)
+		if err != nil {
+			return err
+		}
+		_, err = p.Create(txCtx, post, fmt.Sprintf("restore from version %d", revision.Version))
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	p.RelatedPostService.Invalidate(ctx)
+	invalidateContentCache(ctx)
+	return post, nil
+}
+
+func (p *postRevisionServiceImpl) ConvertToPostRevisionDTO(ctx context.Context, revision *entity.PostRevision) *dto.PostRevision {
+	return &dto.PostRevision{
+		ID:         revision.ID,
+		PostID:     revision.PostID,
+		Version:    revision.Version,
+		Title:      revision.Title,
+		Slug:       revision.Slug,
+		Summary:    revision.Summary,
+		EditorType: revision.EditorType,
+		Remark:     revision.Remark,
+		CreateTime: revision.CreateTime.UnixMilli(),
+	}
+}
+
+func (p *postRevisionServiceImpl) ConvertToPostRevisionDTOs(ctx context.Context, revisions []*entity.PostRevision) []*dto.PostRevision {
+	revisionDTOs := make([]*dto.PostRevision, 0, len(revisions))
+	for _, revision := range revisions {
+		revisionDTOs = append(revisionDTOs, p.ConvertToPostRevisionDTO(ctx, revision))
+	}
+	return revisionDTOs
+}
+
+func (p *postRevisionServiceImpl) ConvertToPostRevisionDetailDTO(ctx context.Context, revision *entity.PostRevision) *dto.PostRevisionDetail {
+	return &dto.PostRevisionDetail{
+		PostRevision:    *p.ConvertToPostRevisionDTO(ctx, revision),
+		OriginalContent: revision.OriginalContent,
+		Content:         revision.FormatContent,
+	}
+}
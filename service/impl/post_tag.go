@@ -9,6 +9,7 @@ import (
 	"dash/model/param"
 	"dash/service"
 
+	"gorm.io/gen/field"
 	"gorm.io/gorm"
 )
 
@@ -87,10 +88,16 @@ func (p *postTagServiceImpl) ListTagMapByPostID(ctx context.Context, postIDs []i
 
 	return res, nil
 }
-func (p postTagServiceImpl) ListTagWithPostCount(ctx context.Context, sort *param.Sort) ([]*dto.TagWithPostCount, error) {
+func (p postTagServiceImpl) ListTagWithPostCount(ctx context.Context, sort *param.Sort, excludeCategoryIDs []int32) ([]*dto.TagWithPostCount, error) {
 	postTagDAL := dal.GetQueryByCtx(ctx).PostTag
 	tagDAL := dal.GetQueryByCtx(ctx).Tag
 	tagDo := tagDAL.WithContext(ctx)
+	joinConds := []field.Expr{tagDAL.ID.EqCol(postTagDAL.TagID)}
+	if len(excludeCategoryIDs) > 0 { // 条件放在 JOIN 中，文章都被排除的标签仍以 0 篇返回
+		postCategoryDAL := dal.GetQueryByCtx(ctx).PostCategory
+		excludePostIDs := postCategoryDAL.WithContext(ctx).Where(postCategoryDAL.CategoryID.In(excludeCategoryIDs...)).Select(postCategoryDAL.PostID)
+		joinConds = append(joinConds, postTagDAL.WithContext(ctx).Columns(postTagDAL.PostID).NotIn(excludePostIDs))
+	}
 
 	err := BuildSort(sort, &tagDAL, &tagDo)
 	if err != nil {
@@ -102,7 +109,7 @@ func (p postTagServiceImpl) ListTagWithPostCount(ctx context.Context, sort *para
 		PostCount int64 `gorm:"column:postCount"`
 	}, 0)
 
-	err = tagDo.Select(tagDAL.ALL, postTagDAL.PostID.Count().As("postCount")).LeftJoin(postTagDAL, tagDAL.ID.EqCol(postTagDAL.TagID)).Group(tagDAL.ID).Scan(&tagWithPostCounts)
+	err = tagDo.Select(tagDAL.ALL, postTagDAL.PostID.Count().As("postCount")).LeftJoin(postTagDAL, joinConds...).Group(tagDAL.ID).Scan(&tagWithPostCounts)
 	if err != nil {
 		return nil, WrapDBErr(err)
 	}
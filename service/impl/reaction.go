@@ -0,0 +1,178 @@
+package impl
+
+import (
+	"context"
+	"dash/cache"
+	"dash/dal"
+	"dash/model/dto"
+	"dash/model/entity"
+	"dash/model/property"
+	"dash/service"
+	"dash/utils/xerr"
+	"strings"
+	"time"
+)
+
+const (
+	// reactionDedupExpiration 同一访客对同一文章重复点赞、回应的去重时间
+	reactionDedupExpiration = 24 * time.Hour
+	reactionRateLimitWindow = time.Minute
+)
+
+type reactionServiceImpl struct {
+	OptionService service.OptionService
+}
+
+func NewReactionService(optionService service.OptionService) service.ReactionService {
+	return &reactionServiceImpl{
+		OptionService: optionService,
+	}
+}
+
+func (r *reactionServiceImpl) LikePost(ctx context.Context, post *entity.Post, visitorID string) (int64, error) {
+	err := r.checkRateLimit(ctx, visitorID)
+	if err != nil {
+		return 0, err
+	}
+	err = r.checkDuplicate(ctx, cache.BuildPostLikeKey(post.ID, visitorID), "you have already liked this post")
+	if err != nil {
+		return 0, err
+	}
+
+	postDAL := dal.GetQueryByCtx(ctx).Post
+	_, err = postDAL.WithContext(ctx).Where(postDAL.ID.Eq(post.ID)).UpdateSimple(postDAL.Likes.Add(1))
+	if err != nil {
+		return 0, WrapDBErr(err)
+	}
+	post, err = postDAL.WithContext(ctx).Where(postDAL.ID.Eq(post.ID)).First()
+	if err != nil {
+		return 0, WrapDBErr(err)
+	}
+	return post.Likes, nil
+}
+
+func (r *reactionServiceImpl) ReactToPost(ctx context.Context, post *entity.Post, reaction string, visitorID string) ([]*dto.Reaction, error) {
+	available := false
+	for _, availableReaction := range r.ListAvailableReactions(ctx) {
+		if availableReaction == reaction {
+			available = true
+			break
+		}
+	}
+	if !available {
+		return nil, xerr.BadParam.New("reaction=%v not available", reaction).WithMsg("reaction is not available").WithStatus(xerr.StatusBadRequest)
+	}
+	err := r.checkRateLimit(ctx, visitorID)
+	if err != nil {
+		return nil, err
+	}
+	err = r.checkDuplicate(ctx, cache.BuildPostReactionKey(post.ID, reaction, visitorID), "you have already reacted with "+reaction)
+	if err != nil {
+		return nil, err
+	}
+
+	err = r.incrReactionCount(ctx, post.ID, reaction)
+	if err != nil {
+		return nil, err
+	}
+	return r.ListReactionsByPostID(ctx, post.ID)
+}
+
+// incrReactionCount 先尝试累加，没有记录时再插入；并发插入由唯一索引拦截后重新累加
+func (r *reactionServiceImpl) incrReactionCount(ctx context.Context, postID int32, reaction string) error {
+	postReactionDAL := dal.GetQueryByCtx(ctx).PostReaction
+	incr := func() (bool, error) {
+		updateResult, err := postReactionDAL.WithContext(ctx).
+			Where(postReactionDAL.PostID.Eq(postID), postReactionDAL.Reaction.Eq(reaction)).
+			UpdateSimple(postReactionDAL.Count.Add(1), postReactionDAL.UpdateTime.Value(time.Now()))
+		if err != nil {
+			return false, WrapDBErr(err)
+		}
+		return updateResult.RowsAffected > 0, nil
+	}
+	ok, err := incr()
+	if err != nil || ok {
+		return err
+	}
+	err = postReactionDAL.WithContext(ctx).Create(&entity.PostReaction{
+		CreateTime: time.Now(),
+		PostID:     postID,
+		Reaction:   reaction,
+		Count:      1,
+	})
+	if err == nil {
+		return nil
+	}
+	ok, incrErr := incr()
+	if incrErr != nil || !ok {
+		return WrapDBErr(err)
+	}
+	return nil
+}
+
+func (r *reactionServiceImpl) ListReactionsByPostID(ctx context.Context, postID int32) ([]*dto.Reaction, error) {
+	postReactionDAL := dal.GetQueryByCtx(ctx).PostReaction
+	postReactions, err := postReactionDAL.WithContext(ctx).Where(postReactionDAL.PostID.Eq(postID)).Find()
+	if err != nil {
+		return nil, WrapDBErr(err)
+	}
+	counts := make(map[string]int64, len(postReactions))
+	for _, postReaction := range postReactions {
+		counts[postReaction.Reaction] = postReaction.Count
+	}
+	// 只返回当前配置的表情，已移除的表情不再展示
+	availableReactions := r.ListAvailableReactions(ctx)
+	reactions := make([]*dto.Reaction, 0, len(availableReactions))
+	for _, reaction := range availableReactions {
+		reactions = append(reactions, &dto.Reaction{
+			Reaction: reaction,
+			Count:    counts[reaction],
+		})
+	}
+	return reactions, nil
+}
+
+func (r *reactionServiceImpl) ListAvailableReactions(ctx context.Context) []string {
+	value := r.OptionService.GetOrByDefault(ctx, property.PostReactions).(string)
+	reactions := make([]string, 0)
+	for _, reaction := range strings.Split(value, ",") {
+		reaction = strings.TrimSpace(reaction)
+		if reaction != "" {
+			reactions = append(reactions, reaction)
+		}
+	}
+	return reactions
+}
+
+func (r *reactionServiceImpl) checkRateLimit(ctx context.Context, visitorID string) error {
+	limit := r.OptionService.GetOrByDefault(ctx, property.ReactionRateLimit).(int)
+	if limit <= 0 {
+		return nil
+	}
+	key := cache.BuildReactionRateLimitKey(visitorID)
+	count, err := cache.Redis.Incr(ctx, key).Result()
+	if err != nil {
+		return xerr.NoType.Wrap(err).WithMsg("rate limit failed")
+	}
+	if count == 1 {
+		err = cache.Redis.Expire(ctx, key, reactionRateLimitWindow).Err()
+		if err != nil {
+			return xerr.NoType.Wrap(err).WithMsg("rate limit failed")
+		}
+	}
+	if count > int64(limit) {
+		return xerr.Forbidden.New("visitor=%v exceeded reaction rate limit", visitorID).WithMsg("too many requests, please try again later").WithStatus(xerr.StatusTooManyRequests)
+	}
+	return nil
+}
+
+func (r *reactionServiceImpl) checkDuplicate(ctx context.Context, key string, msg string) error {
+	ok, err := cache.Redis.SetNX(ctx, key, 1, reactionDedupExpiration).Result()
+	if err != nil {
+		return xerr.NoType.Wrap(err).WithMsg("check duplicate failed")
+	}
+	if !ok {
+		return xerr.BadParam.New("duplicate key=%v", key).WithMsg(msg).WithStatus(xerr.StatusBadRequest)
+	}
+	return nil
+}
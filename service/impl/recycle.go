@@ -0,0 +1,394 @@
+package impl
+
+import (
+	"context"
+	"dash/consts"
+	"dash/dal"
+	"dash/model/dto"
+	"dash/model/entity"
+	"dash/model/param"
+	"dash/model/property"
+	"dash/service"
+	"dash/utils/xerr"
+	"database/sql/driver"
+	"encoding/json"
+	"time"
+
+	"go.uber.org/zap"
+	"gorm.io/gen/field"
+)
+
+type recycleServiceImpl struct {
+	Logger          *zap.Logger
+	OptionService   service.OptionService
+	BasePostService service.BasePostService
+	CategoryService service.CategoryService
+	TagService      service.TagService
+}
+
+func NewRecycleService(
+	logger *zap.Logger,
+	optionService service.OptionService,
+	basePostService service.BasePostService,
+	categoryService service.CategoryService,
+	tagService service.TagService,
+) service.RecycleService {
+	return &recycleServiceImpl{
+		Logger:          logger,
+		OptionService:   optionService,
+		BasePostService: basePostService,
+		CategoryService: categoryService,
+		TagService:      tagService,
+	}
+}
+
+// recycleSnapshot 分类和标签删除前的数据，恢复时按它重建分类或标签以及和文章的关联
+type recycleSnapshot struct {
+	Category *entity.Category `json:"category,omitempty"`
+	Tag      *entity.Tag      `json:"tag,omitempty"`
+	PostIDs  []int32          `json:"post_ids"`
+}
+
+func (r *recycleServiceImpl) RecyclePosts(ctx context.Context, postIDs []int32) error {
+	postDAL := dal.GetQueryByCtx(ctx).Post
+	posts, err := postDAL.WithContext(ctx).Where(postDAL.ID.In(postIDs...)).Find()
+	if err != nil {
+		return WrapDBErr(err)
+	}
+	recycleIDs := make([]int32, 0, len(posts))
+	for _, post := range posts {
+		if post.Status != consts.PostStatusRecycle {
+			recycleIDs = append(recycleIDs, post.ID)
+		}
+	}
+	if len(recycleIDs) == 0 {
+		return nil
+	}
+	_, err = r.BasePostService.UpdateStatusBatch(ctx, recycleIDs, consts.PostStatusRecycle)
+	return err
+}
+
+func (r *recycleServiceImpl) RecycleCategory(ctx context.Context, categoryID int32) error {
+	category, err := r.CategoryService.GetCategoryByID(ctx, categoryID)
+	if err != nil {
+		return err
+	}
+	postCategoryDAL := dal.GetQueryByCtx(ctx).PostCategory
+	postIDs := make([]int32, 0)
+	err = postCategoryDAL.WithContext(ctx).Where(postCategoryDAL.CategoryID.Eq(categoryID)).Pluck(postCategoryDAL.PostID, &postIDs)
+	if err != nil {
+		return WrapDBErr(err)
+	}
+	return r.recycleTaxonomy(ctx, consts.RecycleTypeCategory, categoryID, category.Name, &recycleSnapshot{Category: category, PostIDs: postIDs}, func(txCtx context.Context) error {
+		return r.CategoryService.DeleteByID(txCtx, categoryID)
+	})
+}
+
+func (r *recycleServiceImpl) RecycleTag(ctx context.Context, tagID int32) error {
+	tag, err := r.TagService.GetTagByID(ctx, tagID)
+	if err != nil {
+		return err
+	}
+	postTagDAL := dal.GetQueryByCtx(ctx).PostTag
+	postIDs := make([]int32, 0)
+	err = postTagDAL.WithContext(ctx).Where(postTagDAL.TagID.Eq(tagID)).Pluck(postTagDAL.PostID, &postIDs)
+	if err != nil {
+		return WrapDBErr(err)
+	}
+	return r.recycleTaxonomy(ctx, consts.RecycleTypeTag, tagID, tag.Name, &recycleSnapshot{Tag: tag, PostIDs: postIDs}, func(txCtx context.Context) error {
+		return r.TagService.DeleteByID(txCtx, tagID)
+	})
+}
+
+func (r *recycleServiceImpl) recycleTaxonomy(ctx context.Context, recycleType consts.RecycleType, targetID int32, title string, snapshot *recycleSnapshot, deleteFn func(txCtx context.Context) error) error {
+	snapshotJSON, err := json.Marshal(snapshot)
+	if err != nil {
+		return xerr.NoType.Wrap(err).WithMsg("save recycle snapshot failed")
+	}
+	return dal.Transaction(ctx, func(txCtx context.Context) error {
+		err := dal.GetQueryByCtx(txCtx).RecycleItem.WithContext(txCtx).Create(&entity.RecycleItem{
+			CreateTime: time.Now(),
+			Type:       recycleType,
+			TargetID:   targetID,
+			Title:      title,
+			DeletedBy:  getOperatorName(ctx),
+			Snapshot:   string(snapshotJSON),
+		})
+		if err != nil {
+			return WrapDBErr(err)
+		}
+		return deleteFn(txCtx)
+	})
+}
+
+func (r *recycleServiceImpl) Page(ctx context.Context, query param.RecycleQuery) ([]*entity.RecycleItem, int64, error) {
+	if query.PageNum < 0 || query.PageSize < 0 {
+		return nil, 0, xerr.BadParam.New("").WithStatus(xerr.StatusBadRequest).WithMsg("Paging parameter error")
+	}
+	recycleItemDAL := dal.GetQueryByCtx(ctx).RecycleItem
+	recycleItemDO := recycleItemDAL.WithContext(ctx)
+	if query.Type != nil {
+		recycleItemDO = recycleItemDO.Where(recycleItemDAL.Type.Eq(*query.Type))
+	}
+	if query.Keyword != nil && *query.Keyword != "" {
+		recycleItemDO = recycleItemDO.Where(recycleItemDAL.Title.Like("%" + *query.Keyword + "%"))
+	}
+	items, total, err := recycleItemDO.Order(recycleItemDAL.CreateTime.Desc(), recycleItemDAL.ID.Desc()).
+		FindByPage(query.PageNum*query.PageSize, query.PageSize)
+	if err != nil {
+		return nil, 0, WrapDBErr(err)
+	}
+	return items, total, nil
+}
+
+func (r *recycleServiceImpl) Restore(ctx context.Context, id int32) error {
+	item, err := r.getItemByID(ctx, id)
+	if err != nil {
+		return err
+	}
+	switch item.Type {
+	case consts.RecycleTypePost, consts.RecycleTypeSheet:
+		// 回收站记录随状态同步删除
+		_, err = r.BasePostService.UpdateStatusByID(ctx, item.TargetID, consts.PostStatusDraft)
+		if xerr.GetType(err) != xerr.NoRecord {
+			return err
+		}
+		// 文章已经不存在，只删除记录
+		recycleItemDAL := dal.GetQueryByCtx(ctx).RecycleItem
+		_, err = recycleItemDAL.WithContext(ctx).Where(recycleItemDAL.ID.Eq(item.ID)).Delete()
+		return WrapDBErr(err)
+	default:
+		err = dal.Transaction(ctx, func(txCtx context.Context) error {
+			err := r.restoreTaxonomy(txCtx, item)
+			if err != nil {
+				return err
+			}
+			recycleItemDAL := dal.GetQueryByCtx(txCtx).RecycleItem
+			_, err = recycleItemDAL.WithContext(txCtx).Where(recycleItemDAL.ID.Eq(item.ID)).Delete()
+			return WrapDBErr(err)
+		})
+		if err != nil {
+			return err
+		}
+		invalidateContentCache(ctx)
+		return nil
+	}
+}
+
+// restoreTaxonomy 按快照重建分类或标签，原 ID 被占用时使用新 ID，名称或 slug 被占用时无法恢复
+func (r *recycleServiceImpl) restoreTaxonomy(ctx context.Context, item *entity.RecycleItem) error {
+	snapshot := &recycleSnapshot{}
+	err := json.Unmarshal([]byte(item.Snapshot), snapshot)
+	if err != nil {
+		return xerr.NoType.Wrap(err).WithMsg("parse recycle snapshot failed")
+	}
+	query := dal.GetQueryByCtx(ctx)
+	postIDs := make([]int32, 0, len(snapshot.PostIDs))
+	if len(snapshot.PostIDs) > 0 {
+		err = query.Post.WithContext(ctx).Where(query.Post.ID.In(snapshot.PostIDs...)).Pluck(query.Post.ID, &postIDs)
+		if err != nil {
+			return WrapDBErr(err)
+		}
+	}
+	conflictErr := xerr.BadParam.New("recycle item id=%v conflicts", item.ID).WithMsg("name or slug is used by another one, rename it before restoring").WithStatus(xerr.StatusConflict)
+	now := time.Now()
+
+	if item.Type == consts.RecycleTypeCategory && snapshot.Category != nil {
+		categoryDAL := query.Category
+		category := snapshot.Category
+		count, err := categoryDAL.WithContext(ctx).Where(field.Or(categoryDAL.Name.Eq(category.Name), categoryDAL.Slug.Eq(category.Slug))).Count()
+		if err != nil {
+			return WrapDBErr(err)
+		}
+		if count > 0 {
+			return conflictErr
+		}
+		count, err = categoryDAL.WithContext(ctx).Where(categoryDAL.ID.Eq(category.ID)).Count()
+		if err != nil {
+			return WrapDBErr(err)
+		}
+		if count > 0 {
+			category.ID = 0
+		}
+		err = categoryDAL.WithContext(ctx).Create(category)
+		if err != nil {
+			return WrapDBErr(err)
+		}
+		postCategories := make([]*entity.PostCategory, 0, len(postIDs))
+		for _, postID := range postIDs {
+			postCategories = append(postCategories, &entity.PostCategory{CreateTime: now, PostID: postID, CategoryID: category.ID})
+		}
+		return WrapDBErr(query.PostCategory.WithContext(ctx).CreateInBatches(postCategories, 100))
+	}
+	if item.Type == consts.RecycleTypeTag && snapshot.Tag != nil {
+		tagDAL := query.Tag
+		tag := snapshot.Tag
+		count, err := tagDAL.WithContext(ctx).Where(field.Or(tagDAL.Name.Eq(tag.Name), tagDAL.Slug.Eq(tag.Slug))).Count()
+		if err != nil {
+			return WrapDBErr(err)
+		}
+		if count > 0 {
+			return conflictErr
+		}
+		count, err = tagDAL.WithContext(ctx).Where(tagDAL.ID.Eq(tag.ID)).Count()
+		if err != nil {
+			return WrapDBErr(err)
+		}
+		if count > 0 {
+			tag.ID = 0
+		}
+		err = tagDAL.WithContext(ctx).Create(tag)
+		if err != nil {
+			return WrapDBErr(err)
+		}
+		postTags := make([]*entity.PostTag, 0, len(postIDs))
+		for _, postID := range postIDs {
+			postTags = append(postTags, &entity.PostTag{CreateTime: now, PostID: postID, TagID: tag.ID})
+		}
+		return WrapDBErr(query.PostTag.WithContext(ctx).CreateInBatches(postTags, 100))
+	}
+	return xerr.NoType.New("recycle item id=%v has no snapshot", item.ID).WithMsg("recycle snapshot is broken")
+}
+
+func (r *recycleServiceImpl) Purge(ctx context.Context, id int32) error {
+	item, err := r.getItemByID(ctx, id)
+	if err != nil {
+		return err
+	}
+	return r.purgeItem(ctx, item)
+}
+
+func (r *recycleServiceImpl) purgeItem(ctx context.Context, item *entity.RecycleItem) error {
+	if item.Type == consts.RecycleTypePost || item.Type == consts.RecycleTypeSheet {
+		// 文章的回收站记录在 DeleteByID 中一起删除
+		err := r.BasePostService.DeleteByID(ctx, item.TargetID)
+		if xerr.GetType(err) != xerr.NoRecord {
+			return err
+		}
+	}
+	recycleItemDAL := dal.GetQueryByCtx(ctx).RecycleItem
+	_, err := recycleItemDAL.WithContext(ctx).Where(recycleItemDAL.ID.Eq(item.ID)).Delete()
+	return WrapDBErr(err)
+}
+
+func (r *recycleServiceImpl) PurgeAll(ctx context.Context) (int64, error) {
+	return r.purgeBefore(ctx, time.Now().Add(time.Second))
+}
+
+func (r *recycleServiceImpl) PurgeExpired(ctx context.Context) (int64, error) {
+	retentionDays := r.OptionService.GetOrByDefault(ctx, property.RecycleRetentionDays).(int)
+	if retentionDays <= 0 {
+		return 0, nil
+	}
+	return r.purgeBefore(ctx, time.Now().AddDate(0, 0, -retentionDays))
+}
+
+func (r *recycleServiceImpl) purgeBefore(ctx context.Context, before time.Time) (int64, error) {
+	recycleItemDAL := dal.GetQueryByCtx(ctx).RecycleItem
+	items, err := recycleItemDAL.WithContext(ctx).Where(recycleItemDAL.CreateTime.Lt(before)).Find()
+	if err != nil {
+		return 0, WrapDBErr(err)
+	}
+	var count int64
+	for _, item := range items {
+		// 一条内容删除失败时跳过，不影响其余内容的清理
+		err = r.purgeItem(ctx, item)
+		if err != nil {
+			r.Logger.Error("purge recycle item", zap.Int32("id", item.ID), zap.Int32("targetID", item.TargetID), zap.Error(err))
+			continue
+		}
+		count++
+	}
+	return count, nil
+}
+
+func (r *recycleServiceImpl) getItemByID(ctx context.Context, id int32) (*entity.RecycleItem, error) {
+	recycleItemDAL := dal.GetQueryByCtx(ctx).RecycleItem
+	item, err := recycleItemDAL.WithContext(ctx).Where(recycleItemDAL.ID.Eq(id)).First()
+	if err != nil {
+		return nil, WrapDBErr(err)
+	}
+	return item, nil
+}
+
+func (r *recycleServiceImpl) ConvertToRecycleItemDTOs(ctx context.Context, items []*entity.RecycleItem) []*dto.RecycleItem {
+	itemDTOs := make([]*dto.RecycleItem, 0, len(items))
+	for _, item := range items {
+		itemDTOs = append(itemDTOs, &dto.RecycleItem{
+			ID:         item.ID,
+			Type:       item.Type,
+			TargetID:   item.TargetID,
+			Title:      item.Title,
+			DeletedBy:  item.DeletedBy,
+			DeleteTime: item.CreateTime.UnixMilli(),
+		})
+	}
+	return itemDTOs
+}
+
+// syncRecycleItems 文章状态变化后调用：进入回收站时记录删除时间和操作人，离开回收站时删除记录
+func syncRecycleItems(ctx context.Context, posts []*entity.Post) error {
+	recycleItemDAL := dal.GetQueryByCtx(ctx).RecycleItem
+	postTypes := []driver.Valuer{consts.RecycleTypePost, consts.RecycleTypeSheet}
+	recycledPosts := make([]*entity.Post, 0)
+	recycledIDs := make([]int32, 0)
+	restoredIDs := make([]int32, 0)
+	for _, post := range posts {
+		if post.Status == consts.PostStatusRecycle {
+			recycledPosts = append(recycledPosts, post)
+			recycledIDs = append(recycledIDs, post.ID)
+		} else {
+			restoredIDs = append(restoredIDs, post.ID)
+		}
+	}
+	if len(restoredIDs) > 0 {
+		_, err := recycleItemDAL.WithContext(ctx).Where(recycleItemDAL.Type.In(postTypes...), recycleItemDAL.TargetID.In(restoredIDs...)).Delete()
+		if err != nil {
+			return WrapDBErr(err)
+		}
+	}
+	if len(recycledPosts) == 0 {
+		return nil
+	}
+
+	existIDs := make([]int32, 0)
+	err := recycleItemDAL.WithContext(ctx).Where(recycleItemDAL.Type.In(postTypes...), recycleItemDAL.TargetID.In(recycledIDs...)).Pluck(recycleItemDAL.TargetID, &existIDs)
+	if err != nil {
+		return WrapDBErr(err)
+	}
+	existIDMap := make(map[int32]struct{}, len(existIDs))
+	for _, existID := range existIDs {
+		existIDMap[existID] = struct{}{}
+	}
+	now := time.Now()
+	operator := getOperatorName(ctx)
+	items := make([]*entity.RecycleItem, 0, len(recycledPosts))
+	for _, post := range recycledPosts {
+		if _, ok := existIDMap[post.ID]; ok {
+			continue
+		}
+		recycleType := consts.RecycleTypePost
+		if post.Type == consts.PostTypeSheet {
+			recycleType = consts.RecycleTypeSheet
+		}
+		items = append(items, &entity.RecycleItem{
+			CreateTime: now,
+			Type:       recycleType,
+			TargetID:   post.ID,
+			Title:      post.Title,
+			DeletedBy:  operator,
+		})
+	}
+	if len(items) == 0 {
+		return nil
+	}
+	return WrapDBErr(recycleItemDAL.WithContext(ctx).CreateInBatches(items, 100))
+}
+
+// getOperatorName 当前操作的管理员，定时任务等没有登录用户时返回空字符串
+func getOperatorName(ctx context.Context) string {
+	if user, ok := ctx.Value(consts.AuthorizedUser).(*entity.User); ok {
+		return user.Username
+	}
+	return ""
+}
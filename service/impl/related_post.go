@@ -0,0 +1,290 @@
+package impl
+
+import (
+	"context"
+	"dash/cache"
+	"dash/consts"
+	"dash/dal"
+	"dash/model/entity"
+	"dash/service"
+	"encoding/json"
+	"errors"
+	"math"
+	"sort"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+	"go.uber.org/zap"
+)
+
+const (
+	// relatedCacheSize 每篇文章缓存的相关文章数量，查询时再按需要的数量截取
+	relatedCacheSize       = 20
+	relatedCacheExpiration = 6 * time.Hour
+	// relatedKeywordCount 取文章 TF-IDF 权重最高的多少个词计算正文相似度
+	relatedKeywordCount   = 20
+	relatedTagWeight      = 2.0
+	relatedCategoryWeight = 1.0
+	// relatedTermBatchSize 按词查询索引时每批的数量，避免 IN 参数过多
+	relatedTermBatchSize = 500
+)
+
+type relatedPostServiceImpl struct {
+	Logger *zap.Logger
+}
+
+func NewRelatedPostService(logger *zap.Logger) service.RelatedPostService {
+	return &relatedPostServiceImpl{
+		Logger: logger,
+	}
+}
+
+func (r *relatedPostServiceImpl) ListRelatedPosts(ctx context.Context, post *entity.Post, size int, excludeCategoryIDs []int32) ([]*entity.Post, error) {
+	if size <= 0 {
+		return make([]*entity.Post, 0), nil
+	}
+	relatedIDs, err := r.getRelatedIDs(ctx, post)
+	if err != nil {
+		return nil, err
+	}
+	if len(relatedIDs) == 0 {
+		return make([]*entity.Post, 0), nil
+	}
+
+	// 缓存中的文章可能已经下线，查询时再按状态和隐藏分类过滤一次
+	query := dal.GetQueryByCtx(ctx)
+	postDAL := query.Post
+	postCategoryDAL := query.PostCategory
+	postDO := postDAL.WithContext(ctx).Where(postDAL.ID.In(relatedIDs...), postDAL.Status.Eq(consts.PostStatusPublished), postDAL.Type.Eq(consts.PostTypePost))
+	if len(excludeCategoryIDs) > 0 {
+		excludePostIDs := postCategoryDAL.WithContext(ctx).Where(postCategoryDAL.CategoryID.In(excludeCategoryIDs...)).Select(postCategoryDAL.PostID)
+		postDO = postDO.Where(postDAL.WithContext(ctx).Columns(postDAL.ID).NotIn(excludePostIDs))
+	}
+	posts, err := postDO.Find()
+	if err != nil {
+		return nil, WrapDBErr(err)
+	}
+	postMap := make(map[int32]*entity.Post, len(posts))
+	for _, relatedPost := range posts {
+		postMap[relatedPost.ID] = relatedPost
+	}
+	relatedPosts := make([]*entity.Post, 0, size)
+	for _, relatedID := range relatedIDs {
+		if relatedPost, ok := postMap[relatedID]; ok {
+			relatedPosts = append(relatedPosts, relatedPost)
+			if len(relatedPosts) == size {
+				break
+			}
+		}
+	}
+	return relatedPosts, nil
+}
+
+func (r *relatedPostServiceImpl) Invalidate(ctx context.Context) {
+	err := cache.Redis.Incr(ctx, consts.PostRelatedVersionKey).Err()
+	if err != nil {
+		r.Logger.Error("invalidate related posts", zap.Error(err))
+	}
+}
+
+func (r *relatedPostServiceImpl) getRelatedIDs(ctx context.Context, post *entity.Post) ([]int32, error) {
+	version, err := cache.Redis.Get(ctx, consts.PostRelatedVersionKey).Int64()
+	if err != nil && !errors.Is(err, redis.Nil) {
+		r.Logger.Error("get related posts version", zap.Error(err))
+		return r.computeRelatedIDs(ctx, post)
+	}
+	key := cache.BuildPostRelatedKey(version, post.ID)
+	relatedIDs := make([]int32, 0)
+	value, err := cache.Redis.Get(ctx, key).Bytes()
+	if err == nil && json.Unmarshal(value, &relatedIDs) == nil {
+		return relatedIDs, nil
+	}
+
+	relatedIDs, err = r.computeRelatedIDs(ctx, post)
+	if err != nil {
+		return nil, err
+	}
+	value, err = json.Marshal(relatedIDs)
+	if err == nil {
+		err = cache.Redis.Set(ctx, key, value, relatedCacheExpiration).Err()
+	}
+	if err != nil {
+		r.Logger.Error("cache related posts", zap.Int32("postID", post.ID), zap.Error(err))
+	}
+	return relatedIDs, nil
+}
+
+// computeRelatedIDs 相关度 = (相同标签数 * 2 + 相同分类数) * (1 + 正文相似度) + 正文相似度
+func (r *relatedPostServiceImpl) computeRelatedIDs(ctx context.Context, post *entity.Post) ([]int32, error) {
+	taxonomyScores, err := r.computeTaxonomyScores(ctx, post.ID)
+	if err != nil {
+		return nil, err
+	}
+	contentScores, err := r.computeContentScores(ctx, post.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	candidateIDs := make([]int32, 0, len(taxonomyScores)+len(contentScores))
+	for postID := range taxonomyScores {
+		candidateIDs = append(candidateIDs, postID)
+	}
+	for postID := range contentScores {
+		if _, ok := taxonomyScores[postID]; !ok {
+			candidateIDs = append(candidateIDs, postID)
+		}
+	}
+	if len(candidateIDs) == 0 {
+		return candidateIDs, nil
+	}
+	postDAL := dal.GetQueryByCtx(ctx).Post
+	publishedIDs := make([]int32, 0)
+	err = postDAL.WithContext(ctx).
+		Where(postDAL.ID.In(candidateIDs...), postDAL.Status.Eq(consts.PostStatusPublished), postDAL.Type.Eq(consts.PostTypePost)).
+		Pluck(postDAL.ID, &publishedIDs)
+	if err != nil {
+		return nil, WrapDBErr(err)
+	}
+
+	scores := make(map[int32]float64, len(publishedIDs))
+	for _, postID := range publishedIDs {
+		similarity := contentScores[postID]
+		scores[postID] = taxonomyScores[postID]*(1+similarity) + similarity
+	}
+	sort.Slice(publishedIDs, func(i, j int) bool {
+		if scores[publishedIDs[i]] != scores[publishedIDs[j]] {
+			return scores[publishedIDs[i]] > scores[publishedIDs[j]]
+		}
+		return publishedIDs[i] > publishedIDs[j]
+	})
+	if len(publishedIDs) > relatedCacheSize {
+		publishedIDs = publishedIDs[:relatedCacheSize]
+	}
+	return publishedIDs, nil
+}
+
+// computeTaxonomyScores 统计其他文章和这篇文章相同的标签和分类
+func (r *relatedPostServiceImpl) computeTaxonomyScores(ctx context.Context, postID int32) (map[int32]float64, error) {
+	query := dal.GetQueryByCtx(ctx)
+	postTagDAL := query.PostTag
+	postCategoryDAL := query.PostCategory
+	scores := make(map[int32]float64)
+
+	sharedCounts := make([]*struct {
+		PostID int32
+		Shared int64
+	}, 0)
+	tagIDs := postTagDAL.WithContext(ctx).Where(postTagDAL.PostID.Eq(postID)).Select(postTagDAL.TagID)
+	err := postTagDAL.WithContext(ctx).
+		Select(postTagDAL.PostID, postTagDAL.ID.Count().As("shared")).
+		Where(postTagDAL.WithContext(ctx).Columns(postTagDAL.TagID).In(tagIDs), postTagDAL.PostID.Neq(postID)).
+		Group(postTagDAL.PostID).
+		Scan(&sharedCounts)
+	if err != nil {
+		return nil, WrapDBErr(err)
+	}
+	for _, sharedCount := range sharedCounts {
+		scores[sharedCount.PostID] += relatedTagWeight * float64(sharedCount.Shared)
+	}
+
+	sharedCounts = sharedCounts[:0]
+	categoryIDs := postCategoryDAL.WithContext(ctx).Where(postCategoryDAL.PostID.Eq(postID)).Select(postCategoryDAL.CategoryID)
+	err = postCategoryDAL.WithContext(ctx).
+		Select(postCategoryDAL.PostID, postCategoryDAL.ID.Count().As("shared")).
+		Where(postCategoryDAL.WithContext(ctx).Columns(postCategoryDAL.CategoryID).In(categoryIDs), postCategoryDAL.PostID.Neq(postID)).
+		Group(postCategoryDAL.PostID).
+		Scan(&sharedCounts)
+	if err != nil {
+		return nil, WrapDBErr(err)
+	}
+	for _, sharedCount := range sharedCounts {
+		scores[sharedCount.PostID] += relatedCategoryWeight * float64(sharedCount.Shared)
+	}
+	return scores, nil
+}
+
+// computeContentScores 用搜索索引计算正文的余弦相似度，只比较这篇文章权重最高的几个词
+func (r *relatedPostServiceImpl) computeContentScores(ctx context.Context, postID int32) (map[int32]float64, error) {
+	query := dal.GetQueryByCtx(ctx)
+	postSearchIndexDAL := query.PostSearchIndex
+	scores := make(map[int32]float64)
+
+	indexes, err := postSearchIndexDAL.WithContext(ctx).Where(postSearchIndexDAL.PostID.Eq(postID)).Find()
+	if err != nil {
+		return nil, WrapDBErr(err)
+	}
+	if len(indexes) == 0 {
+		return scores, nil
+	}
+	postCount, err := query.Post.WithContext(ctx).Where(query.Post.Type.Eq(consts.PostTypePost)).Count()
+	if err != nil {
+		return nil, WrapDBErr(err)
+	}
+
+	terms := make([]string, 0, len(indexes))
+	for _, index := range indexes {
+		terms = append(terms, index.Term)
+	}
+	docFreq := make(map[string]int64, len(terms))
+	for start := 0; start < len(terms); start += relatedTermBatchSize {
+		termCounts := make([]*struct {
+			Term    string
+			DocFreq int64
+		}, 0)
+		err = postSearchIndexDAL.WithContext(ctx).
+			Select(postSearchIndexDAL.Term, postSearchIndexDAL.ID.Count().As("doc_freq")).
+			Where(postSearchIndexDAL.Term.In(terms[start:min(start+relatedTermBatchSize, len(terms))]...)).
+			Group(postSearchIndexDAL.Term).
+			Scan(&termCounts)
+		if err != nil {
+			return nil, WrapDBErr(err)
+		}
+		for _, termCount := range termCounts {
+			docFreq[termCount.Term] = termCount.DocFreq
+		}
+	}
+
+	weight := func(index *entity.PostSearchIndex) float64 {
+		freq := float64(index.TitleFreq)*searchTitleBoost + float64(index.ContentFreq)
+		if freq <= 0 || docFreq[index.Term] == 0 {
+			return 0
+		}
+		return (1 + math.Log(freq)) * math.Log(1+float64(postCount)/float64(docFreq[index.Term]))
+	}
+	sort.Slice(indexes, func(i, j int) bool {
+		return weight(indexes[i]) > weight(indexes[j])
+	})
+	if len(indexes) > relatedKeywordCount {
+		indexes = indexes[:relatedKeywordCount]
+	}
+	keywords := make([]string, 0, len(indexes))
+	keywordWeights := make(map[string]float64, len(indexes))
+	var norm float64
+	for _, index := range indexes {
+		w := weight(index)
+		keywords = append(keywords, index.Term)
+		keywordWeights[index.Term] = w
+		norm += w * w
+	}
+	if norm == 0 {
+		return scores, nil
+	}
+
+	postings, err := postSearchIndexDAL.WithContext(ctx).Where(postSearchIndexDAL.Term.In(keywords...), postSearchIndexDAL.PostID.Neq(postID)).Find()
+	if err != nil {
+		return nil, WrapDBErr(err)
+	}
+	dots := make(map[int32]float64)
+	norms := make(map[int32]float64)
+	for _, posting := range postings {
+		w := weight(posting)
+		dots[posting.PostID] += w * keywordWeights[posting.Term]
+		norms[posting.PostID] += w * w
+	}
+	for candidateID, dot := range dots {
+		if norms[candidateID] > 0 {
+			scores[candidateID] = dot / (math.Sqrt(norm) * math.Sqrt(norms[candidateID]))
+		}
+	}
+	return scores, nil
+}
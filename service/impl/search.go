@@ -0,0 +1,276 @@
+package impl
+
+import (
+	"context"
+	"dash/consts"
+	"dash/dal"
+	"dash/model/dto"
+	"dash/model/entity"
+	"dash/model/param"
+	"dash/service"
+	"dash/utils"
+	"dash/utils/xerr"
+	"database/sql/driver"
+	"math"
+	"sort"
+	"strings"
+
+	"gorm.io/gen"
+	"gorm.io/gen/field"
+)
+
+const (
+	// searchTitleBoost 标题中命中的词相对正文的权重
+	searchTitleBoost = 5.0
+	// searchMaxTerms 关键词最多切分出的查询词数量
+	searchMaxTerms = 16
+	// searchSnippetLength 搜索结果摘要的长度（字符）
+	searchSnippetLength = 120
+	// searchBatchSize 重建索引时每批读取的文章数量
+	searchBatchSize = 100
+)
+
+type searchServiceImpl struct{}
+
+func NewSearchService() service.SearchService {
+	return &searchServiceImpl{}
+}
+
+func (s *searchServiceImpl) IndexPost(ctx context.Context, post *entity.Post) error {
+	err := s.DeleteIndexByPostID(ctx, post.ID)
+	if err != nil || post.Type != consts.PostTypePost {
+		return err
+	}
+	titleTerms := utils.IndexTerms(post.Title)
+	contentTerms := utils.IndexTerms(utils.HTMLToText(post.FormatContent))
+	indexes := make([]*entity.PostSearchIndex, 0, len(titleTerms)+len(contentTerms))
+	for term, freq := range titleTerms {
+		indexes = append(indexes, &entity.PostSearchIndex{
+			PostID:      post.ID,
+			Term:        term,
+			TitleFreq:   int32(freq),
+			ContentFreq: int32(contentTerms[term]),
+		})
+	}
+	for term, freq := range contentTerms {
+		if _, ok := titleTerms[term]; ok {
+			continue
+		}
+		indexes = append(indexes, &entity.PostSearchIndex{
+			PostID:      post.ID,
+			Term:        term,
+			ContentFreq: int32(freq),
+		})
+	}
+	if len(indexes) == 0 {
+		return nil
+	}
+	postSearchIndexDAL := dal.GetQueryByCtx(ctx).PostSearchIndex
+	return WrapDBErr(postSearchIndexDAL.WithContext(ctx).CreateInBatches(indexes, 500))
+}
+
+func (s *searchServiceImpl) DeleteIndexByPostID(ctx context.Context, postID int32) error {
+	postSearchIndexDAL := dal.GetQueryByCtx(ctx).PostSearchIndex
+	_, err := postSearchIndexDAL.WithContext(ctx).Where(postSearchIndexDAL.PostID.Eq(postID)).Delete()
+	return WrapDBErr(err)
+}
+
+func (s *searchServiceImpl) RebuildIndex(ctx context.Context) (int64, error) {
+	var count int64
+	err := dal.Transaction(ctx, func(txCtx context.Context) error {
+		query := dal.GetQueryByCtx(txCtx)
+		postDAL := query.Post
+		postSearchIndexDAL := query.PostSearchIndex
+
+		_, err := postSearchIndexDAL.WithContext(txCtx).Where(postSearchIndexDAL.ID.Gt(0)).Delete()
+		if err != nil {
+			return WrapDBErr(err)
+		}
+		var lastID int32
+		for {
+			posts, err := postDAL.WithContext(txCtx).
+				Where(postDAL.ID.Gt(lastID), postDAL.Type.Eq(consts.PostTypePost)).
+				Order(postDAL.ID).
+				Limit(searchBatchSize).
+				Find()
+			if err != nil {
+				return WrapDBErr(err)
+			}
+			if len(posts) == 0 {
+				return nil
+			}
+			for _, post := range posts {
+				lastID = post.ID
+				err = s.IndexPost(txCtx, post)
+				if err != nil {
+					return err
+				}
+				count++
+			}
+		}
+	})
+	if err != nil {
+		return 0, err
+	}
+	return count, nil
+}
+
+func (s *searchServiceImpl) Search(ctx context.Context, searchQuery param.SearchQuery) ([]*dto.SearchHit, int64, error) {
+	if searchQuery.PageNum < 0 || searchQuery.PageSize < 0 {
+		return nil, 0, xerr.BadParam.New("").WithStatus(xerr.StatusBadRequest).WithMsg("Paging parameter error")
+	}
+	terms := utils.QueryTerms(searchQuery.Keyword)
+	if len(terms) == 0 {
+		return make([]*dto.SearchHit, 0), 0, nil
+	}
+	if len(terms) > searchMaxTerms {
+		terms = terms[:searchMaxTerms]
+	}
+
+	query := dal.GetQueryByCtx(ctx)
+	postDAL := query.Post
+	postSearchIndexDAL := query.PostSearchIndex
+	visibleConds := s.buildVisibleConditions(ctx, searchQuery.Statuses, searchQuery.ExcludeCategoryIDs, searchQuery.ExcludeProtected)
+
+	postCount, err := postDAL.WithContext(ctx).Where(visibleConds...).Count()
+	if err != nil {
+		return nil, 0, WrapDBErr(err)
+	}
+	indexes, err := postSearchIndexDAL.WithContext(ctx).
+		Select(postSearchIndexDAL.ALL).
+		Join(&entity.Post{}, postSearchIndexDAL.PostID.EqCol(postDAL.ID)).
+		Where(postSearchIndexDAL.Term.In(terms...)).
+		Where(visibleConds...).
+		Find()
+	if err != nil {
+		return nil, 0, WrapDBErr(err)
+	}
+
+	// tf-idf：词在越少的文章中出现权重越高，词频取对数避免长文章占优
+	docFreq := make(map[string]int, len(terms))
+	for _, index := range indexes {
+		docFreq[index.Term]++
+	}
+	termFreq := func(freq int32) float64 {
+		if freq <= 0 {
+			return 0
+		}
+		return 1 + math.Log(float64(freq))
+	}
+	scores := make(map[int32]float64)
+	matchedTerms := make(map[int32]int)
+	for _, index := range indexes {
+		idf := math.Log(1 + float64(postCount)/float64(docFreq[index.Term]))
+		scores[index.PostID] += idf * (searchTitleBoost*termFreq(index.TitleFreq) + termFreq(index.ContentFreq))
+		matchedTerms[index.PostID]++
+	}
+	// 所有查询词都命中的文章才算匹配
+	hits := make([]*dto.SearchHit, 0)
+	for postID, score := range scores {
+		if matchedTerms[postID] == len(terms) {
+			hits = append(hits, &dto.SearchHit{PostID: postID, Score: score})
+		}
+	}
+	sort.Slice(hits, func(i, j int) bool {
+		if hits[i].Score != hits[j].Score {
+			return hits[i].Score > hits[j].Score
+		}
+		return hits[i].PostID > hits[j].PostID
+	})
+
+	total := int64(len(hits))
+	if searchQuery.PageSize > 0 {
+		start := min(searchQuery.PageNum*searchQuery.PageSize, len(hits))
+		end := min(start+searchQuery.PageSize, len(hits))
+		hits = hits[start:end]
+	}
+	if len(hits) == 0 {
+		return hits, total, nil
+	}
+
+	postIDs := make([]int32, 0, len(hits))
+	for _, hit := range hits {
+		postIDs = append(postIDs, hit.PostID)
+	}
+	posts, err := postDAL.WithContext(ctx).Where(postDAL.ID.In(postIDs...)).Find()
+	if err != nil {
+		return nil, 0, WrapDBErr(err)
+	}
+	postMap := make(map[int32]*entity.Post, len(posts))
+	for _, post := range posts {
+		postMap[post.ID] = post
+	}
+	highlightTerms := s.buildHighlightTerms(searchQuery.Keyword, terms)
+	for _, hit := range hits {
+		post, ok := postMap[hit.PostID]
+		if !ok {
+			continue
+		}
+		hit.TitleHighlight = utils.Highlight(post.Title, highlightTerms, 0)
+		hit.Snippet = utils.Highlight(strings.Join(strings.Fields(utils.HTMLToText(post.FormatContent)), " "), highlightTerms, searchSnippetLength)
+	}
+	return hits, total, nil
+}
+
+func (s *searchServiceImpl) Suggest(ctx context.Context, suggestQuery param.SuggestQuery) ([]*dto.SearchSuggestion, error) {
+	prefix := strings.ToLower(strings.TrimSpace(suggestQuery.Prefix))
+	// LIKE 的通配符不能出现在前缀中
+	prefix = strings.NewReplacer("%", "", "_", "", "\\", "").Replace(prefix)
+	if prefix == "" {
+		return make([]*dto.SearchSuggestion, 0), nil
+	}
+	size := suggestQuery.Size
+	if size <= 0 || size > 20 {
+		size = 10
+	}
+
+	query := dal.GetQueryByCtx(ctx)
+	postSearchIndexDAL := query.PostSearchIndex
+	suggestions := make([]*dto.SearchSuggestion, 0)
+	err := postSearchIndexDAL.WithContext(ctx).
+		Select(postSearchIndexDAL.Term, postSearchIndexDAL.PostID.Count().As("post_count")).
+		Join(&entity.Post{}, postSearchIndexDAL.PostID.EqCol(query.Post.ID)).
+		Where(postSearchIndexDAL.Term.Like(prefix+"%"), postSearchIndexDAL.Term.Neq(prefix)).
+		Where(s.buildVisibleConditions(ctx, suggestQuery.Statuses, suggestQuery.ExcludeCategoryIDs, suggestQuery.ExcludeProtected)...).
+		Group(postSearchIndexDAL.Term).
+		Order(field.NewInt64("", "post_count").Desc(), postSearchIndexDAL.Term).
+		Limit(size).
+		Scan(&suggestions)
+	if err != nil {
+		return nil, WrapDBErr(err)
+	}
+	return suggestions, nil
+}
+
+// buildVisibleConditions 只在访问者可见的文章中搜索
+func (s *searchServiceImpl) buildVisibleConditions(ctx context.Context, statuses []consts.PostStatus, excludeCategoryIDs []int32, excludeProtected bool) []gen.Condition {
+	query := dal.GetQueryByCtx(ctx)
+	postDAL := query.Post
+	postCategoryDAL := query.PostCategory
+
+	conditions := []gen.Condition{postDAL.Type.Eq(consts.PostTypePost)}
+	if len(statuses) > 0 {
+		statusValues := make([]driver.Valuer, 0, len(statuses))
+		for _, status := range statuses {
+			statusValues = append(statusValues, status)
+		}
+		conditions = append(conditions, postDAL.Status.In(statusValues...))
+	}
+	if len(excludeCategoryIDs) > 0 {
+		excludePostIDs := postCategoryDAL.WithContext(ctx).Where(postCategoryDAL.CategoryID.In(excludeCategoryIDs...)).Select(postCategoryDAL.PostID)
+		conditions = append(conditions, postDAL.WithContext(ctx).Columns(postDAL.ID).NotIn(excludePostIDs))
+	}
+	if excludeProtected {
+		conditions = append(conditions, postDAL.Password.Eq(""))
+	}
+	return conditions
+}
+
+// buildHighlightTerms 高亮时优先标出完整的中日韩关键词，再标出切分后的查询词
+func (s *searchServiceImpl) buildHighlightTerms(keyword string, terms []string) []string {
+	highlightTerms := make([]string, 0, len(terms))
+	for _, word := range strings.Fields(strings.ToLower(keyword)) {
+		highlightTerms = append(highlightTerms, word)
+	}
+	return append(highlightTerms, terms...)
+}
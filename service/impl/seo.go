@@ -0,0 +1,377 @@
+package impl
+
+import (
+	"bytes"
+	"context"
+	"dash/consts"
+	"dash/model/dto"
+	"dash/model/entity"
+	"dash/model/property"
+	"dash/service"
+	"dash/utils/xerr"
+	"encoding/json"
+	"html/template"
+	"regexp"
+	"strings"
+	"time"
+)
+
+type seoServiceImpl struct {
+	OptionService       service.OptionService
+	PermalinkService    service.PermalinkService
+	VisibilityService   service.VisibilityService
+	CategoryService     service.CategoryService
+	TagService          service.TagService
+	PostTagService      service.PostTagService
+	PostCategoryService service.PostCategoryService
+	UserService         service.UserService
+}
+
+func NewSEOService(
+	optionService service.OptionService,
+	permalinkService service.PermalinkService,
+	visibilityService service.VisibilityService,
+	categoryService service.CategoryService,
+	tagService service.TagService,
+	postTagService service.PostTagService,
+	postCategoryService service.PostCategoryService,
+	userService service.UserService,
+) service.SEOService {
+	return &seoServiceImpl{
+		OptionService:       optionService,
+		PermalinkService:    permalinkService,
+		VisibilityService:   visibilityService,
+		CategoryService:     categoryService,
+		TagService:          tagService,
+		PostTagService:      postTagService,
+		PostCategoryService: postCategoryService,
+		UserService:         userService,
+	}
+}
+
+// pageMetaTemplate html/template 会按属性和 URL 的上下文转义，标题、摘要中的引号和尖括号不会破坏页面
+var pageMetaTemplate = template.Must(template.New("page_meta").Parse(`<title>{{.Title}}</title>
+  {{- if .Description}}
+  <meta name="description" content="{{.Description}}" />
+  {{- end}}
+  {{- if .Keywords}}
+  <meta name="keywords" content="{{.Keywords}}" />
+  {{- end}}
+  <link rel="canonical" href="{{.CanonicalURL}}" />
+  <meta property="og:type" content="{{.Type}}" />
+  <meta property="og:title" content="{{.Title}}" />
+  {{- if .Description}}
+  <meta property="og:description" content="{{.Description}}" />
+  {{- end}}
+  <meta property="og:url" content="{{.CanonicalURL}}" />
+  {{- if .SiteName}}
+  <meta property="og:site_name" content="{{.SiteName}}" />
+  {{- end}}
+  {{- if .Image}}
+  <meta property="og:image" content="{{.Image}}" />
+  {{- end}}
+  {{- if .PublishedTime}}
+  <meta property="article:published_time" content="{{.PublishedTime}}" />
+  {{- end}}
+  {{- if .ModifiedTime}}
+  <meta property="article:modified_time" content="{{.ModifiedTime}}" />
+  {{- end}}
+  {{- if .Author}}
+  <meta property="article:author" content="{{.Author}}" />
+  {{- end}}
+  {{- range .Tags}}
+  <meta property="article:tag" content="{{.}}" />
+  {{- end}}
+  <meta name="twitter:card" content="{{if .Image}}summary_large_image{{else}}summary{{end}}" />
+  <meta name="twitter:title" content="{{.Title}}" />
+  {{- if .Description}}
+  <meta name="twitter:description" content="{{.Description}}" />
+  {{- end}}
+  {{- if .Image}}
+  <meta name="twitter:image" content="{{.Image}}" />
+  {{- end}}
+  {{- if .JSONLD}}
+  <script type="application/ld+json">{{.JSONLD}}</script>
+  {{- end}}
+`))
+
+var (
+	titleTagRegexp = regexp.MustCompile(`(?is)<title>.*?</title>`)
+	headEndRegexp  = regexp.MustCompile(`(?i)</head>`)
+)
+
+// seoContext 生成各类页面 meta 都需要的博客设置
+type seoContext struct {
+	blogTitle   string
+	blogURL     string
+	description string
+	keywords    string
+}
+
+func (s *seoServiceImpl) getSEOContext(ctx context.Context) (*seoContext, error) {
+	blogTitle, err := s.OptionService.GetOrByDefaultWithErr(ctx, property.BlogTitle, property.BlogTitle.DefaultValue)
+	if err != nil {
+		return nil, err
+	}
+	blogURL, err := s.OptionService.GetBlogBaseURL(ctx)
+	if err != nil {
+		return nil, err
+	}
+	description, err := s.OptionService.GetOrByDefaultWithErr(ctx, property.SeoDescription, property.SeoDescription.DefaultValue)
+	if err != nil {
+		return nil, err
+	}
+	keywords, err := s.OptionService.GetOrByDefaultWithErr(ctx, property.SeoKeywords, property.SeoKeywords.DefaultValue)
+	if err != nil {
+		return nil, err
+	}
+	return &seoContext{
+		blogTitle:   blogTitle.(string),
+		blogURL:     strings.TrimRight(blogURL, "/"),
+		description: description.(string),
+		keywords:    keywords.(string),
+	}, nil
+}
+
+// absoluteURL 站内路径加上博客地址，链接预览要求 og:url、og:image 是完整 URL
+func (c *seoContext) absoluteURL(path string) string {
+	if path == "" || strings.HasPrefix(path, "http://") || strings.HasPrefix(path, "https://") {
+		return path
+	}
+	if !strings.HasPrefix(path, "/") {
+		path = "/" + path
+	}
+	return c.blogURL + path
+}
+
+// pageTitle 内容页面的标题为“内容标题 - 博客标题”
+func (c *seoContext) pageTitle(title string) string {
+	if c.blogTitle == "" {
+		return title
+	}
+	if title == "" {
+		return c.blogTitle
+	}
+	return title + " - " + c.blogTitle
+}
+
+func (c *seoContext) defaultPageMeta(path string) *dto.PageMeta {
+	title := c.blogTitle
+	if title == "" {
+		title = "Dash"
+	}
+	return &dto.PageMeta{
+		Title:        title,
+		Description:  c.description,
+		Keywords:     c.keywords,
+		CanonicalURL: c.absoluteURL(path),
+		SiteName:     c.blogTitle,
+		Type:         "website",
+	}
+}
+
+func (s *seoServiceImpl) GetPageMeta(ctx context.Context, path string) (*dto.PageMeta, error) {
+	seoCtx, err := s.getSEOContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if path == "" {
+		path = "/"
+	}
+	pageMeta, err := s.getContentPageMeta(ctx, seoCtx, path)
+	if xerr.GetType(err) == xerr.NoRecord {
+		return seoCtx.defaultPageMeta(path), nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return pageMeta, nil
+}
+
+// getContentPageMeta 依次按分类、标签、文章和页面的链接格式解析，都不匹配时返回 NoRecord
+func (s *seoServiceImpl) getContentPageMeta(ctx context.Context, seoCtx *seoContext, path string) (*dto.PageMeta, error) {
+	notFound := xerr.NoRecord.New("page path=%v not found", path).WithStatus(xerr.StatusNotFound)
+	segments := strings.Split(strings.Trim(path, "/"), "/")
+	if len(segments) == 2 {
+		categoryPrefix, err := s.OptionService.GetOrByDefaultWithErr(ctx, property.CategoriesPrefix, property.CategoriesPrefix.DefaultValue)
+		if err != nil {
+			return nil, err
+		}
+		if segments[0] == strings.Trim(categoryPrefix.(string), "/") {
+			return s.getCategoryPageMeta(ctx, seoCtx, path, segments[1])
+		}
+		tagPrefix, err := s.OptionService.GetOrByDefaultWithErr(ctx, property.TagsPrefix, property.TagsPrefix.DefaultValue)
+		if err != nil {
+			return nil, err
+		}
+		if segments[0] == strings.Trim(tagPrefix.(string), "/") {
+			return s.getTagPageMeta(ctx, seoCtx, path, segments[1])
+		}
+	}
+
+	post, err := s.PermalinkService.Resolve(ctx, path)
+	if err != nil {
+		if xerr.GetType(err) == xerr.BadParam { // 路径匹配多篇文章时不确定是哪一篇，使用默认信息
+			return nil, notFound
+		}
+		return nil, err
+	}
+	// 草稿、私密文章和私密分类下的文章对游客不可见，不能通过 meta 泄露标题和摘要
+	err = s.VisibilityService.CheckPostVisible(ctx, post)
+	if err != nil {
+		return nil, err
+	}
+	return s.getPostPageMeta(ctx, seoCtx, post)
+}
+
+func (s *seoServiceImpl) getCategoryPageMeta(ctx context.Context, seoCtx *seoContext, path, slug string) (*dto.PageMeta, error) {
+	category, err := s.CategoryService.GetCategoryBySlug(ctx, slug)
+	if err != nil {
+		return nil, err
+	}
+	if category.Type == consts.CategoryTypeIntimate && !s.VisibilityService.IsAdmin(ctx) {
+		return nil, xerr.NoRecord.New("category slug=%v not visible", slug).WithStatus(xerr.StatusNotFound)
+	}
+	pageMeta := seoCtx.defaultPageMeta(path)
+	pageMeta.Title = seoCtx.pageTitle(category.Name)
+	if category.Description != "" {
+		pageMeta.Description = category.Description
+	}
+	pageMeta.Image = seoCtx.absoluteURL(category.Thumbnail)
+	return pageMeta, nil
+}
+
+func (s *seoServiceImpl) getTagPageMeta(ctx context.Context, seoCtx *seoContext, path, slug string) (*dto.PageMeta, error) {
+	tag, err := s.TagService.GetTagBySlug(ctx, slug)
+	if err != nil {
+		return nil, err
+	}
+	pageMeta := seoCtx.defaultPageMeta(path)
+	pageMeta.Title = seoCtx.pageTitle(tag.Name)
+	pageMeta.Image = seoCtx.absoluteURL(tag.Thumbnail)
+	return pageMeta, nil
+}
+
+func (s *seoServiceImpl) getPostPageMeta(ctx context.Context, seoCtx *seoContext, post *entity.Post) (*dto.PageMeta, error) {
+	postPath, err := s.PermalinkService.BuildPath(ctx, post)
+	if err != nil {
+		return nil, err
+	}
+	pageMeta := seoCtx.defaultPageMeta(postPath)
+	pageMeta.Title = seoCtx.pageTitle(post.Title)
+	pageMeta.Image = seoCtx.absoluteURL(post.Thumbnail)
+	// meta_description 为空时使用摘要，摘要在保存文章时已经生成
+	if description := strings.TrimSpace(post.MetaDescription); description != "" {
+		pageMeta.Description = description
+	} else if summary := strings.TrimSpace(post.Summary); summary != "" {
+		pageMeta.Description = summary
+	}
+	if post.Type != consts.PostTypePost {
+		if post.MetaKeywords != "" {
+			pageMeta.Keywords = post.MetaKeywords
+		}
+		return pageMeta, nil
+	}
+
+	tags, err := s.PostTagService.ListTagsByPostID(ctx, post.ID)
+	if err != nil {
+		return nil, err
+	}
+	tagNames := make([]string, 0, len(tags))
+	for _, tag := range tags {
+		tagNames = append(tagNames, tag.Name)
+	}
+	// meta_keywords 为空时使用文章的标签
+	if post.MetaKeywords != "" {
+		pageMeta.Keywords = post.MetaKeywords
+	} else if len(tagNames) > 0 {
+		pageMeta.Keywords = strings.Join(tagNames, ",")
+	}
+	categories, err := s.PostCategoryService.ListCategoriesByPostID(ctx, post.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	pageMeta.Type = "article"
+	pageMeta.Tags = tagNames
+	pageMeta.PublishedTime = post.CreateTime.Format(time.RFC3339)
+	modifiedTime := post.CreateTime
+	if post.UpdateTime != nil {
+		modifiedTime = *post.UpdateTime
+	}
+	pageMeta.ModifiedTime = modifiedTime.Format(time.RFC3339)
+	user, err := s.UserService.GetFirst(ctx)
+	if err != nil && xerr.GetType(err) != xerr.NoRecord {
+		return nil, err
+	}
+	if user != nil {
+		pageMeta.Author = user.Nickname
+	}
+
+	blogPosting := map[string]interface{}{
+		"@context":         "https://schema.org",
+		"@type":            "BlogPosting",
+		"headline":         post.Title,
+		"url":              pageMeta.CanonicalURL,
+		"mainEntityOfPage": map[string]string{"@type": "WebPage", "@id": pageMeta.CanonicalURL},
+		"datePublished":    pageMeta.PublishedTime,
+		"dateModified":     pageMeta.ModifiedTime,
+		"wordCount":        post.WordCount,
+	}
+	if pageMeta.Description != "" {
+		blogPosting["description"] = pageMeta.Description
+	}
+	if pageMeta.Image != "" {
+		blogPosting["image"] = pageMeta.Image
+	}
+	if pageMeta.Keywords != "" {
+		blogPosting["keywords"] = pageMeta.Keywords
+	}
+	if len(categories) > 0 {
+		blogPosting["articleSection"] = categories[0].Name
+	}
+	if pageMeta.Author != "" {
+		blogPosting["author"] = map[string]string{"@type": "Person", "name": pageMeta.Author}
+	}
+	if seoCtx.blogTitle != "" {
+		blogPosting["publisher"] = map[string]string{"@type": "Organization", "name": seoCtx.blogTitle, "url": seoCtx.blogURL}
+	}
+	// json.Marshal 默认转义 <、>、&，内容中的 </script> 不会提前结束脚本
+	jsonLD, err := json.Marshal(blogPosting)
+	if err != nil {
+		return nil, xerr.NoType.Wrap(err).WithMsg("marshal json-ld failed")
+	}
+	pageMeta.JSONLD = string(jsonLD)
+	return pageMeta, nil
+}
+
+func (s *seoServiceImpl) RenderPage(ctx context.Context, html []byte, pageMeta *dto.PageMeta) ([]byte, error) {
+	data := struct {
+		*dto.PageMeta
+		JSONLD template.JS
+	}{
+		PageMeta: pageMeta,
+		JSONLD:   template.JS(pageMeta.JSONLD),
+	}
+	buf := &bytes.Buffer{}
+	err := pageMetaTemplate.Execute(buf, data)
+	if err != nil {
+		return nil, xerr.NoType.Wrap(err).WithMsg("render page meta failed")
+	}
+	head := bytes.TrimRight(buf.Bytes(), "\n")
+
+	if loc := titleTagRegexp.FindIndex(html); loc != nil {
+		result := make([]byte, 0, len(html)+len(head))
+		result = append(result, html[:loc[0]]...)
+		result = append(result, head...)
+		return append(result, html[loc[1]:]...), nil
+	}
+	if loc := headEndRegexp.FindIndex(html); loc != nil {
+		result := make([]byte, 0, len(html)+len(head)+1)
+		result = append(result, html[:loc[0]]...)
+		result = append(result, head...)
+		result = append(result, '\n')
+		return append(result, html[loc[0]:]...), nil
+	}
+	return html, nil
+}
@@ -0,0 +1,282 @@
+package impl
+
+import (
+	"context"
+	"dash/consts"
+	"dash/dal"
+	"dash/model/dto"
+	"dash/model/entity"
+	"dash/model/param"
+	"dash/service"
+	"dash/utils"
+	"dash/utils/xerr"
+	"database/sql/driver"
+	"time"
+)
+
+type seriesServiceImpl struct{}
+
+func NewSeriesService() service.SeriesService {
+	return &seriesServiceImpl{}
+}
+
+// correctSeriesParam slug 为空时由名称生成，名称全是中文时生成的 slug 为空，需要手动填写
+func correctSeriesParam(seriesParam *param.Series) error {
+	if seriesParam.Slug == "" {
+		seriesParam.Slug = utils.Slug(seriesParam.Name)
+	} else {
+		seriesParam.Slug = utils.Slug(seriesParam.Slug)
+	}
+	if seriesParam.Slug == "" {
+		return xerr.BadParam.New("empty slug").WithMsg("series slug is required").WithStatus(xerr.StatusBadRequest)
+	}
+	return nil
+}
+
+func (s *seriesServiceImpl) checkSlug(ctx context.Context, id int32, slug string) error {
+	seriesDAL := dal.GetQueryByCtx(ctx).Series
+	count, err := seriesDAL.WithContext(ctx).Where(seriesDAL.ID.Neq(id), seriesDAL.Slug.Eq(slug)).Count()
+	if err != nil {
+		return WrapDBErr(err)
+	}
+	if count > 0 {
+		return xerr.BadParam.New("slug=%s", slug).WithMsg("series slug has exist already").WithStatus(xerr.StatusBadRequest)
+	}
+	return nil
+}
+
+func (s *seriesServiceImpl) Create(ctx context.Context, seriesParam *param.Series) (*entity.Series, error) {
+	err := correctSeriesParam(seriesParam)
+	if err != nil {
+		return nil, err
+	}
+	err = s.checkSlug(ctx, 0, seriesParam.Slug)
+	if err != nil {
+		return nil, err
+	}
+	series := &entity.Series{
+		CreateTime:  time.Now(),
+		Name:        seriesParam.Name,
+		Slug:        seriesParam.Slug,
+		Description: seriesParam.Description,
+		Thumbnail:   seriesParam.Thumbnail,
+	}
+	seriesDAL := dal.GetQueryByCtx(ctx).Series
+	err = seriesDAL.WithContext(ctx).Create(series)
+	if err != nil {
+		return nil, WrapDBErr(err)
+	}
+	return series, nil
+}
+
+func (s *seriesServiceImpl) UpdateByID(ctx context.Context, id int32, seriesParam *param.Series) (*entity.Series, error) {
+	err := correctSeriesParam(seriesParam)
+	if err != nil {
+		return nil, err
+	}
+	_, err = s.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	err = s.checkSlug(ctx, id, seriesParam.Slug)
+	if err != nil {
+		return nil, err
+	}
+	seriesDAL := dal.GetQueryByCtx(ctx).Series
+	_, err = seriesDAL.WithContext(ctx).Where(seriesDAL.ID.Eq(id)).UpdateSimple(
+		seriesDAL.UpdateTime.Value(time.Now()),
+		seriesDAL.Name.Value(seriesParam.Name),
+		seriesDAL.Slug.Value(seriesParam.Slug),
+		seriesDAL.Description.Value(seriesParam.Description),
+		seriesDAL.Thumbnail.Value(seriesParam.Thumbnail),
+	)
+	if err != nil {
+		return nil, WrapDBErr(err)
+	}
+	return s.GetByID(ctx, id)
+}
+
+// DeleteByID 只删除系列，文章保留
+func (s *seriesServiceImpl) DeleteByID(ctx context.Context, id int32) error {
+	return dal.Transaction(ctx, func(txCtx context.Context) error {
+		query := dal.GetQueryByCtx(txCtx)
+		deleteResult, err := query.Series.WithContext(txCtx).Where(query.Series.ID.Eq(id)).Delete()
+		if err != nil {
+			return WrapDBErr(err)
+		}
+		if deleteResult.RowsAffected == 0 {
+			return xerr.NoRecord.New("id=%d", id).WithMsg("series not found").WithStatus(xerr.StatusNotFound)
+		}
+		_, err = query.SeriesPost.WithContext(txCtx).Where(query.SeriesPost.SeriesID.Eq(id)).Delete()
+		return WrapDBErr(err)
+	})
+}
+
+func (s *seriesServiceImpl) List(ctx context.Context) ([]*entity.Series, error) {
+	seriesDAL := dal.GetQueryByCtx(ctx).Series
+	series, err := seriesDAL.WithContext(ctx).Order(seriesDAL.CreateTime.Desc(), seriesDAL.ID.Desc()).Find()
+	return series, WrapDBErr(err)
+}
+
+func (s *seriesServiceImpl) GetByID(ctx context.Context, id int32) (*entity.Series, error) {
+	seriesDAL := dal.GetQueryByCtx(ctx).Series
+	series, err := seriesDAL.WithContext(ctx).Where(seriesDAL.ID.Eq(id)).First()
+	return series, WrapDBErr(err)
+}
+
+func (s *seriesServiceImpl) GetBySlug(ctx context.Context, slug string) (*entity.Series, error) {
+	seriesDAL := dal.GetQueryByCtx(ctx).Series
+	series, err := seriesDAL.WithContext(ctx).Where(seriesDAL.Slug.Eq(slug)).First()
+	return series, WrapDBErr(err)
+}
+
+func (s *seriesServiceImpl) GetByPostID(ctx context.Context, postID int32) (*entity.Series, error) {
+	query := dal.GetQueryByCtx(ctx)
+	seriesPost, err := query.SeriesPost.WithContext(ctx).Where(query.SeriesPost.PostID.Eq(postID)).First()
+	if err != nil {
+		return nil, WrapDBErr(err)
+	}
+	return s.GetByID(ctx, seriesPost.SeriesID)
+}
+
+func (s *seriesServiceImpl) UpdatePosts(ctx context.Context, id int32, postIDs []int32) error {
+	_, err := s.GetByID(ctx, id)
+	if err != nil {
+		return err
+	}
+	postIDSet := make(map[int32]struct{}, len(postIDs))
+	distinctIDs := make([]int32, 0, len(postIDs))
+	for _, postID := range postIDs {
+		if _, ok := postIDSet[postID]; !ok {
+			postIDSet[postID] = struct{}{}
+			distinctIDs = append(distinctIDs, postID)
+		}
+	}
+	postIDs = distinctIDs
+	if len(postIDs) > 0 {
+		postDAL := dal.GetQueryByCtx(ctx).Post
+		count, err := postDAL.WithContext(ctx).Where(postDAL.ID.In(postIDs...), postDAL.Type.Eq(consts.PostTypePost)).Count()
+		if err != nil {
+			return WrapDBErr(err)
+		}
+		if count != int64(len(postIDs)) {
+			return xerr.BadParam.New("post_ids=%v", postIDs).WithMsg("post not found").WithStatus(xerr.StatusBadRequest)
+		}
+	}
+
+	return dal.Transaction(ctx, func(txCtx context.Context) error {
+		seriesPostDAL := dal.GetQueryByCtx(txCtx).SeriesPost
+		_, err := seriesPostDAL.WithContext(txCtx).Where(seriesPostDAL.SeriesID.Eq(id)).Delete()
+		if err != nil {
+			return WrapDBErr(err)
+		}
+		if len(postIDs) == 0 {
+			return nil
+		}
+		_, err = seriesPostDAL.WithContext(txCtx).Where(seriesPostDAL.PostID.In(postIDs...)).Delete()
+		if err != nil {
+			return WrapDBErr(err)
+		}
+		now := time.Now()
+		seriesPosts := make([]*entity.SeriesPost, 0, len(postIDs))
+		for i, postID := range postIDs {
+			seriesPosts = append(seriesPosts, &entity.SeriesPost{
+				CreateTime: now,
+				SeriesID:   id,
+				PostID:     postID,
+				Priority:   int32(i),
+			})
+		}
+		return WrapDBErr(seriesPostDAL.WithContext(txCtx).Create(seriesPosts...))
+	})
+}
+
+func (s *seriesServiceImpl) ListPosts(ctx context.Context, id int32, statuses []consts.PostStatus) ([]*entity.Post, error) {
+	query := dal.GetQueryByCtx(ctx)
+	seriesPostDAL := query.SeriesPost
+	seriesPosts, err := seriesPostDAL.WithContext(ctx).Where(seriesPostDAL.SeriesID.Eq(id)).Order(seriesPostDAL.Priority, seriesPostDAL.ID).Find()
+	if err != nil {
+		return nil, WrapDBErr(err)
+	}
+	if len(seriesPosts) == 0 {
+		return []*entity.Post{}, nil
+	}
+	postIDs := make([]int32, 0, len(seriesPosts))
+	for _, seriesPost := range seriesPosts {
+		postIDs = append(postIDs, seriesPost.PostID)
+	}
+
+	postDAL := query.Post
+	postDO := postDAL.WithContext(ctx).Where(postDAL.ID.In(postIDs...))
+	if len(statuses) > 0 {
+		values := make([]driver.Valuer, 0, len(statuses))
+		for _, status := range statuses {
+			values = append(values, status)
+		}
+		postDO = postDO.Where(postDAL.Status.In(values...))
+	}
+	posts, err := postDO.Find()
+	if err != nil {
+		return nil, WrapDBErr(err)
+	}
+	postMap := make(map[int32]*entity.Post, len(posts))
+	for _, post := range posts {
+		postMap[post.ID] = post
+	}
+	result := make([]*entity.Post, 0, len(posts))
+	for _, postID := range postIDs {
+		if post, ok := postMap[postID]; ok {
+			result = append(result, post)
+		}
+	}
+	return result, nil
+}
+
+func (s *seriesServiceImpl) ConvertToSeriesDTO(ctx context.Context, series *entity.Series) (*dto.Series, error) {
+	seriesDTOs, err := s.ConvertToSeriesDTOs(ctx, []*entity.Series{series})
+	if err != nil {
+		return nil, err
+	}
+	return seriesDTOs[0], nil
+}
+
+// ConvertToSeriesDTOs PostCount 为系列中所有状态的文章数
+func (s *seriesServiceImpl) ConvertToSeriesDTOs(ctx context.Context, series []*entity.Series) ([]*dto.Series, error) {
+	seriesIDs := make([]int32, 0, len(series))
+	for _, item := range series {
+		seriesIDs = append(seriesIDs, item.ID)
+	}
+	postCounts := make(map[int32]int64)
+	if len(seriesIDs) > 0 {
+		seriesPostDAL := dal.GetQueryByCtx(ctx).SeriesPost
+		counts := make([]struct {
+			SeriesID int32
+			Count    int64
+		}, 0)
+		err := seriesPostDAL.WithContext(ctx).
+			Select(seriesPostDAL.SeriesID, seriesPostDAL.ID.Count().As("count")).
+			Where(seriesPostDAL.SeriesID.In(seriesIDs...)).
+			Group(seriesPostDAL.SeriesID).
+			Scan(&counts)
+		if err != nil {
+			return nil, WrapDBErr(err)
+		}
+		for _, count := range counts {
+			postCounts[count.SeriesID] = count.Count
+		}
+	}
+
+	seriesDTOs := make([]*dto.Series, 0, len(series))
+	for _, item := range series {
+		seriesDTOs = append(seriesDTOs, &dto.Series{
+			ID:          item.ID,
+			Name:        item.Name,
+			Slug:        item.Slug,
+			Description: item.Description,
+			Thumbnail:   item.Thumbnail,
+			CreateTime:  item.CreateTime.UnixMilli(),
+			PostCount:   postCounts[item.ID],
+		})
+	}
+	return seriesDTOs, nil
+}
@@ -0,0 +1,247 @@
+package impl
+
+import (
+	"context"
+	"dash/consts"
+	"dash/dal"
+	"dash/model/entity"
+	"dash/model/param"
+	"dash/service"
+	"dash/utils/xerr"
+	"database/sql/driver"
+
+	"gorm.io/gen/field"
+)
+
+// maxSheetDepth 页面最多嵌套的层数，同时防止脏数据导致死循环
+const maxSheetDepth = 10
+
+type sheetServiceImpl struct {
+	BasePostService service.BasePostService
+}
+
+func NewSheetService(basePostService service.BasePostService) service.SheetService {
+	return &sheetServiceImpl{
+		BasePostService: basePostService,
+	}
+}
+
+func (s *sheetServiceImpl) Create(ctx context.Context, sheetParam *param.Post) (*entity.Post, error) {
+	err := s.checkParent(ctx, 0, sheetParam.ParentID)
+	if err != nil {
+		return nil, err
+	}
+	return s.BasePostService.Create(ctx, sheetParam, consts.PostTypeSheet)
+}
+
+func (s *sheetServiceImpl) UpdateByID(ctx context.Context, id int32, sheetParam *param.Post) (*entity.Post, error) {
+	_, err := s.GetSheetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	err = s.checkParent(ctx, id, sheetParam.ParentID)
+	if err != nil {
+		return nil, err
+	}
+	return s.BasePostService.UpdateByID(ctx, id, sheetParam, consts.PostTypeSheet)
+}
+
+func (s *sheetServiceImpl) UpdateStatusByID(ctx context.Context, id int32, status consts.PostStatus) (*entity.Post, error) {
+	_, err := s.GetSheetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	return s.BasePostService.UpdateStatusByID(ctx, id, status)
+}
+
+func (s *sheetServiceImpl) DeleteByID(ctx context.Context, id int32) error {
+	_, err := s.GetSheetByID(ctx, id)
+	if err != nil {
+		return err
+	}
+	// 删除的页面先进入回收站，在回收站中彻底删除；有子页面时由 UpdateStatusByID 拒绝
+	_, err = s.BasePostService.UpdateStatusByID(ctx, id, consts.PostStatusRecycle)
+	return err
+}
+
+func (s *sheetServiceImpl) Page(ctx context.Context, sheetQuery param.SheetQuery) ([]*entity.Post, int64, error) {
+	if sheetQuery.PageNum < 0 || sheetQuery.PageSize < 0 {
+		return nil, 0, xerr.BadParam.New("").WithStatus(xerr.StatusBadRequest).WithMsg("Paging parameter error")
+	}
+	postDAL := dal.GetQueryByCtx(ctx).Post
+	postDo := postDAL.WithContext(ctx).Where(postDAL.Type.Eq(consts.PostTypeSheet))
+	err := BuildSort(sheetQuery.Sort, &postDAL, &postDo)
+	if err != nil {
+		return nil, 0, err
+	}
+	if sheetQuery.Keyword != nil {
+		keyword := "%" + *sheetQuery.Keyword + "%"
+		postDo = postDo.Where(field.Or(postDAL.Title.Like(keyword), postDAL.OriginalContent.Like(keyword)))
+	}
+	if len(sheetQuery.Statuses) > 0 {
+		statuses := make([]driver.Valuer, len(sheetQuery.Statuses))
+		for i, status := range sheetQuery.Statuses {
+			statuses[i] = driver.Valuer(status)
+		}
+		postDo = postDo.Where(postDAL.Status.In(statuses...))
+	}
+	if sheetQuery.ParentID != nil {
+		postDo = postDo.Where(postDAL.ParentID.Eq(*sheetQuery.ParentID))
+	}
+	sheets, totalCount, err := postDo.FindByPage(sheetQuery.PageNum*sheetQuery.PageSize, sheetQuery.PageSize)
+	if err != nil {
+		return nil, 0, WrapDBErr(err)
+	}
+	return sheets, totalCount, nil
+}
+
+func (s *sheetServiceImpl) GetSheetByID(ctx context.Context, id int32) (*entity.Post, error) {
+	sheet, err := s.BasePostService.GetPostByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if sheet.Type != consts.PostTypeSheet {
+		return nil, xerr.NoRecord.New("post id=%v is not a sheet", id).WithMsg("The resource does not exist or has been deleted").WithStatus(xerr.StatusNotFound)
+	}
+	return sheet, nil
+}
+
+func (s *sheetServiceImpl) GetSheetByPath(ctx context.Context, slugs []string) (*entity.Post, error) {
+	notFound := xerr.NoRecord.New("sheet path=%v not found", slugs).WithMsg("The resource does not exist or has been deleted").WithStatus(xerr.StatusNotFound)
+	if len(slugs) == 0 {
+		return nil, notFound
+	}
+	sheet, err := s.BasePostService.GetPostBySlug(ctx, slugs[len(slugs)-1])
+	if err != nil {
+		return nil, err
+	}
+	if sheet.Type != consts.PostTypeSheet {
+		return nil, notFound
+	}
+	// slug 全局唯一，但仍要求路径和页面的层级一致，避免同一页面出现多个地址
+	slugPath, err := s.GetSlugPath(ctx, sheet)
+	if err != nil {
+		return nil, err
+	}
+	if len(slugPath) != len(slugs) {
+		return nil, notFound
+	}
+	for i := range slugPath {
+		if slugPath[i] != slugs[i] {
+			return nil, notFound
+		}
+	}
+	return sheet, nil
+}
+
+func (s *sheetServiceImpl) ListChildren(ctx context.Context, parentID int32, statuses []consts.PostStatus) ([]*entity.Post, error) {
+	postDAL := dal.GetQueryByCtx(ctx).Post
+	postDo := postDAL.WithContext(ctx).Where(postDAL.Type.Eq(consts.PostTypeSheet), postDAL.ParentID.Eq(parentID))
+	if len(statuses) > 0 {
+		statusValues := make([]driver.Valuer, 0, len(statuses))
+		for _, status := range statuses {
+			statusValues = append(statusValues, status)
+		}
+		postDo = postDo.Where(postDAL.Status.In(statusValues...))
+	}
+	sheets, err := postDo.Order(postDAL.TopPriority.Desc(), postDAL.CreateTime).Find()
+	return sheets, WrapDBErr(err)
+}
+
+func (s *sheetServiceImpl) GetSlugPath(ctx context.Context, sheet *entity.Post) ([]string, error) {
+	ancestors, err := s.listAncestors(ctx, sheet)
+	if err != nil {
+		return nil, err
+	}
+	slugs := make([]string, 0, len(ancestors)+1)
+	for i := len(ancestors) - 1; i >= 0; i-- {
+		slugs = append(slugs, ancestors[i].Slug)
+	}
+	return append(slugs, sheet.Slug), nil
+}
+
+// listAncestors 从直接父页面开始逐级向上查找，父页面不存在或不是页面时到此为止，按顶层页面处理
+func (s *sheetServiceImpl) listAncestors(ctx context.Context, sheet *entity.Post) ([]*entity.Post, error) {
+	postDAL := dal.GetQueryByCtx(ctx).Post
+	ancestors := make([]*entity.Post, 0)
+	parentID := sheet.ParentID
+	for parentID != 0 {
+		if len(ancestors) >= maxSheetDepth {
+			return nil, xerr.NoType.New("sheet id=%v nested too deep", sheet.ID).WithMsg("sheet nested too deep")
+		}
+		parent, err := postDAL.WithContext(ctx).Where(postDAL.ID.Eq(parentID), postDAL.Type.Eq(consts.PostTypeSheet)).First()
+		if err != nil {
+			err = WrapDBErr(err)
+			if xerr.GetType(err) == xerr.NoRecord {
+				break
+			}
+			return nil, err
+		}
+		ancestors = append(ancestors, parent)
+		parentID = parent.ParentID
+	}
+	return ancestors, nil
+}
+
+// checkParent 父页面必须存在，且不能是页面自己或它的子孙页面；parentID 为 nil 时不修改父页面，无需检查
+func (s *sheetServiceImpl) checkParent(ctx context.Context, id int32, parentIDParam *int32) error {
+	if parentIDParam == nil || *parentIDParam == 0 {
+		return nil
+	}
+	parentID := *parentIDParam
+	invalidParent := xerr.BadParam.New("invalid parent id=%v for sheet id=%v", parentID, id).WithStatus(xerr.StatusBadRequest)
+	if parentID == id {
+		return invalidParent.WithMsg("sheet can not be its own parent")
+	}
+	parent, err := s.GetSheetByID(ctx, parentID)
+	if err != nil {
+		if xerr.GetType(err) == xerr.NoRecord {
+			return invalidParent.WithMsg("parent sheet not exist")
+		}
+		return err
+	}
+	if parent.Status == consts.PostStatusRecycle {
+		return invalidParent.WithMsg("parent sheet not exist")
+	}
+	ancestors, err := s.listAncestors(ctx, parent)
+	if err != nil {
+		return err
+	}
+	for _, ancestor := range ancestors {
+		if ancestor.ID == id {
+			return invalidParent.WithMsg("parent sheet can not be a child of the sheet")
+		}
+	}
+	// 移动的页面会带着它的子孙页面一起移动，层数要加上整棵子树的高度
+	height := int32(1)
+	if id != 0 {
+		height, err = s.getSubtreeHeight(ctx, id)
+		if err != nil {
+			return err
+		}
+	}
+	if len(ancestors)+1+int(height) > maxSheetDepth {
+		return invalidParent.WithMsg("sheet nested too deep")
+	}
+	return nil
+}
+
+// getSubtreeHeight 逐层向下查找子页面，返回以该页面为根的子树层数，页面自己算一层
+func (s *sheetServiceImpl) getSubtreeHeight(ctx context.Context, id int32) (int32, error) {
+	postDAL := dal.GetQueryByCtx(ctx).Post
+	height := int32(1)
+	levelIDs := []int32{id}
+	for height <= maxSheetDepth {
+		childIDs := make([]int32, 0)
+		err := postDAL.WithContext(ctx).Where(postDAL.Type.Eq(consts.PostTypeSheet), postDAL.ParentID.In(levelIDs...)).Pluck(postDAL.ID, &childIDs)
+		if err != nil {
+			return 0, WrapDBErr(err)
+		}
+		if len(childIDs) == 0 {
+			break
+		}
+		height++
+		levelIDs = childIDs
+	}
+	return height, nil
+}
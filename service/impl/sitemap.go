@@ -0,0 +1,302 @@
+package impl
+
+import (
+	"context"
+	"dash/cache"
+	"dash/consts"
+	"dash/dal"
+	"dash/model/dto"
+	"dash/model/entity"
+	"dash/model/property"
+	"dash/service"
+	"dash/utils/xerr"
+	"encoding/xml"
+	"errors"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+	"go.uber.org/zap"
+)
+
+const (
+	// sitemapMaxURLs 协议规定单个站点地图最多 50000 条链接，超过后分页并生成索引
+	sitemapMaxURLs         = 50000
+	sitemapCacheExpiration = 24 * time.Hour
+)
+
+const defaultRobotsTxt = `User-agent: *
+Disallow: /api/
+Disallow: /console
+`
+
+type sitemapServiceImpl struct {
+	Logger              *zap.Logger
+	OptionService       service.OptionService
+	PermalinkService    service.PermalinkService
+	CategoryService     service.CategoryService
+	TagService          service.TagService
+	PostCategoryService service.PostCategoryService
+}
+
+func NewSitemapService(
+	logger *zap.Logger,
+	optionService service.OptionService,
+	permalinkService service.PermalinkService,
+	categoryService service.CategoryService,
+	tagService service.TagService,
+	postCategoryService service.PostCategoryService,
+) service.SitemapService {
+	return &sitemapServiceImpl{
+		Logger:              logger,
+		OptionService:       optionService,
+		PermalinkService:    permalinkService,
+		CategoryService:     categoryService,
+		TagService:          tagService,
+		PostCategoryService: postCategoryService,
+	}
+}
+
+// sitemapEntry 站内路径和最后修改时间，生成 XML 时再加上博客地址
+type sitemapEntry struct {
+	path    string
+	lastMod time.Time
+}
+
+func (s *sitemapServiceImpl) GetSitemap(ctx context.Context, page int) ([]byte, error) {
+	if page < 0 {
+		return nil, xerr.BadParam.New("page=%d", page).WithMsg("invalid sitemap page").WithStatus(xerr.StatusBadRequest)
+	}
+	return s.getOrBuild(ctx, "page_"+strconv.Itoa(page), func() ([]byte, error) {
+		return s.buildSitemap(ctx, page)
+	})
+}
+
+func (s *sitemapServiceImpl) GetRobots(ctx context.Context) ([]byte, error) {
+	return s.getOrBuild(ctx, "robots", func() ([]byte, error) {
+		blogURL, err := s.getBlogURL(ctx)
+		if err != nil {
+			return nil, err
+		}
+		robots, err := s.OptionService.GetOrByDefaultWithErr(ctx, property.RobotsTxt, property.RobotsTxt.DefaultValue)
+		if err != nil {
+			return nil, err
+		}
+		content := strings.TrimSpace(robots.(string))
+		if content == "" {
+			content = strings.TrimSpace(defaultRobotsTxt)
+		}
+		if !strings.Contains(strings.ToLower(content), "sitemap:") {
+			content += "\n\nSitemap: " + blogURL + "/sitemap.xml"
+		}
+		return []byte(content + "\n"), nil
+	})
+}
+
+// getOrBuild 缓存 key 带有内容版本号，内容变化后版本号递增，旧缓存不再使用并自然过期
+func (s *sitemapServiceImpl) getOrBuild(ctx context.Context, name string, build func() ([]byte, error)) ([]byte, error) {
+	version, err := cache.Redis.Get(ctx, consts.ContentVersionKey).Int64()
+	if err != nil && !errors.Is(err, redis.Nil) {
+		s.Logger.Error("get content version", zap.Error(err))
+		return build()
+	}
+	key := cache.BuildSitemapKey(version, name)
+	value, err := cache.Redis.Get(ctx, key).Bytes()
+	if err == nil {
+		return value, nil
+	}
+
+	value, err = build()
+	if err != nil {
+		return nil, err
+	}
+	err = cache.Redis.Set(ctx, key, value, sitemapCacheExpiration).Err()
+	if err != nil {
+		s.Logger.Error("cache sitemap", zap.String("name", name), zap.Error(err))
+	}
+	return value, nil
+}
+
+func (s *sitemapServiceImpl) getBlogURL(ctx context.Context) (string, error) {
+	blogURL, err := s.OptionService.GetBlogBaseURL(ctx)
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimRight(blogURL, "/"), nil
+}
+
+func (s *sitemapServiceImpl) buildSitemap(ctx context.Context, page int) ([]byte, error) {
+	blogURL, err := s.getBlogURL(ctx)
+	if err != nil {
+		return nil, err
+	}
+	entries, err := s.listEntries(ctx)
+	if err != nil {
+		return nil, err
+	}
+	pages := (len(entries) + sitemapMaxURLs - 1) / sitemapMaxURLs
+	if page > pages {
+		return nil, xerr.NoRecord.New("sitemap page=%d", page).WithMsg("sitemap page not found").WithStatus(xerr.StatusNotFound)
+	}
+
+	if page == 0 && pages > 1 {
+		sitemapIndex := &dto.SitemapIndex{Xmlns: dto.SitemapXmlns, Sitemaps: make([]*dto.SitemapURL, 0, pages)}
+		for i := 1; i <= pages; i++ {
+			var lastMod time.Time
+			for _, entry := range pageEntries(entries, i) {
+				if entry.lastMod.After(lastMod) {
+					lastMod = entry.lastMod
+				}
+			}
+			sitemapIndex.Sitemaps = append(sitemapIndex.Sitemaps, &dto.SitemapURL{
+				Loc:     blogURL + "/sitemap/" + strconv.Itoa(i) + ".xml",
+				LastMod: formatSitemapTime(lastMod),
+			})
+		}
+		return marshalSitemap(sitemapIndex)
+	}
+
+	if page == 0 {
+		page = 1
+	}
+	urlSet := &dto.SitemapURLSet{Xmlns: dto.SitemapXmlns, URLs: make([]*dto.SitemapURL, 0)}
+	for _, entry := range pageEntries(entries, page) {
+		urlSet.URLs = append(urlSet.URLs, &dto.SitemapURL{
+			Loc:     blogURL + (&url.URL{Path: entry.path}).EscapedPath(),
+			LastMod: formatSitemapTime(entry.lastMod),
+		})
+	}
+	return marshalSitemap(urlSet)
+}
+
+// listEntries 首页、游客可见的文章和页面、分类、标签，不包括设置了密码和属于私密、加密分类的文章
+func (s *sitemapServiceImpl) listEntries(ctx context.Context) ([]*sitemapEntry, error) {
+	hiddenCategoryIDs, err := s.CategoryService.ListHiddenCategoryIDs(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	hiddenCategoryIDSet := make(map[int32]struct{}, len(hiddenCategoryIDs))
+	for _, id := range hiddenCategoryIDs {
+		hiddenCategoryIDSet[id] = struct{}{}
+	}
+	hiddenPostIDs := make(map[int32]struct{})
+	if len(hiddenCategoryIDs) > 0 {
+		hiddenPostIDs, err = s.PostCategoryService.ListPostIDSetByCategoryIDs(ctx, hiddenCategoryIDs)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	postDAL := dal.GetQueryByCtx(ctx).Post
+	posts, err := postDAL.WithContext(ctx).
+		Where(
+			postDAL.Status.Eq(consts.PostStatusPublished),
+			postDAL.Type.In(consts.PostTypePost, consts.PostTypeSheet),
+			postDAL.Password.Eq(""),
+		).
+		Order(postDAL.CreateTime.Desc(), postDAL.ID.Desc()).
+		Find()
+	if err != nil {
+		return nil, WrapDBErr(err)
+	}
+
+	entries := make([]*sitemapEntry, 0, len(posts)+1)
+	home := &sitemapEntry{path: "/"}
+	entries = append(entries, home)
+	for _, post := range posts {
+		if _, ok := hiddenPostIDs[post.ID]; ok {
+			continue
+		}
+		path, err := s.PermalinkService.BuildPath(ctx, post)
+		if err != nil {
+			return nil, err
+		}
+		lastMod := postLastModified(post)
+		if lastMod.After(home.lastMod) {
+			home.lastMod = lastMod
+		}
+		entries = append(entries, &sitemapEntry{path: path, lastMod: lastMod})
+	}
+
+	categoryPrefix, err := s.OptionService.GetOrByDefaultWithErr(ctx, property.CategoriesPrefix, property.CategoriesPrefix.DefaultValue)
+	if err != nil {
+		return nil, err
+	}
+	categories, err := s.CategoryService.List(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	for _, category := range categories {
+		if _, ok := hiddenCategoryIDSet[category.ID]; ok {
+			continue
+		}
+		entries = append(entries, &sitemapEntry{
+			path:    "/" + categoryPrefix.(string) + "/" + category.Slug,
+			lastMod: taxonomyLastModified(category.CreateTime, category.UpdateTime),
+		})
+	}
+
+	tagPrefix, err := s.OptionService.GetOrByDefaultWithErr(ctx, property.TagsPrefix, property.TagsPrefix.DefaultValue)
+	if err != nil {
+		return nil, err
+	}
+	tags, err := s.TagService.List(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	for _, tag := range tags {
+		entries = append(entries, &sitemapEntry{
+			path:    "/" + tagPrefix.(string) + "/" + tag.Slug,
+			lastMod: taxonomyLastModified(tag.CreateTime, tag.UpdateTime),
+		})
+	}
+	return entries, nil
+}
+
+// postLastModified 优先使用正文的编辑时间，其次是更新时间
+func postLastModified(post *entity.Post) time.Time {
+	if post.EditTime != nil {
+		return *post.EditTime
+	}
+	if post.UpdateTime != nil {
+		return *post.UpdateTime
+	}
+	return post.CreateTime
+}
+
+func taxonomyLastModified(createTime time.Time, updateTime *time.Time) time.Time {
+	if updateTime != nil {
+		return *updateTime
+	}
+	return createTime
+}
+
+// pageEntries page 从 1 开始
+func pageEntries(entries []*sitemapEntry, page int) []*sitemapEntry {
+	start := (page - 1) * sitemapMaxURLs
+	if start >= len(entries) {
+		return nil
+	}
+	end := start + sitemapMaxURLs
+	if end > len(entries) {
+		end = len(entries)
+	}
+	return entries[start:end]
+}
+
+func formatSitemapTime(t time.Time) string {
+	if t.IsZero() {
+		return ""
+	}
+	return t.Format(time.RFC3339)
+}
+
+func marshalSitemap(v interface{}) ([]byte, error) {
+	content, err := xml.Marshal(v)
+	if err != nil {
+		return nil, xerr.NoType.Wrap(err).WithMsg("generate sitemap failed")
+	}
+	return append([]byte(xml.Header), content...), nil
+}
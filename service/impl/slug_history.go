@@ -0,0 +1,117 @@
+package impl
+
+import (
+	"context"
+	"dash/consts"
+	"dash/dal"
+	"dash/model/dto"
+	"dash/model/entity"
+	"dash/model/param"
+	"dash/service"
+	"dash/utils/xerr"
+	"time"
+)
+
+type slugHistoryServiceImpl struct{}
+
+func NewSlugHistoryService() service.SlugHistoryService {
+	return &slugHistoryServiceImpl{}
+}
+
+func (s *slugHistoryServiceImpl) Record(ctx context.Context, slugType consts.SlugType, targetID int32, oldSlug string, newSlug string) error {
+	if oldSlug == "" || oldSlug == newSlug {
+		return nil
+	}
+	slugHistoryDAL := dal.GetQueryByCtx(ctx).SlugHistory
+	// newSlug 重新生效，不再作为旧链接跳转；oldSlug 以前属于别的对象时由最近一次改名的对象接管
+	_, err := slugHistoryDAL.WithContext(ctx).
+		Where(slugHistoryDAL.Type.Eq(slugType), slugHistoryDAL.Slug.In(oldSlug, newSlug)).
+		Delete()
+	if err != nil {
+		return WrapDBErr(err)
+	}
+	err = slugHistoryDAL.WithContext(ctx).Create(&entity.SlugHistory{
+		CreateTime: time.Now(),
+		Type:       slugType,
+		Slug:       oldSlug,
+		TargetID:   targetID,
+	})
+	return WrapDBErr(err)
+}
+
+func (s *slugHistoryServiceImpl) GetTargetID(ctx context.Context, slugType consts.SlugType, slug string) (int32, error) {
+	slugHistoryDAL := dal.GetQueryByCtx(ctx).SlugHistory
+	slugHistory, err := slugHistoryDAL.WithContext(ctx).Where(slugHistoryDAL.Type.Eq(slugType), slugHistoryDAL.Slug.Eq(slug)).First()
+	if err != nil {
+		return 0, WrapDBErr(err)
+	}
+	return slugHistory.TargetID, nil
+}
+
+func (s *slugHistoryServiceImpl) DeleteByTargetID(ctx context.Context, slugType consts.SlugType, targetID int32) error {
+	slugHistoryDAL := dal.GetQueryByCtx(ctx).SlugHistory
+	_, err := slugHistoryDAL.WithContext(ctx).Where(slugHistoryDAL.Type.Eq(slugType), slugHistoryDAL.TargetID.Eq(targetID)).Delete()
+	return WrapDBErr(err)
+}
+
+func (s *slugHistoryServiceImpl) Page(ctx context.Context, query param.SlugHistoryQuery) ([]*entity.SlugHistory, int64, error) {
+	if query.PageNum < 0 || query.PageSize < 0 {
+		return nil, 0, xerr.BadParam.New("").WithStatus(xerr.StatusBadRequest).WithMsg("Paging parameter error")
+	}
+	slugHistoryDAL := dal.GetQueryByCtx(ctx).SlugHistory
+	slugHistoryDO := slugHistoryDAL.WithContext(ctx)
+	if query.Type != nil {
+		slugHistoryDO = slugHistoryDO.Where(slugHistoryDAL.Type.Eq(*query.Type))
+	}
+	if query.TargetID != nil {
+		slugHistoryDO = slugHistoryDO.Where(slugHistoryDAL.TargetID.Eq(*query.TargetID))
+	}
+	if query.Keyword != nil && *query.Keyword != "" {
+		slugHistoryDO = slugHistoryDO.Where(slugHistoryDAL.Slug.Like("%" + *query.Keyword + "%"))
+	}
+	histories, total, err := slugHistoryDO.Order(slugHistoryDAL.CreateTime.Desc(), slugHistoryDAL.ID.Desc()).
+		FindByPage(query.PageNum*query.PageSize, query.PageSize)
+	if err != nil {
+		return nil, 0, WrapDBErr(err)
+	}
+	return histories, total, nil
+}
+
+func (s *slugHistoryServiceImpl) DeleteByID(ctx context.Context, id int32) error {
+	slugHistoryDAL := dal.GetQueryByCtx(ctx).SlugHistory
+	deleteResult, err := slugHistoryDAL.WithContext(ctx).Where(slugHistoryDAL.ID.Eq(id)).Delete()
+	if err != nil {
+		return WrapDBErr(err)
+	}
+	if deleteResult.RowsAffected != 1 {
+		return xerr.NoRecord.New("slug history id=%v not found", id).WithMsg("The resource does not exist or has been deleted").WithStatus(xerr.StatusNotFound)
+	}
+	return nil
+}
+
+func (s *slugHistoryServiceImpl) Prune(ctx context.Context, before time.Time, slugType *consts.SlugType) (int64, error) {
+	slugHistoryDAL := dal.GetQueryByCtx(ctx).SlugHistory
+	slugHistoryDO := slugHistoryDAL.WithContext(ctx).Where(slugHistoryDAL.CreateTime.Lt(before))
+	if slugType != nil {
+		slugHistoryDO = slugHistoryDO.Where(slugHistoryDAL.Type.Eq(*slugType))
+	}
+	deleteResult, err := slugHistoryDO.Delete()
+	if err != nil {
+		return 0, WrapDBErr(err)
+	}
+	return deleteResult.RowsAffected, nil
+}
+
+func (s *slugHistoryServiceImpl) ConvertToSlugHistoryDTOs(ctx context.Context, histories []*entity.SlugHistory) []*dto.SlugHistory {
+	slugHistoryDTOs := make([]*dto.SlugHistory, 0, len(histories))
+	for _, history := range histories {
+		slugHistoryDTOs = append(slugHistoryDTOs, &dto.SlugHistory{
+			ID:         history.ID,
+			Type:       history.Type,
+			Slug:       history.Slug,
+			TargetID:   history.TargetID,
+			CreateTime: history.CreateTime.UnixMilli(),
+		})
+	}
+	return slugHistoryDTOs
+}
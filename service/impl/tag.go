@@ -19,14 +19,16 @@ import (
 )
 
 type tagServiceImpl struct {
-	OptionService service.OptionService
-	DB            *gorm.DB
+	OptionService      service.OptionService
+	SlugHistoryService service.SlugHistoryService
+	DB                 *gorm.DB
 }
 
-func NewTagService(optionService service.OptionService, db *gorm.DB) service.TagService {
+func NewTagService(optionService service.OptionService, slugHistoryService service.SlugHistoryService, db *gorm.DB) service.TagService {
 	return &tagServiceImpl{
-		OptionService: optionService,
-		DB:            db,
+		OptionService:      optionService,
+		SlugHistoryService: slugHistoryService,
+		DB:                 db,
 	}
 }
 
@@ -67,6 +69,7 @@ func (t *tagServiceImpl) Create(ctx context.Context, tagParam *param.Tag) (*enti
 	if err != nil {
 		return nil, WrapDBErr(err)
 	}
+	invalidateContentCache(ctx)
 	return tag, nil
 }
 
@@ -83,9 +86,13 @@ func (t *tagServiceImpl) DeleteByID(ctx context.Context, id int32) error {
 		if err != nil {
 			return WrapDBErr(err)
 		}
-		return nil
+		return t.SlugHistoryService.DeleteByTargetID(txCtx, consts.SlugTypeTag, id)
 	})
-	return err
+	if err != nil {
+		return err
+	}
+	invalidateContentCache(ctx)
+	return nil
 }
 
 func (t *tagServiceImpl) UpdateByID(ctx context.Context, id int32, tagParam *param.Tag) (*entity.Tag, error) {
@@ -100,7 +107,7 @@ func (t *tagServiceImpl) UpdateByID(ctx context.Context, id int32, tagParam *par
 
 	tagDAL := dal.GetQueryByCtx(ctx).Tag
 	// determine if tag exist
-	_, err := tagDAL.WithContext(ctx).Where(tagDAL.ID.Eq(id)).First()
+	oldTag, err := tagDAL.WithContext(ctx).Where(tagDAL.ID.Eq(id)).First()
 	if err != nil {
 		return nil, WrapDBErr(err)
 	}
@@ -120,19 +127,27 @@ func (t *tagServiceImpl) UpdateByID(ctx context.Context, id int32, tagParam *par
 		return nil, xerr.BadParam.New("invalid parameter").WithMsg("tag name or slug has exist already").WithStatus(xerr.StatusBadRequest)
 	}
 
-	updateResult, err := tagDAL.WithContext(ctx).Where(tagDAL.ID.Eq(id)).UpdateSimple(
-		tagDAL.UpdateTime.Value(time.Now()),
-		tagDAL.Name.Value(tagParam.Name),
-		tagDAL.Slug.Value(tagParam.Slug),
-		tagDAL.Thumbnail.Value(tagParam.Thumbnail),
-		tagDAL.Color.Value(tagParam.Color),
-	)
+	err = dal.Transaction(ctx, func(txCtx context.Context) error {
+		tagDAL := dal.GetQueryByCtx(txCtx).Tag
+		updateResult, err := tagDAL.WithContext(txCtx).Where(tagDAL.ID.Eq(id)).UpdateSimple(
+			tagDAL.UpdateTime.Value(time.Now()),
+			tagDAL.Name.Value(tagParam.Name),
+			tagDAL.Slug.Value(tagParam.Slug),
+			tagDAL.Thumbnail.Value(tagParam.Thumbnail),
+			tagDAL.Color.Value(tagParam.Color),
+		)
+		if err != nil {
+			return WrapDBErr(err)
+		}
+		if updateResult.RowsAffected != 1 {
+			return xerr.NoType.New("update tag failed id=%v", id).WithStatus(xerr.StatusInternalServerError).WithMsg("update tag failed")
+		}
+		return t.SlugHistoryService.Record(txCtx, consts.SlugTypeTag, id, oldTag.Slug, tagParam.Slug)
+	})
 	if err != nil {
-		return nil, WrapDBErr(err)
-	}
-	if updateResult.RowsAffected != 1 {
-		return nil, xerr.NoType.New("update tag failed id=%v", id).WithStatus(xerr.StatusInternalServerError).WithMsg("update tag failed")
+		return nil, err
 	}
+	invalidateContentCache(ctx)
 
 	tag, err := tagDAL.WithContext(ctx).Where(tagDAL.ID.Value(id)).First()
 	if err != nil {
@@ -256,15 +271,14 @@ func (t *tagServiceImpl) ConvertToTagDTOs(ctx context.Context, tags []*entity.Ta
 	return tagDTOs, nil
 }
 
-func (t *tagServiceImpl) ConvertToTagWithPostCountDTO(ctx context.Context, tag *entity.Tag) (*dto.TagWithPostCount, error) {
+func (t *tagServiceImpl) ConvertToTagWithPostCountDTO(ctx context.Context, tag *entity.Tag, excludeCategoryIDs []int32) (*dto.TagWithPostCount, error) {
 	tagDTO, err := t.ConvertToTagDTO(ctx, tag)
 	if err != nil {
 		return nil, err
 	}
-	postTagDAL := dal.GetQueryByCtx(ctx).PostTag
-	count, err := postTagDAL.WithContext(ctx).Where(postTagDAL.TagID.Eq(tagDTO.ID)).Count()
+	count, err := t.countPosts(ctx, tagDTO.ID, excludeCategoryIDs)
 	if err != nil {
-		return nil, WrapDBErr(err)
+		return nil, err
 	}
 	tagWithPostCountDTO := &dto.TagWithPostCount{
 		Tag:       tagDTO,
@@ -273,17 +287,16 @@ func (t *tagServiceImpl) ConvertToTagWithPostCountDTO(ctx context.Context, tag *
 	return tagWithPostCountDTO, nil
 }
 
-func (t *tagServiceImpl) ConvertToTagWithPostCountDTOs(ctx context.Context, tags []*entity.Tag) ([]*dto.TagWithPostCount, error) {
+func (t *tagServiceImpl) ConvertToTagWithPostCountDTOs(ctx context.Context, tags []*entity.Tag, excludeCategoryIDs []int32) ([]*dto.TagWithPostCount, error) {
 	tagDTOs, err := t.ConvertToTagDTOs(ctx, tags)
 	if err != nil {
 		return nil, err
 	}
 	tagWithPostCountDOTs := make([]*dto.TagWithPostCount, 0)
-	postTagDAL := dal.GetQueryByCtx(ctx).PostTag
 	for _, tagDTO := range tagDTOs {
-		count, err := postTagDAL.WithContext(ctx).Where(postTagDAL.TagID.Eq(tagDTO.ID)).Count()
+		count, err := t.countPosts(ctx, tagDTO.ID, excludeCategoryIDs)
 		if err != nil {
-			return nil, WrapDBErr(err)
+			return nil, err
 		}
 		tagWithPostCountDTO := &dto.TagWithPostCount{
 			Tag:       tagDTO,
@@ -293,3 +306,20 @@ func (t *tagServiceImpl) ConvertToTagWithPostCountDTOs(ctx context.Context, tags
 	}
 	return tagWithPostCountDOTs, nil
 }
+
+// countPosts 统计标签下的文章数，excludeCategoryIDs 下的文章不计入
+func (t *tagServiceImpl) countPosts(ctx context.Context, tagID int32, excludeCategoryIDs []int32) (int64, error) {
+	query := dal.GetQueryByCtx(ctx)
+	postTagDAL := query.PostTag
+	postTagDo := postTagDAL.WithContext(ctx).Where(postTagDAL.TagID.Eq(tagID))
+	if len(excludeCategoryIDs) > 0 {
+		postCategoryDAL := query.PostCategory
+		excludePostIDs := postCategoryDAL.WithContext(ctx).Where(postCategoryDAL.CategoryID.In(excludeCategoryIDs...)).Select(postCategoryDAL.PostID)
+		postTagDo = postTagDo.Where(postTagDAL.WithContext(ctx).Columns(postTagDAL.PostID).NotIn(excludePostIDs))
+	}
+	count, err := postTagDo.Count()
+	if err != nil {
+		return 0, WrapDBErr(err)
+	}
+	return count, nil
+}
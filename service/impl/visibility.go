@@ -0,0 +1,68 @@
+package impl
+
+import (
+	"context"
+	"dash/consts"
+	"dash/model/entity"
+	"dash/service"
+	"dash/utils/xerr"
+)
+
+type visibilityServiceImpl struct {
+	CategoryService     service.CategoryService
+	PostCategoryService service.PostCategoryService
+}
+
+func NewVisibilityService(categoryService service.CategoryService, postCategoryService service.PostCategoryService) service.VisibilityService {
+	return &visibilityServiceImpl{
+		CategoryService:     categoryService,
+		PostCategoryService: postCategoryService,
+	}
+}
+
+func (v *visibilityServiceImpl) IsAdmin(ctx context.Context) bool {
+	// 鉴权中间件会把管理员放进 gin.Context，service 层的 ctx 都来自它
+	_, ok := ctx.Value(consts.AuthorizedUser).(*entity.User)
+	return ok
+}
+
+func (v *visibilityServiceImpl) VisibleStatuses(ctx context.Context) []consts.PostStatus {
+	if v.IsAdmin(ctx) {
+		return []consts.PostStatus{consts.PostStatusPublished, consts.PostStatusDraft, consts.PostStatusIntimate}
+	}
+	return []consts.PostStatus{consts.PostStatusPublished}
+}
+
+func (v *visibilityServiceImpl) FilterStatuses(ctx context.Context, statuses []*consts.PostStatus) []*consts.PostStatus {
+	if v.IsAdmin(ctx) {
+		return statuses
+	}
+	return []*consts.PostStatus{consts.PostStatusPublished.Ptr()}
+}
+
+func (v *visibilityServiceImpl) CheckPostVisible(ctx context.Context, post *entity.Post) error {
+	notFound := xerr.NoRecord.New("post not visible id=%v", post.ID).WithMsg("The resource does not exist or has been deleted").WithStatus(xerr.StatusNotFound)
+	if v.IsAdmin(ctx) {
+		return nil
+	}
+	if post.Status != consts.PostStatusPublished {
+		return notFound
+	}
+	categories, err := v.PostCategoryService.ListCategoriesByPostID(ctx, post.ID)
+	if err != nil {
+		return err
+	}
+	for _, category := range categories {
+		if category.Type == consts.CategoryTypeIntimate {
+			return notFound
+		}
+	}
+	return nil
+}
+
+func (v *visibilityServiceImpl) ListHiddenCategoryIDs(ctx context.Context, unlockTokens []string) ([]int32, error) {
+	if v.IsAdmin(ctx) {
+		return nil, nil
+	}
+	return v.CategoryService.ListHiddenCategoryIDs(ctx, unlockTokens)
+}
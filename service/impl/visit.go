@@ -0,0 +1,101 @@
+package impl
+
+import (
+	"context"
+	"dash/cache"
+	"dash/consts"
+	"dash/dal"
+	"dash/service"
+	"dash/utils"
+	"strconv"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// visitDedupExpiration 同一访客重复访问同一文章只计一次的时间窗口
+const visitDedupExpiration = 30 * time.Minute
+
+type visitServiceImpl struct {
+	Logger *zap.Logger
+}
+
+func NewVisitService(logger *zap.Logger) service.VisitService {
+	return &visitServiceImpl{
+		Logger: logger,
+	}
+}
+
+func (v *visitServiceImpl) RecordVisit(ctx context.Context, postID int32, visitorID string, userAgent string) {
+	if utils.IsBot(userAgent) {
+		return
+	}
+	// 计数失败不影响文章的正常访问，只记录日志
+	dedupKey := cache.BuildPostVisitKey(postID, visitorID)
+	ok, err := cache.Redis.SetNX(ctx, dedupKey, 1, visitDedupExpiration).Result()
+	if err != nil {
+		v.Logger.Error("record post visit", zap.Int32("postID", postID), zap.Error(err))
+		return
+	}
+	if !ok {
+		return
+	}
+	err = cache.Redis.HIncrBy(ctx, consts.PostVisitBufferKey, strconv.Itoa(int(postID)), 1).Err()
+	if err != nil {
+		v.Logger.Error("record post visit", zap.Int32("postID", postID), zap.Error(err))
+		// 计数没有写入时去掉去重标记，下次访问还能计数
+		cache.Redis.Del(ctx, dedupKey)
+	}
+}
+
+func (v *visitServiceImpl) FlushVisits(ctx context.Context) (int64, error) {
+	// 先把缓冲区整体改名，之后的访问写入新的缓冲区；上次写入中断时留下的数据会先被处理
+	flushingCount, err := cache.Redis.Exists(ctx, consts.PostVisitFlushingKey).Result()
+	if err != nil {
+		return 0, err
+	}
+	if flushingCount == 0 {
+		bufferCount, err := cache.Redis.Exists(ctx, consts.PostVisitBufferKey).Result()
+		if err != nil {
+			return 0, err
+		}
+		if bufferCount == 0 {
+			return 0, nil
+		}
+		err = cache.Redis.Rename(ctx, consts.PostVisitBufferKey, consts.PostVisitFlushingKey).Err()
+		if err != nil {
+			return 0, err
+		}
+	}
+
+	visits, err := cache.Redis.HGetAll(ctx, consts.PostVisitFlushingKey).Result()
+	if err != nil {
+		return 0, err
+	}
+	postDAL := dal.GetQueryByCtx(ctx).Post
+	var total int64
+	for postIDStr, countStr := range visits {
+		postID, err := strconv.ParseInt(postIDStr, 10, 32)
+		if err != nil {
+			v.Logger.Warn("invalid post visit field", zap.String("field", postIDStr))
+			cache.Redis.HDel(ctx, consts.PostVisitFlushingKey, postIDStr)
+			continue
+		}
+		count, err := strconv.ParseInt(countStr, 10, 64)
+		if err != nil || count <= 0 {
+			cache.Redis.HDel(ctx, consts.PostVisitFlushingKey, postIDStr)
+			continue
+		}
+		_, err = postDAL.WithContext(ctx).Where(postDAL.ID.Eq(int32(postID))).UpdateSimple(postDAL.Visits.Add(count))
+		if err != nil {
+			return total, WrapDBErr(err)
+		}
+		// 每篇文章写入后立即删除，中途失败时不会重复累加
+		err = cache.Redis.HDel(ctx, consts.PostVisitFlushingKey, postIDStr).Err()
+		if err != nil {
+			return total, err
+		}
+		total += count
+	}
+	return total, nil
+}
@@ -0,0 +1,14 @@
+package service
+
+import (
+	"context"
+	"dash/model/dto"
+)
+
+// ImportService 从其他博客系统导入文章，单个文件失败不影响其他文件
+type ImportService interface {
+	// ImportMarkdown 导入 Hexo/Hugo/Jekyll 的 Markdown 文章，src 可以是 zip 文件、目录或单个 Markdown 文件
+	ImportMarkdown(ctx context.Context, src string, dryRun bool) (*dto.ImportResult, error)
+	// ImportWordPress 导入 WordPress 导出的 WXR 文件，文章和页面的正文转为 Markdown，结果中包含旧链接到新链接的对应关系
+	ImportWordPress(ctx context.Context, src string, dryRun bool) (*dto.ImportResult, error)
+}
@@ -0,0 +1,8 @@
+package service
+
+import "context"
+
+type MarkdownService interface {
+	// Render 按当前渲染设置将 Markdown 渲染为 HTML
+	Render(ctx context.Context, markdown string) (string, error)
+}
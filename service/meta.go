@@ -0,0 +1,16 @@
+package service
+
+import (
+	"context"
+	"dash/model/dto"
+	"dash/model/entity"
+	"dash/model/param"
+)
+
+type MetaService interface {
+	ListByPostID(ctx context.Context, postID int32) ([]*entity.PostMeta, error)
+	// SaveByPostID 用 metas 整体替换文章原有的元数据，需要在文章的事务中调用
+	SaveByPostID(ctx context.Context, postID int32, metas []*param.Meta) error
+	DeleteByPostID(ctx context.Context, postID int32) error
+	ConvertToMetaDTOs(ctx context.Context, metas []*entity.PostMeta) []*dto.Meta
+}
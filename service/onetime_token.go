@@ -1,6 +1,9 @@
 package service
 
+import "time"
+
 type OneTimeTokenService interface {
 	Get(oneTimeToken string) (string, bool)
 	Create(value string) string
+	CreateWithExpiration(value string, expiration time.Duration) string
 }
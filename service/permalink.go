@@ -0,0 +1,16 @@
+package service
+
+import (
+	"context"
+	"dash/model/entity"
+)
+
+// PermalinkService 按 post_permalink_type、sheet_permalink_type 和 path_suffix 生成文章和页面的链接，并把链接解析回文章
+type PermalinkService interface {
+	// BuildPath 生成站内路径，如 /2025/03/14/my-post.html
+	BuildPath(ctx context.Context, post *entity.Post) (string, error)
+	// BuildFullPath 开启 global_absolute_path_enabled 时在 BuildPath 前加上博客地址
+	BuildFullPath(ctx context.Context, post *entity.Post) (string, error)
+	// Resolve 解析任意一种链接格式，path 可以是完整 URL，同一路径对应多篇文章或页面时返回 Conflict
+	Resolve(ctx context.Context, path string) (*entity.Post, error)
+}
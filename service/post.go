@@ -15,4 +15,9 @@ type PostService interface {
 	GetPostCountByStatus(ctx context.Context, status consts.PostStatus) (int64, error)
 	GetVisitCount(ctx context.Context) (int64, error)
 	GetLikeCount(ctx context.Context) (int64, error)
+	ListScheduledPosts(ctx context.Context) ([]*entity.Post, error)
+	// PublishScheduledPosts 发布所有已到定时发布时间的草稿，返回发布的数量
+	PublishScheduledPosts(ctx context.Context) (int64, error)
+	// ExpirePosts 下线所有已到下线时间的文章，返回下线的数量
+	ExpirePosts(ctx context.Context) (int64, error)
 }
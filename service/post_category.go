@@ -11,4 +11,6 @@ type PostCategoryService interface {
 	ListCategoriesByPostID(ctx context.Context, postID int32) ([]*entity.Category, error)
 	ListPostsByCategoryID(ctx context.Context, categoryID int32, status consts.PostStatus) ([]*entity.Post, error)
 	ListCategoryMapByPostID(ctx context.Context, postIDs []int32) (map[int32][]*entity.Category, error)
+	// ListPostIDSetByCategoryIDs 返回属于这些分类的文章 ID 集合
+	ListPostIDSetByCategoryIDs(ctx context.Context, categoryIDs []int32) (map[int32]struct{}, error)
 }
@@ -0,0 +1,21 @@
+package service
+
+import (
+	"context"
+
+	"dash/model/dto"
+	"dash/model/entity"
+	"dash/model/param"
+)
+
+type PostDraftService interface {
+	// Save 自动保存文章的工作草稿，每篇文章只保留一份，已发布的内容不受影响
+	Save(ctx context.Context, postID int32, draftParam *param.PostDraft) (*entity.PostDraft, error)
+	GetByPostID(ctx context.Context, postID int32) (*entity.PostDraft, error)
+	// Publish 将草稿原子地替换为文章的已发布内容，记录一个新版本后删除草稿
+	Publish(ctx context.Context, postID int32) (*entity.Post, error)
+	// DeleteByPostID 丢弃草稿，没有草稿时不报错
+	DeleteByPostID(ctx context.Context, postID int32) error
+
+	ConvertToPostDraftDTO(ctx context.Context, draft *entity.PostDraft) *dto.PostDraft
+}
@@ -0,0 +1,23 @@
+package service
+
+import (
+	"context"
+
+	"dash/model/dto"
+	"dash/model/entity"
+)
+
+type PostRevisionService interface {
+	// Create 根据文章当前内容记录一个新版本，需在写入文章的同一事务上下文中调用
+	Create(ctx context.Context, post *entity.Post, remark string) (*entity.PostRevision, error)
+	ListByPostID(ctx context.Context, postID int32) ([]*entity.PostRevision, error)
+	GetByID(ctx context.Context, postID int32, revisionID int32) (*entity.PostRevision, error)
+	DeleteByPostID(ctx context.Context, postID int32) error
+	Diff(ctx context.Context, postID int32, fromID int32, toID int32) (*dto.PostRevisionDiff, error)
+	// Restore 将文章内容回滚到指定版本，回滚本身会记录为一个新版本
+	Restore(ctx context.Context, postID int32, revisionID int32) (*entity.Post, error)
+
+	ConvertToPostRevisionDTO(ctx context.Context, revision *entity.PostRevision) *dto.PostRevision
+	ConvertToPostRevisionDTOs(ctx context.Context, revisions []*entity.PostRevision) []*dto.PostRevision
+	ConvertToPostRevisionDetailDTO(ctx context.Context, revision *entity.PostRevision) *dto.PostRevisionDetail
+}
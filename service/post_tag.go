@@ -12,5 +12,6 @@ type PostTagService interface {
 	ListTagsByPostID(ctx context.Context, postID int32) ([]*entity.Tag, error)
 	ListPostsByTagID(ctx context.Context, tagID int32, status consts.PostStatus) ([]*entity.Post, error)
 	ListTagMapByPostID(ctx context.Context, postIDs []int32) (map[int32][]*entity.Tag, error)
-	ListTagWithPostCount(ctx context.Context, sort *param.Sort) ([]*dto.TagWithPostCount, error)
+	// ListTagWithPostCount excludeCategoryIDs 下的文章不计入文章数
+	ListTagWithPostCount(ctx context.Context, sort *param.Sort, excludeCategoryIDs []int32) ([]*dto.TagWithPostCount, error)
 }
@@ -0,0 +1,17 @@
+package service
+
+import (
+	"context"
+	"dash/model/dto"
+	"dash/model/entity"
+)
+
+type ReactionService interface {
+	// LikePost 点赞文章，同一访客在去重时间内只能点赞一次，返回最新的点赞数
+	LikePost(ctx context.Context, post *entity.Post, visitorID string) (int64, error)
+	// ReactToPost 给文章添加表情回应，返回文章所有表情的最新数量
+	ReactToPost(ctx context.Context, post *entity.Post, reaction string, visitorID string) ([]*dto.Reaction, error)
+	// ListReactionsByPostID 返回所有可用表情在文章上的数量，没有回应的表情数量为 0
+	ListReactionsByPostID(ctx context.Context, postID int32) ([]*dto.Reaction, error)
+	ListAvailableReactions(ctx context.Context) []string
+}
@@ -0,0 +1,25 @@
+package service
+
+import (
+	"context"
+	"dash/model/dto"
+	"dash/model/entity"
+	"dash/model/param"
+)
+
+// RecycleService 回收站。文章和页面移入回收站时只把状态改为 RECYCLE，分类和标签保存快照后删除，恢复时按快照重建
+type RecycleService interface {
+	RecyclePosts(ctx context.Context, postIDs []int32) error
+	RecycleCategory(ctx context.Context, categoryID int32) error
+	RecycleTag(ctx context.Context, tagID int32) error
+	Page(ctx context.Context, query param.RecycleQuery) ([]*entity.RecycleItem, int64, error)
+	// Restore 恢复回收站中的内容，文章和页面恢复为草稿
+	Restore(ctx context.Context, id int32) error
+	// Purge 彻底删除回收站中的内容
+	Purge(ctx context.Context, id int32) error
+	// PurgeAll 清空回收站，返回删除的数量
+	PurgeAll(ctx context.Context) (int64, error)
+	// PurgeExpired 彻底删除超过 recycle_retention_days 的内容，返回删除的数量
+	PurgeExpired(ctx context.Context) (int64, error)
+	ConvertToRecycleItemDTOs(ctx context.Context, items []*entity.RecycleItem) []*dto.RecycleItem
+}
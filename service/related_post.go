@@ -0,0 +1,14 @@
+package service
+
+import (
+	"context"
+	"dash/model/entity"
+)
+
+// RelatedPostService 根据相同的标签、分类和正文的 TF-IDF 相似度推荐相关文章
+type RelatedPostService interface {
+	// ListRelatedPosts 返回已发布的相关文章，按相关度排序，excludeCategoryIDs 下的文章不会返回
+	ListRelatedPosts(ctx context.Context, post *entity.Post, size int, excludeCategoryIDs []int32) ([]*entity.Post, error)
+	// Invalidate 使所有文章的相关文章缓存失效，文章新增、修改、删除或状态变化后调用
+	Invalidate(ctx context.Context)
+}
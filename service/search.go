@@ -0,0 +1,21 @@
+package service
+
+import (
+	"context"
+	"dash/model/dto"
+	"dash/model/entity"
+	"dash/model/param"
+)
+
+// SearchService 基于 post_search_index 倒排索引的全文搜索，只索引文章，不索引页面
+type SearchService interface {
+	// IndexPost 重建一篇文章的索引，需要在保存文章的事务中调用
+	IndexPost(ctx context.Context, post *entity.Post) error
+	DeleteIndexByPostID(ctx context.Context, postID int32) error
+	// RebuildIndex 清空并重建所有文章的索引，返回索引的文章数量
+	RebuildIndex(ctx context.Context) (int64, error)
+	// Search 按相关度排序搜索文章，标题命中的权重更高
+	Search(ctx context.Context, searchQuery param.SearchQuery) ([]*dto.SearchHit, int64, error)
+	// Suggest 返回以 prefix 开头的索引词，按包含该词的文章数量排序
+	Suggest(ctx context.Context, suggestQuery param.SuggestQuery) ([]*dto.SearchSuggestion, error)
+}
@@ -0,0 +1,14 @@
+package service
+
+import (
+	"context"
+	"dash/model/dto"
+)
+
+// SEOService 前端是 SPA，爬虫和链接预览拿不到页面信息，需要在服务端把 title、description 等写入 index.html
+type SEOService interface {
+	// GetPageMeta 按请求路径解析文章、页面、分类和标签，其他路径或游客不可见的内容返回博客的默认信息
+	GetPageMeta(ctx context.Context, path string) (*dto.PageMeta, error)
+	// RenderPage 用 pageMeta 替换 html 中的 <title>，并在 </head> 前加上 meta、canonical 和 JSON-LD
+	RenderPage(ctx context.Context, html []byte, pageMeta *dto.PageMeta) ([]byte, error)
+}
@@ -0,0 +1,28 @@
+package service
+
+import (
+	"context"
+	"dash/consts"
+	"dash/model/dto"
+	"dash/model/entity"
+	"dash/model/param"
+)
+
+// SeriesService 文章系列，一篇文章最多属于一个系列
+type SeriesService interface {
+	Create(ctx context.Context, seriesParam *param.Series) (*entity.Series, error)
+	UpdateByID(ctx context.Context, id int32, seriesParam *param.Series) (*entity.Series, error)
+	DeleteByID(ctx context.Context, id int32) error
+	List(ctx context.Context) ([]*entity.Series, error)
+	GetByID(ctx context.Context, id int32) (*entity.Series, error)
+	GetBySlug(ctx context.Context, slug string) (*entity.Series, error)
+	// GetByPostID 文章不属于任何系列时返回 NoRecord
+	GetByPostID(ctx context.Context, postID int32) (*entity.Series, error)
+	// UpdatePosts 按 postIDs 的顺序设置系列中的文章，文章原来属于其他系列时会移到这个系列
+	UpdatePosts(ctx context.Context, id int32, postIDs []int32) error
+	// ListPosts 按系列中的顺序返回文章，statuses 为空时不过滤状态
+	ListPosts(ctx context.Context, id int32, statuses []consts.PostStatus) ([]*entity.Post, error)
+
+	ConvertToSeriesDTO(ctx context.Context, series *entity.Series) (*dto.Series, error)
+	ConvertToSeriesDTOs(ctx context.Context, series []*entity.Series) ([]*dto.Series, error)
+}
@@ -0,0 +1,24 @@
+package service
+
+import (
+	"context"
+	"dash/consts"
+	"dash/model/entity"
+	"dash/model/param"
+)
+
+// SheetService 独立页面，页面和文章共用 post 表，通过 consts.PostTypeSheet 区分
+type SheetService interface {
+	Create(ctx context.Context, sheetParam *param.Post) (*entity.Post, error)
+	UpdateByID(ctx context.Context, id int32, sheetParam *param.Post) (*entity.Post, error)
+	UpdateStatusByID(ctx context.Context, id int32, status consts.PostStatus) (*entity.Post, error)
+	DeleteByID(ctx context.Context, id int32) error
+	Page(ctx context.Context, sheetQuery param.SheetQuery) ([]*entity.Post, int64, error)
+	GetSheetByID(ctx context.Context, id int32) (*entity.Post, error)
+	// GetSheetByPath 按完整路径查找页面，例如 docs/install 对应 [docs install]
+	GetSheetByPath(ctx context.Context, slugs []string) (*entity.Post, error)
+	// ListChildren 查询子页面，statuses 为空时不限制状态
+	ListChildren(ctx context.Context, parentID int32, statuses []consts.PostStatus) ([]*entity.Post, error)
+	// GetSlugPath 返回从顶级页面到该页面的 slug 列表
+	GetSlugPath(ctx context.Context, sheet *entity.Post) ([]string, error)
+}
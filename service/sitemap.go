@@ -0,0 +1,11 @@
+package service
+
+import "context"
+
+// SitemapService 生成 sitemap.xml 和 robots.txt，结果缓存在 Redis 中，文章、分类、标签或设置变化后重新生成
+type SitemapService interface {
+	// GetSitemap page 为 0 时返回 /sitemap.xml，链接超过 50000 条时为分页索引；page 从 1 开始返回对应分页的链接
+	GetSitemap(ctx context.Context, page int) ([]byte, error)
+	// GetRobots 返回 robots.txt，内容来自 robots_txt 设置，并引用站点地图地址
+	GetRobots(ctx context.Context) ([]byte, error)
+}
@@ -0,0 +1,24 @@
+package service
+
+import (
+	"context"
+	"dash/consts"
+	"dash/model/dto"
+	"dash/model/entity"
+	"dash/model/param"
+	"time"
+)
+
+// SlugHistoryService 记录文章、分类和标签改名前的 slug，旧链接通过它跳转到新地址
+type SlugHistoryService interface {
+	// Record slug 从 oldSlug 改为 newSlug 时调用，需要在修改 slug 的事务中调用
+	Record(ctx context.Context, slugType consts.SlugType, targetID int32, oldSlug string, newSlug string) error
+	// GetTargetID 按旧 slug 查找现在的对象 ID，没有记录时返回 NoRecord
+	GetTargetID(ctx context.Context, slugType consts.SlugType, slug string) (int32, error)
+	DeleteByTargetID(ctx context.Context, slugType consts.SlugType, targetID int32) error
+	Page(ctx context.Context, query param.SlugHistoryQuery) ([]*entity.SlugHistory, int64, error)
+	DeleteByID(ctx context.Context, id int32) error
+	// Prune 删除 before 之前的记录，返回删除的数量
+	Prune(ctx context.Context, before time.Time, slugType *consts.SlugType) (int64, error)
+	ConvertToSlugHistoryDTOs(ctx context.Context, histories []*entity.SlugHistory) []*dto.SlugHistory
+}
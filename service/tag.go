@@ -20,6 +20,7 @@ type TagService interface {
 
 	ConvertToTagDTO(ctx context.Context, tag *entity.Tag) (*dto.Tag, error)
 	ConvertToTagDTOs(ctx context.Context, tags []*entity.Tag) ([]*dto.Tag, error)
-	ConvertToTagWithPostCountDTO(ctx context.Context, tag *entity.Tag) (*dto.TagWithPostCount, error)
-	ConvertToTagWithPostCountDTOs(ctx context.Context, tags []*entity.Tag) ([]*dto.TagWithPostCount, error)
+	// ConvertToTagWithPostCountDTO excludeCategoryIDs 下的文章不计入文章数，用于对游客隐藏私密和加密分类
+	ConvertToTagWithPostCountDTO(ctx context.Context, tag *entity.Tag, excludeCategoryIDs []int32) (*dto.TagWithPostCount, error)
+	ConvertToTagWithPostCountDTOs(ctx context.Context, tags []*entity.Tag, excludeCategoryIDs []int32) ([]*dto.TagWithPostCount, error)
 }
@@ -0,0 +1,22 @@
+package service
+
+import (
+	"context"
+	"dash/consts"
+	"dash/model/entity"
+)
+
+// VisibilityService 公开接口的文章可见性规则：游客只能看到已发布且不在私密、未解锁加密分类下的文章，
+// 已登录的管理员可以看到草稿和私密文章
+type VisibilityService interface {
+	// IsAdmin 当前请求是否来自已登录的管理员
+	IsAdmin(ctx context.Context) bool
+	// VisibleStatuses 访问者可以看到的文章状态
+	VisibleStatuses(ctx context.Context) []consts.PostStatus
+	// FilterStatuses 按访问者身份过滤要查询的文章状态，游客只能查询已发布的文章，管理员不受限制
+	FilterStatuses(ctx context.Context, statuses []*consts.PostStatus) []*consts.PostStatus
+	// CheckPostVisible 访问者不可见时返回 NotFound，避免泄露文章是否存在
+	CheckPostVisible(ctx context.Context, post *entity.Post) error
+	// ListHiddenCategoryIDs 对当前访问者隐藏的分类，管理员没有隐藏分类
+	ListHiddenCategoryIDs(ctx context.Context, unlockTokens []string) ([]int32, error)
+}
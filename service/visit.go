@@ -0,0 +1,11 @@
+package service
+
+import "context"
+
+// VisitService 文章访问量先累加在 Redis 中，由定时任务批量写入数据库
+type VisitService interface {
+	// RecordVisit 记录一次访问，同一访客在去重时间内重复访问和爬虫的访问不计数
+	RecordVisit(ctx context.Context, postID int32, visitorID string, userAgent string)
+	// FlushVisits 把 Redis 中累计的访问量写入数据库，返回写入的访问次数
+	FlushVisits(ctx context.Context) (int64, error)
+}
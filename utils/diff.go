@@ -0,0 +1,186 @@
+package utils
+
+import "strings"
+
+type DiffOp int
+
+const (
+	DiffEqual DiffOp = iota
+	DiffInsert
+	DiffDelete
+)
+
+func (d DiffOp) MarshalJSON() ([]byte, error) {
+	switch d {
+	case DiffInsert:
+		return []byte(`"INSERT"`), nil
+	case DiffDelete:
+		return []byte(`"DELETE"`), nil
+	default:
+		return []byte(`"EQUAL"`), nil
+	}
+}
+
+// DiffLine 行级差异中的一行，OldLine/NewLine 为该行在旧/新文本中的行号（从 1 开始，不存在时为 0）
+type DiffLine struct {
+	Op      DiffOp `json:"op"`
+	OldLine int    `json:"old_line"`
+	NewLine int    `json:"new_line"`
+	Text    string `json:"text"`
+}
+
+// DiffLines 使用 Myers 算法计算两段文本的行级差异，采用线性空间的分治版本，内存只和行数成正比
+func DiffLines(oldText, newText string) []*DiffLine {
+	d := &differ{a: splitLines(oldText), b: splitLines(newText)}
+	d.result = make([]*DiffLine, 0, len(d.a)+len(d.b))
+	d.diff(0, len(d.a), 0, len(d.b))
+	return d.result
+}
+
+type differ struct {
+	a, b   []string
+	result []*DiffLine
+}
+
+// diff 按顺序输出 a[aLo:aHi] 和 b[bLo:bHi] 的差异，先去掉相同的首尾，再从中间蛇形路径处一分为二
+func (d *differ) diff(aLo, aHi, bLo, bHi int) {
+	for aLo < aHi && bLo < bHi && d.a[aLo] == d.b[bLo] {
+		d.appendLine(DiffEqual, aLo, bLo)
+		aLo++
+		bLo++
+	}
+	suffix := 0
+	for aLo < aHi-suffix && bLo < bHi-suffix && d.a[aHi-suffix-1] == d.b[bHi-suffix-1] {
+		suffix++
+	}
+	aHi -= suffix
+	bHi -= suffix
+
+	switch {
+	case aLo == aHi:
+		for y := bLo; y < bHi; y++ {
+			d.appendLine(DiffInsert, 0, y)
+		}
+	case bLo == bHi:
+		for x := aLo; x < aHi; x++ {
+			d.appendLine(DiffDelete, x, 0)
+		}
+	default:
+		x, y, ok := d.bisect(aLo, aHi, bLo, bHi)
+		if ok {
+			d.diff(aLo, x, bLo, y)
+			d.diff(x, aHi, y, bHi)
+		} else {
+			for i := aLo; i < aHi; i++ {
+				d.appendLine(DiffDelete, i, 0)
+			}
+			for i := bLo; i < bHi; i++ {
+				d.appendLine(DiffInsert, 0, i)
+			}
+		}
+	}
+
+	for i := 0; i < suffix; i++ {
+		d.appendLine(DiffEqual, aHi+i, bHi+i)
+	}
+}
+
+// bisect 从两端同时搜索，找到正反两条路径相遇的位置作为分割点，没有相同的行时返回 false。
+// vf/vb[k+offset] 分别记录正向和反向在对角线 k 上能到达的最远距离，只保留当前一步
+func (d *differ) bisect(aLo, aHi, bLo, bHi int) (int, int, bool) {
+	n, m := aHi-aLo, bHi-bLo
+	maxD := (n + m + 1) / 2
+	offset := maxD
+	vf := make([]int, 2*maxD+2)
+	vb := make([]int, 2*maxD+2)
+	for i := range vf {
+		vf[i] = -1
+		vb[i] = -1
+	}
+	vf[offset+1] = 0
+	vb[offset+1] = 0
+	delta := n - m
+	// 总长度为奇数时在正向搜索中检查相遇，偶数时在反向搜索中检查
+	front := delta%2 != 0
+	// 越过边界的对角线不再搜索
+	kfStart, kfEnd, kbStart, kbEnd := 0, 0, 0, 0
+	for step := 0; step < maxD; step++ {
+		for kf := -step + kfStart; kf <= step-kfEnd; kf += 2 {
+			kfOffset := kf + offset
+			var xf int
+			if kf == -step || (kf != step && vf[kfOffset-1] < vf[kfOffset+1]) {
+				xf = vf[kfOffset+1]
+			} else {
+				xf = vf[kfOffset-1] + 1
+			}
+			yf := xf - kf
+			for xf < n && yf < m && d.a[aLo+xf] == d.b[bLo+yf] {
+				xf++
+				yf++
+			}
+			vf[kfOffset] = xf
+			if xf > n {
+				kfEnd += 2
+			} else if yf > m {
+				kfStart += 2
+			} else if front {
+				kbOffset := offset + delta - kf
+				if kbOffset >= 0 && kbOffset < len(vb) && vb[kbOffset] != -1 && xf >= n-vb[kbOffset] {
+					return aLo + xf, bLo + yf, true
+				}
+			}
+		}
+		for kb := -step + kbStart; kb <= step-kbEnd; kb += 2 {
+			kbOffset := kb + offset
+			var xb int
+			if kb == -step || (kb != step && vb[kbOffset-1] < vb[kbOffset+1]) {
+				xb = vb[kbOffset+1]
+			} else {
+				xb = vb[kbOffset-1] + 1
+			}
+			yb := xb - kb
+			for xb < n && yb < m && d.a[aHi-xb-1] == d.b[bHi-yb-1] {
+				xb++
+				yb++
+			}
+			vb[kbOffset] = xb
+			if xb > n {
+				kbEnd += 2
+			} else if yb > m {
+				kbStart += 2
+			} else if !front {
+				kfOffset := offset + delta - kb
+				if kfOffset >= 0 && kfOffset < len(vf) && vf[kfOffset] != -1 {
+					xf := vf[kfOffset]
+					yf := offset + xf - kfOffset
+					if xf >= n-xb {
+						return aLo + xf, bLo + yf, true
+					}
+				}
+			}
+		}
+	}
+	return 0, 0, false
+}
+
+// appendLine x、y 为行在旧/新文本中的下标，不需要的一方忽略
+func (d *differ) appendLine(op DiffOp, x, y int) {
+	line := &DiffLine{Op: op}
+	switch op {
+	case DiffEqual:
+		line.OldLine, line.NewLine, line.Text = x+1, y+1, d.a[x]
+	case DiffInsert:
+		line.NewLine, line.Text = y+1, d.b[y]
+	case DiffDelete:
+		line.OldLine, line.Text = x+1, d.a[x]
+	}
+	d.result = append(d.result, line)
+}
+
+func splitLines(text string) []string {
+	if text == "" {
+		return nil
+	}
+	text = strings.ReplaceAll(text, "\r\n", "\n")
+	return strings.Split(strings.TrimSuffix(text, "\n"), "\n")
+}
@@ -0,0 +1,90 @@
+package utils
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/pelletier/go-toml/v2"
+	"gopkg.in/yaml.v3"
+)
+
+// ParseFrontMatter 解析 Markdown 文件开头的 front matter，支持 Hexo/Jekyll 的 YAML（---）和 Hugo 的 TOML（+++）
+// 没有 front matter 时返回空 map 和原始内容
+func ParseFrontMatter(content []byte) (map[string]interface{}, string, error) {
+	content = bytes.TrimPrefix(content, []byte("\xef\xbb\xbf"))
+	text := strings.ReplaceAll(string(content), "\r\n", "\n")
+	frontMatter := make(map[string]interface{})
+
+	var delimiter string
+	switch {
+	case strings.HasPrefix(text, "---\n"):
+		delimiter = "---"
+	case strings.HasPrefix(text, "+++\n"):
+		delimiter = "+++"
+	default:
+		return frontMatter, text, nil
+	}
+	rest := text[len(delimiter)+1:]
+	var matter, body string
+	if strings.HasPrefix(rest, delimiter+"\n") || rest == delimiter {
+		body = strings.TrimPrefix(rest, delimiter)
+	} else {
+		end := strings.Index(rest, "\n"+delimiter+"\n")
+		if end < 0 {
+			if !strings.HasSuffix(rest, "\n"+delimiter) {
+				return nil, "", fmt.Errorf("front matter is not closed with %s", delimiter)
+			}
+			end = len(rest) - len(delimiter) - 1
+		}
+		matter = rest[:end]
+		body = rest[end+len(delimiter)+1:]
+	}
+	body = strings.TrimPrefix(body, "\n")
+
+	var err error
+	if delimiter == "---" {
+		err = yaml.Unmarshal([]byte(matter), &frontMatter)
+	} else {
+		err = toml.Unmarshal([]byte(matter), &frontMatter)
+	}
+	if err != nil {
+		return nil, "", fmt.Errorf("parse front matter: %w", err)
+	}
+	if frontMatter == nil {
+		frontMatter = make(map[string]interface{})
+	}
+	return frontMatter, body, nil
+}
+
+var frontMatterTimeLayouts = []string{
+	time.RFC3339,
+	"2006-01-02T15:04:05",
+	"2006-01-02 15:04:05 -0700",
+	"2006-01-02 15:04:05 -07:00",
+	"2006-01-02 15:04:05",
+	"2006-01-02 15:04",
+	"2006-01-02",
+	"2006/01/02 15:04:05",
+	"2006/01/02",
+}
+
+// ParseFrontMatterTime 解析 front matter 中的时间，没有时区的时间按 loc 处理
+func ParseFrontMatterTime(value interface{}, loc *time.Location) (time.Time, bool) {
+	switch v := value.(type) {
+	case time.Time:
+		return v, true
+	case nil:
+		return time.Time{}, false
+	}
+	// TOML 的 LocalDate/LocalDateTime 等类型也走字符串解析
+	str := strings.TrimSpace(fmt.Sprint(value))
+	for _, layout := range frontMatterTimeLayouts {
+		t, err := time.ParseInLocation(layout, str, loc)
+		if err == nil {
+			return t, true
+		}
+	}
+	return time.Time{}, false
+}
@@ -0,0 +1,273 @@
+package utils
+
+import (
+	"bytes"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"golang.org/x/net/html"
+	"golang.org/x/net/html/atom"
+)
+
+var (
+	markdownEscaper     = strings.NewReplacer(`\`, `\\`, `*`, `\*`, `_`, `\_`, "`", "\\`", `[`, `\[`, `]`, `\]`, `<`, `&lt;`)
+	multiNewlinePattern = regexp.MustCompile(`[ \t\r\f]*\n[ \t\r\f]*\n\s*`)
+	whitespacePattern   = regexp.MustCompile(`\s+`)
+)
+
+// HTMLToMarkdown 把 HTML 转为 Markdown，表格、iframe、视频等 Markdown 无法表示的内容原样保留为 HTML
+// 没有 <p> 的内容（如 WordPress 导出的正文）按空行分段
+func HTMLToMarkdown(content string) (string, error) {
+	body := &html.Node{Type: html.ElementNode, Data: "body", DataAtom: atom.Body}
+	nodes, err := html.ParseFragment(strings.NewReader(content), body)
+	if err != nil {
+		return "", err
+	}
+	for _, node := range nodes {
+		body.AppendChild(node)
+	}
+	return strings.TrimSpace(convertBlocks(body, "\n\n")) + "\n", nil
+}
+
+func isMarkdownBlock(n *html.Node) bool {
+	if n.Type == html.CommentNode {
+		return true
+	}
+	if n.Type != html.ElementNode {
+		return false
+	}
+	switch n.DataAtom {
+	case atom.P, atom.Div, atom.Section, atom.Article, atom.Header, atom.Footer, atom.Main, atom.Aside, atom.Figure, atom.Figcaption,
+		atom.H1, atom.H2, atom.H3, atom.H4, atom.H5, atom.H6, atom.Blockquote, atom.Ul, atom.Ol, atom.Pre, atom.Hr,
+		atom.Table, atom.Iframe, atom.Video, atom.Audio, atom.Object, atom.Embed, atom.Script, atom.Style, atom.Dl, atom.Form:
+		return true
+	}
+	return false
+}
+
+// convertBlocks 转换 n 的子节点，块级元素之间用 sep 分隔，连续的行内内容合并为段落
+func convertBlocks(n *html.Node, sep string) string {
+	parts := make([]string, 0)
+	inline := strings.Builder{}
+	flush := func() {
+		text := multiNewlinePattern.ReplaceAllString(inline.String(), "\n\n")
+		for _, paragraph := range strings.Split(text, "\n\n") {
+			paragraph = strings.Trim(paragraph, " \n")
+			if paragraph != "" {
+				parts = append(parts, paragraph)
+			}
+		}
+		inline.Reset()
+	}
+	for child := n.FirstChild; child != nil; child = child.NextSibling {
+		if !isMarkdownBlock(child) {
+			inline.WriteString(convertInline(child))
+			continue
+		}
+		flush()
+		if block := convertBlock(child); block != "" {
+			parts = append(parts, block)
+		}
+	}
+	flush()
+	return strings.Join(parts, sep)
+}
+
+func convertBlock(n *html.Node) string {
+	if n.Type == html.CommentNode { // WordPress 的 <!-- wp:paragraph --> 等区块注释
+		return ""
+	}
+	switch n.DataAtom {
+	case atom.H1, atom.H2, atom.H3, atom.H4, atom.H5, atom.H6:
+		level := int(n.Data[1] - '0')
+		text := strings.Join(strings.Fields(convertChildrenInline(n)), " ")
+		if text == "" {
+			return ""
+		}
+		return strings.Repeat("#", level) + " " + text
+	case atom.Blockquote:
+		content := convertBlocks(n, "\n\n")
+		if content == "" {
+			return ""
+		}
+		lines := strings.Split(content, "\n")
+		for i, line := range lines {
+			lines[i] = strings.TrimRight("> "+line, " ")
+		}
+		return strings.Join(lines, "\n")
+	case atom.Ul, atom.Ol:
+		return convertList(n)
+	case atom.Pre:
+		return convertPre(n)
+	case atom.Hr:
+		return "---"
+	case atom.Script, atom.Style:
+		return ""
+	case atom.Table, atom.Iframe, atom.Video, atom.Audio, atom.Object, atom.Embed, atom.Dl, atom.Form:
+		return renderHTML(n)
+	default:
+		return convertBlocks(n, "\n\n")
+	}
+}
+
+func convertList(n *html.Node) string {
+	items := make([]string, 0)
+	index := 1
+	if start, err := strconv.Atoi(getAttr(n, "start")); err == nil {
+		index = start
+	}
+	for child := n.FirstChild; child != nil; child = child.NextSibling {
+		if child.Type != html.ElementNode || child.DataAtom != atom.Li {
+			continue
+		}
+		marker := "- "
+		if n.DataAtom == atom.Ol {
+			marker = strconv.Itoa(index) + ". "
+			index++
+		}
+		content := convertBlocks(child, "\n")
+		lines := strings.Split(content, "\n")
+		for i := 1; i < len(lines); i++ {
+			if lines[i] != "" {
+				lines[i] = strings.Repeat(" ", len(marker)) + lines[i]
+			}
+		}
+		items = append(items, marker+strings.Join(lines, "\n"))
+	}
+	return strings.Join(items, "\n")
+}
+
+func convertPre(n *html.Node) string {
+	lang := languageFromClass(getAttr(n, "class"))
+	if code := n.FirstChild; code != nil && code.NextSibling == nil && code.DataAtom == atom.Code {
+		if codeLang := languageFromClass(getAttr(code, "class")); codeLang != "" {
+			lang = codeLang
+		}
+	}
+	text := strings.TrimRight(textContent(n), "\n")
+	fence := "```"
+	for strings.Contains(text, fence) {
+		fence += "`"
+	}
+	return fence + lang + "\n" + text + "\n" + fence
+}
+
+func languageFromClass(class string) string {
+	for _, c := range strings.Fields(class) {
+		if lang, ok := strings.CutPrefix(c, "language-"); ok {
+			return lang
+		}
+		if lang, ok := strings.CutPrefix(c, "lang-"); ok {
+			return lang
+		}
+	}
+	return ""
+}
+
+func convertChildrenInline(n *html.Node) string {
+	builder := strings.Builder{}
+	for child := n.FirstChild; child != nil; child = child.NextSibling {
+		builder.WriteString(convertInline(child))
+	}
+	return builder.String()
+}
+
+func convertInline(n *html.Node) string {
+	switch n.Type {
+	case html.TextNode:
+		// 空行保留为分段，其他空白合并为一个空格
+		paragraphs := strings.Split(multiNewlinePattern.ReplaceAllString(n.Data, "\n\n"), "\n\n")
+		for i, paragraph := range paragraphs {
+			paragraphs[i] = markdownEscaper.Replace(whitespacePattern.ReplaceAllString(paragraph, " "))
+		}
+		return strings.Join(paragraphs, "\n\n")
+	case html.ElementNode:
+	default:
+		return ""
+	}
+
+	switch n.DataAtom {
+	case atom.Br:
+		return "  \n"
+	case atom.Strong, atom.B:
+		return wrapInline(convertChildrenInline(n), "**")
+	case atom.Em, atom.I:
+		return wrapInline(convertChildrenInline(n), "*")
+	case atom.Del, atom.S, atom.Strike:
+		return wrapInline(convertChildrenInline(n), "~~")
+	case atom.Code, atom.Kbd, atom.Tt:
+		text := textContent(n)
+		if text == "" {
+			return ""
+		}
+		if strings.Contains(text, "`") {
+			return "`` " + text + " ``"
+		}
+		return "`" + text + "`"
+	case atom.A:
+		text := convertChildrenInline(n)
+		href := getAttr(n, "href")
+		if href == "" {
+			return text
+		}
+		if strings.TrimSpace(text) == "" {
+			text = href
+		}
+		if title := getAttr(n, "title"); title != "" {
+			return "[" + text + "](" + href + ` "` + strings.ReplaceAll(title, `"`, `\"`) + `")`
+		}
+		return "[" + text + "](" + href + ")"
+	case atom.Img:
+		src := getAttr(n, "src")
+		if src == "" {
+			return ""
+		}
+		return "![" + markdownEscaper.Replace(getAttr(n, "alt")) + "](" + src + ")"
+	case atom.Script, atom.Style:
+		return ""
+	default:
+		return convertChildrenInline(n)
+	}
+}
+
+// wrapInline 加上强调标记，标记需要紧贴文字，首尾空白移到标记外
+func wrapInline(text string, marker string) string {
+	trimmed := strings.TrimSpace(text)
+	if trimmed == "" {
+		return text
+	}
+	start := strings.Index(text, trimmed)
+	return text[:start] + marker + trimmed + marker + text[start+len(trimmed):]
+}
+
+func textContent(n *html.Node) string {
+	if n.Type == html.TextNode {
+		return n.Data
+	}
+	if n.DataAtom == atom.Br {
+		return "\n"
+	}
+	builder := strings.Builder{}
+	for child := n.FirstChild; child != nil; child = child.NextSibling {
+		builder.WriteString(textContent(child))
+	}
+	return builder.String()
+}
+
+func getAttr(n *html.Node, key string) string {
+	for _, attr := range n.Attr {
+		if attr.Key == key {
+			return attr.Val
+		}
+	}
+	return ""
+}
+
+func renderHTML(n *html.Node) string {
+	buf := &bytes.Buffer{}
+	if err := html.Render(buf, n); err != nil {
+		return ""
+	}
+	return buf.String()
+}
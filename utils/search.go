@@ -0,0 +1,168 @@
+package utils
+
+import (
+	"html"
+	"sort"
+	"strings"
+	"unicode"
+)
+
+// maxTermLength 索引词的最大长度（字节），超出的部分会被截断
+const maxTermLength = 64
+
+// isCJK 中日韩文字没有空格分词，按单字和相邻两字切分
+func isCJK(r rune) bool {
+	return unicode.Is(unicode.Han, r) || unicode.Is(unicode.Hiragana, r) || unicode.Is(unicode.Katakana, r) || unicode.Is(unicode.Hangul, r)
+}
+
+func truncateTerm(term string) string {
+	if len(term) <= maxTermLength {
+		return term
+	}
+	runes := []rune(term)
+	for len(string(runes)) > maxTermLength {
+		runes = runes[:len(runes)-1]
+	}
+	return string(runes)
+}
+
+// tokenize 把文本切分为小写的词，英文和数字按连续字母切分，中日韩文字交给 cjk 处理连续的一段
+func tokenize(text string, word func(string), cjk func([]rune)) {
+	var wordRunes, cjkRunes []rune
+	flushWord := func() {
+		if len(wordRunes) > 0 {
+			word(truncateTerm(string(wordRunes)))
+			wordRunes = wordRunes[:0]
+		}
+	}
+	flushCJK := func() {
+		if len(cjkRunes) > 0 {
+			cjk(cjkRunes)
+			cjkRunes = cjkRunes[:0]
+		}
+	}
+	for _, r := range text {
+		r = unicode.ToLower(r)
+		switch {
+		case isCJK(r):
+			flushWord()
+			cjkRunes = append(cjkRunes, r)
+		case unicode.IsLetter(r) || unicode.IsDigit(r):
+			flushCJK()
+			wordRunes = append(wordRunes, r)
+		default:
+			flushWord()
+			flushCJK()
+		}
+	}
+	flushWord()
+	flushCJK()
+}
+
+// IndexTerms 返回文本中每个索引词出现的次数，中日韩文字同时索引单字和相邻两字
+func IndexTerms(text string) map[string]int {
+	terms := make(map[string]int)
+	tokenize(text, func(word string) {
+		terms[word]++
+	}, func(runes []rune) {
+		for i := range runes {
+			terms[string(runes[i])]++
+			if i+1 < len(runes) {
+				terms[string(runes[i:i+2])]++
+			}
+		}
+	})
+	return terms
+}
+
+// QueryTerms 把搜索关键词切分为去重后的查询词，连续的中日韩文字按相邻两字切分，单独一个字时按单字查询
+func QueryTerms(keyword string) []string {
+	terms := make([]string, 0)
+	seen := make(map[string]struct{})
+	add := func(term string) {
+		if _, ok := seen[term]; !ok {
+			seen[term] = struct{}{}
+			terms = append(terms, term)
+		}
+	}
+	tokenize(keyword, add, func(runes []rune) {
+		if len(runes) == 1 {
+			add(string(runes))
+			return
+		}
+		for i := 0; i+1 < len(runes); i++ {
+			add(string(runes[i : i+2]))
+		}
+	})
+	return terms
+}
+
+// Highlight 转义文本并用 <mark> 标出所有查询词，maxRunes 大于 0 时截取第一个命中位置附近的片段
+func Highlight(text string, terms []string, maxRunes int) string {
+	runes := []rune(text)
+	lower := make([]rune, len(runes))
+	for i, r := range runes {
+		lower[i] = unicode.ToLower(r)
+	}
+
+	type span struct{ start, end int }
+	spans := make([]span, 0)
+	for _, term := range terms {
+		termRunes := []rune(term)
+		if len(termRunes) == 0 {
+			continue
+		}
+		for i := 0; i+len(termRunes) <= len(lower); i++ {
+			if string(lower[i:i+len(termRunes)]) == term {
+				spans = append(spans, span{i, i + len(termRunes)})
+			}
+		}
+	}
+	sort.Slice(spans, func(i, j int) bool {
+		return spans[i].start < spans[j].start
+	})
+	merged := make([]span, 0, len(spans))
+	for _, s := range spans {
+		if n := len(merged); n > 0 && s.start <= merged[n-1].end {
+			merged[n-1].end = max(merged[n-1].end, s.end)
+			continue
+		}
+		merged = append(merged, s)
+	}
+
+	start, end := 0, len(runes)
+	if maxRunes > 0 && len(runes) > maxRunes {
+		if len(merged) > 0 {
+			start = max(0, merged[0].start-maxRunes/4)
+		}
+		end = min(len(runes), start+maxRunes)
+		start = max(0, end-maxRunes)
+	}
+
+	builder := strings.Builder{}
+	if start > 0 {
+		builder.WriteString("…")
+	}
+	cursor := start
+	for _, s := range merged {
+		if s.end <= start || s.start >= end {
+			continue
+		}
+		s.start, s.end = max(s.start, start), min(s.end, end)
+		builder.WriteString(html.EscapeString(string(runes[cursor:s.start])))
+		builder.WriteString("<mark>")
+		builder.WriteString(html.EscapeString(string(runes[s.start:s.end])))
+		builder.WriteString("</mark>")
+		cursor = s.end
+	}
+	builder.WriteString(html.EscapeString(string(runes[cursor:end])))
+	if end < len(runes) {
+		builder.WriteString("…")
+	}
+	return builder.String()
+}
+
+// HTMLToText 去掉 HTML 标签并还原转义字符，用于建立索引和生成摘要
+func HTMLToText(htmlContent string) string {
+	return html.UnescapeString(CleanHTMLTag(htmlContent))
+}
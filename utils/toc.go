@@ -0,0 +1,183 @@
+package utils
+
+import (
+	"bytes"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"golang.org/x/net/html"
+	"golang.org/x/net/html/atom"
+)
+
+// TocItem 文章目录中的一个标题
+type TocItem struct {
+	Level    int        `json:"level"`
+	Text     string     `json:"text"`
+	ID       string     `json:"id"`
+	Children []*TocItem `json:"children"`
+}
+
+// HeadingIDs 为标题生成文档内唯一的锚点 id，保留中文等非 ASCII 字符
+type HeadingIDs struct {
+	values map[string]bool
+}
+
+func NewHeadingIDs() *HeadingIDs {
+	return &HeadingIDs{values: map[string]bool{}}
+}
+
+func (h *HeadingIDs) Generate(text string) string {
+	var builder strings.Builder
+	lastDash := true
+	for _, r := range strings.TrimSpace(text) {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) || r == '_' {
+			builder.WriteRune(unicode.ToLower(r))
+			lastDash = false
+		} else if !lastDash {
+			builder.WriteByte('-')
+			lastDash = true
+		}
+	}
+	id := strings.TrimRight(builder.String(), "-")
+	if id == "" {
+		id = "heading"
+	}
+	if !h.values[id] {
+		h.values[id] = true
+		return id
+	}
+	for i := 1; ; i++ {
+		newID := id + "-" + strconv.Itoa(i)
+		if !h.values[newID] {
+			h.values[newID] = true
+			return newID
+		}
+	}
+}
+
+func (h *HeadingIDs) Put(id string) {
+	h.values[id] = true
+}
+
+var headingLevels = map[atom.Atom]int{
+	atom.H1: 1, atom.H2: 2, atom.H3: 3, atom.H4: 4, atom.H5: 5, atom.H6: 6,
+}
+
+// BuildToc 从 HTML 中提取嵌套的标题目录，并为缺少 id 的标题补上 id，返回补全后的 HTML
+func BuildToc(htmlContent string) (string, []*TocItem) {
+	if htmlContent == "" {
+		return htmlContent, make([]*TocItem, 0)
+	}
+
+	// existing ids are reserved first so generated ones never collide with them
+	ids := NewHeadingIDs()
+	tokenizer := html.NewTokenizer(strings.NewReader(htmlContent))
+	for tt := tokenizer.Next(); tt != html.ErrorToken; tt = tokenizer.Next() {
+		if tt == html.StartTagToken || tt == html.SelfClosingTagToken {
+			if id, ok := tokenAttr(tokenizer.Token(), "id"); ok {
+				ids.Put(id)
+			}
+		}
+	}
+
+	var out bytes.Buffer
+	headings := make([]*TocItem, 0)
+	var (
+		current     *TocItem
+		startTag    html.Token
+		hasID       bool
+		startRaw    []byte
+		inner       bytes.Buffer
+		text        strings.Builder
+		anchorDepth int
+	)
+	tokenizer = html.NewTokenizer(strings.NewReader(htmlContent))
+	for tt := tokenizer.Next(); tt != html.ErrorToken; tt = tokenizer.Next() {
+		// Token unescapes text and attributes in place, so the raw bytes are copied first
+		raw := append([]byte(nil), tokenizer.Raw()...)
+		if current == nil {
+			if tt == html.StartTagToken {
+				token := tokenizer.Token()
+				if level, ok := headingLevels[token.DataAtom]; ok {
+					current = &TocItem{Level: level}
+					startTag = token
+					current.ID, hasID = tokenAttr(token, "id")
+					startRaw = append(startRaw[:0], raw...)
+					inner.Reset()
+					text.Reset()
+					anchorDepth = 0
+					continue
+				}
+			}
+			out.Write(raw)
+			continue
+		}
+
+		token := tokenizer.Token()
+		switch {
+		case tt == html.EndTagToken && token.DataAtom == startTag.DataAtom:
+			current.Text = strings.TrimSpace(text.String())
+			if hasID {
+				out.Write(startRaw)
+			} else {
+				current.ID = ids.Generate(current.Text)
+				startTag.Attr = append(startTag.Attr, html.Attribute{Key: "id", Val: current.ID})
+				out.WriteString(startTag.String())
+			}
+			out.Write(inner.Bytes())
+			out.Write(raw)
+			headings = append(headings, current)
+			current = nil
+			continue
+		case tt == html.StartTagToken && token.DataAtom == atom.A:
+			// the anchor link rendered for markdown headings is not part of the title
+			if class, _ := tokenAttr(token, "class"); anchorDepth > 0 || strings.Contains(class, "heading-anchor") {
+				anchorDepth++
+			}
+		case tt == html.EndTagToken && token.DataAtom == atom.A:
+			if anchorDepth > 0 {
+				anchorDepth--
+			}
+		case tt == html.TextToken:
+			if anchorDepth == 0 {
+				text.WriteString(token.Data)
+			}
+		}
+		inner.Write(raw)
+	}
+	// an unclosed heading is written back untouched
+	if current != nil {
+		out.Write(startRaw)
+		out.Write(inner.Bytes())
+	}
+	return out.String(), nestToc(headings)
+}
+
+func nestToc(headings []*TocItem) []*TocItem {
+	root := make([]*TocItem, 0)
+	stack := make([]*TocItem, 0)
+	for _, heading := range headings {
+		heading.Children = make([]*TocItem, 0)
+		for len(stack) > 0 && stack[len(stack)-1].Level >= heading.Level {
+			stack = stack[:len(stack)-1]
+		}
+		if len(stack) == 0 {
+			root = append(root, heading)
+		} else {
+			parent := stack[len(stack)-1]
+			parent.Children = append(parent.Children, heading)
+		}
+		stack = append(stack, heading)
+	}
+	return root
+}
+
+func tokenAttr(token html.Token, key string) (string, bool) {
+	for _, attr := range token.Attr {
+		if attr.Key == key {
+			return attr.Val, true
+		}
+	}
+	return "", false
+}
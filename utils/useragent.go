@@ -0,0 +1,23 @@
+package utils
+
+import "strings"
+
+// botKeywords 常见爬虫和命令行工具 User-Agent 中包含的关键字
+var botKeywords = []string{
+	"bot", "spider", "crawl", "slurp", "curl", "wget", "python", "go-http-client", "java/",
+	"okhttp", "headless", "lighthouse", "facebookexternalhit", "preview", "monitor", "scan",
+}
+
+// IsBot 根据 User-Agent 粗略判断请求是否来自爬虫，空 User-Agent 也视为爬虫
+func IsBot(userAgent string) bool {
+	userAgent = strings.ToLower(strings.TrimSpace(userAgent))
+	if userAgent == "" {
+		return true
+	}
+	for _, keyword := range botKeywords {
+		if strings.Contains(userAgent, keyword) {
+			return true
+		}
+	}
+	return false
+}
@@ -16,6 +16,8 @@ const (
 	StatusUnauthorized        = http.StatusUnauthorized
 	StatusForbidden           = http.StatusForbidden
 	StatusNotFound            = http.StatusNotFound
+	StatusConflict            = http.StatusConflict
+	StatusTooManyRequests     = http.StatusTooManyRequests
 )
 
 type ErrorType uint